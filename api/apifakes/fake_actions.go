@@ -0,0 +1,308 @@
+// This file was generated by counterfeiter
+package apifakes
+
+import (
+	"sync"
+
+	"github.com/mdelillo/claimer/bot/commands"
+	"github.com/mdelillo/claimer/locker"
+)
+
+type FakeActions struct {
+	ClaimStub        func(pools []string, username, message string) (commands.Result, error)
+	claimMutex       sync.RWMutex
+	claimArgsForCall []struct {
+		pools    []string
+		username string
+		message  string
+	}
+	claimReturns struct {
+		result1 commands.Result
+		result2 error
+	}
+	claimReturnsOnCall map[int]struct {
+		result1 commands.Result
+		result2 error
+	}
+	CreateStub        func(pool, username string, metadata locker.Metadata) (commands.Result, error)
+	createMutex       sync.RWMutex
+	createArgsForCall []struct {
+		pool     string
+		username string
+		metadata locker.Metadata
+	}
+	createReturns struct {
+		result1 commands.Result
+		result2 error
+	}
+	createReturnsOnCall map[int]struct {
+		result1 commands.Result
+		result2 error
+	}
+	DestroyStub        func(pool, username string) (commands.Result, error)
+	destroyMutex       sync.RWMutex
+	destroyArgsForCall []struct {
+		pool     string
+		username string
+	}
+	destroyReturns struct {
+		result1 commands.Result
+		result2 error
+	}
+	destroyReturnsOnCall map[int]struct {
+		result1 commands.Result
+		result2 error
+	}
+	ReleaseStub        func(pools []string, username string) (commands.Result, error)
+	releaseMutex       sync.RWMutex
+	releaseArgsForCall []struct {
+		pools    []string
+		username string
+	}
+	releaseReturns struct {
+		result1 commands.Result
+		result2 error
+	}
+	releaseReturnsOnCall map[int]struct {
+		result1 commands.Result
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeActions) Claim(pools []string, username string, message string) (commands.Result, error) {
+	fake.claimMutex.Lock()
+	ret, specificReturn := fake.claimReturnsOnCall[len(fake.claimArgsForCall)]
+	fake.claimArgsForCall = append(fake.claimArgsForCall, struct {
+		pools    []string
+		username string
+		message  string
+	}{pools, username, message})
+	fake.recordInvocation("Claim", []interface{}{pools, username, message})
+	fake.claimMutex.Unlock()
+	if fake.ClaimStub != nil {
+		return fake.ClaimStub(pools, username, message)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.claimReturns.result1, fake.claimReturns.result2
+}
+
+func (fake *FakeActions) ClaimCallCount() int {
+	fake.claimMutex.RLock()
+	defer fake.claimMutex.RUnlock()
+	return len(fake.claimArgsForCall)
+}
+
+func (fake *FakeActions) ClaimArgsForCall(i int) ([]string, string, string) {
+	fake.claimMutex.RLock()
+	defer fake.claimMutex.RUnlock()
+	return fake.claimArgsForCall[i].pools, fake.claimArgsForCall[i].username, fake.claimArgsForCall[i].message
+}
+
+func (fake *FakeActions) ClaimReturns(result1 commands.Result, result2 error) {
+	fake.ClaimStub = nil
+	fake.claimReturns = struct {
+		result1 commands.Result
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeActions) ClaimReturnsOnCall(i int, result1 commands.Result, result2 error) {
+	fake.ClaimStub = nil
+	if fake.claimReturnsOnCall == nil {
+		fake.claimReturnsOnCall = make(map[int]struct {
+			result1 commands.Result
+			result2 error
+		})
+	}
+	fake.claimReturnsOnCall[i] = struct {
+		result1 commands.Result
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeActions) Create(pool string, username string, metadata locker.Metadata) (commands.Result, error) {
+	fake.createMutex.Lock()
+	ret, specificReturn := fake.createReturnsOnCall[len(fake.createArgsForCall)]
+	fake.createArgsForCall = append(fake.createArgsForCall, struct {
+		pool     string
+		username string
+		metadata locker.Metadata
+	}{pool, username, metadata})
+	fake.recordInvocation("Create", []interface{}{pool, username, metadata})
+	fake.createMutex.Unlock()
+	if fake.CreateStub != nil {
+		return fake.CreateStub(pool, username, metadata)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.createReturns.result1, fake.createReturns.result2
+}
+
+func (fake *FakeActions) CreateCallCount() int {
+	fake.createMutex.RLock()
+	defer fake.createMutex.RUnlock()
+	return len(fake.createArgsForCall)
+}
+
+func (fake *FakeActions) CreateArgsForCall(i int) (string, string, locker.Metadata) {
+	fake.createMutex.RLock()
+	defer fake.createMutex.RUnlock()
+	return fake.createArgsForCall[i].pool, fake.createArgsForCall[i].username, fake.createArgsForCall[i].metadata
+}
+
+func (fake *FakeActions) CreateReturns(result1 commands.Result, result2 error) {
+	fake.CreateStub = nil
+	fake.createReturns = struct {
+		result1 commands.Result
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeActions) CreateReturnsOnCall(i int, result1 commands.Result, result2 error) {
+	fake.CreateStub = nil
+	if fake.createReturnsOnCall == nil {
+		fake.createReturnsOnCall = make(map[int]struct {
+			result1 commands.Result
+			result2 error
+		})
+	}
+	fake.createReturnsOnCall[i] = struct {
+		result1 commands.Result
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeActions) Destroy(pool string, username string) (commands.Result, error) {
+	fake.destroyMutex.Lock()
+	ret, specificReturn := fake.destroyReturnsOnCall[len(fake.destroyArgsForCall)]
+	fake.destroyArgsForCall = append(fake.destroyArgsForCall, struct {
+		pool     string
+		username string
+	}{pool, username})
+	fake.recordInvocation("Destroy", []interface{}{pool, username})
+	fake.destroyMutex.Unlock()
+	if fake.DestroyStub != nil {
+		return fake.DestroyStub(pool, username)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.destroyReturns.result1, fake.destroyReturns.result2
+}
+
+func (fake *FakeActions) DestroyCallCount() int {
+	fake.destroyMutex.RLock()
+	defer fake.destroyMutex.RUnlock()
+	return len(fake.destroyArgsForCall)
+}
+
+func (fake *FakeActions) DestroyArgsForCall(i int) (string, string) {
+	fake.destroyMutex.RLock()
+	defer fake.destroyMutex.RUnlock()
+	return fake.destroyArgsForCall[i].pool, fake.destroyArgsForCall[i].username
+}
+
+func (fake *FakeActions) DestroyReturns(result1 commands.Result, result2 error) {
+	fake.DestroyStub = nil
+	fake.destroyReturns = struct {
+		result1 commands.Result
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeActions) DestroyReturnsOnCall(i int, result1 commands.Result, result2 error) {
+	fake.DestroyStub = nil
+	if fake.destroyReturnsOnCall == nil {
+		fake.destroyReturnsOnCall = make(map[int]struct {
+			result1 commands.Result
+			result2 error
+		})
+	}
+	fake.destroyReturnsOnCall[i] = struct {
+		result1 commands.Result
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeActions) Release(pools []string, username string) (commands.Result, error) {
+	fake.releaseMutex.Lock()
+	ret, specificReturn := fake.releaseReturnsOnCall[len(fake.releaseArgsForCall)]
+	fake.releaseArgsForCall = append(fake.releaseArgsForCall, struct {
+		pools    []string
+		username string
+	}{pools, username})
+	fake.recordInvocation("Release", []interface{}{pools, username})
+	fake.releaseMutex.Unlock()
+	if fake.ReleaseStub != nil {
+		return fake.ReleaseStub(pools, username)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.releaseReturns.result1, fake.releaseReturns.result2
+}
+
+func (fake *FakeActions) ReleaseCallCount() int {
+	fake.releaseMutex.RLock()
+	defer fake.releaseMutex.RUnlock()
+	return len(fake.releaseArgsForCall)
+}
+
+func (fake *FakeActions) ReleaseArgsForCall(i int) ([]string, string) {
+	fake.releaseMutex.RLock()
+	defer fake.releaseMutex.RUnlock()
+	return fake.releaseArgsForCall[i].pools, fake.releaseArgsForCall[i].username
+}
+
+func (fake *FakeActions) ReleaseReturns(result1 commands.Result, result2 error) {
+	fake.ReleaseStub = nil
+	fake.releaseReturns = struct {
+		result1 commands.Result
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeActions) ReleaseReturnsOnCall(i int, result1 commands.Result, result2 error) {
+	fake.ReleaseStub = nil
+	if fake.releaseReturnsOnCall == nil {
+		fake.releaseReturnsOnCall = make(map[int]struct {
+			result1 commands.Result
+			result2 error
+		})
+	}
+	fake.releaseReturnsOnCall[i] = struct {
+		result1 commands.Result
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeActions) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.claimMutex.RLock()
+	defer fake.claimMutex.RUnlock()
+	fake.createMutex.RLock()
+	defer fake.createMutex.RUnlock()
+	fake.destroyMutex.RLock()
+	defer fake.destroyMutex.RUnlock()
+	fake.releaseMutex.RLock()
+	defer fake.releaseMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeActions) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
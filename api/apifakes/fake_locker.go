@@ -0,0 +1,87 @@
+// This file was generated by counterfeiter
+package apifakes
+
+import (
+	"sync"
+
+	clocker "github.com/mdelillo/claimer/locker"
+)
+
+type FakeLocker struct {
+	StatusStub        func() ([]clocker.Lock, error)
+	statusMutex       sync.RWMutex
+	statusArgsForCall []struct{}
+	statusReturns     struct {
+		result1 []clocker.Lock
+		result2 error
+	}
+	statusReturnsOnCall map[int]struct {
+		result1 []clocker.Lock
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeLocker) Status() ([]clocker.Lock, error) {
+	fake.statusMutex.Lock()
+	ret, specificReturn := fake.statusReturnsOnCall[len(fake.statusArgsForCall)]
+	fake.statusArgsForCall = append(fake.statusArgsForCall, struct{}{})
+	fake.recordInvocation("Status", []interface{}{})
+	fake.statusMutex.Unlock()
+	if fake.StatusStub != nil {
+		return fake.StatusStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.statusReturns.result1, fake.statusReturns.result2
+}
+
+func (fake *FakeLocker) StatusCallCount() int {
+	fake.statusMutex.RLock()
+	defer fake.statusMutex.RUnlock()
+	return len(fake.statusArgsForCall)
+}
+
+func (fake *FakeLocker) StatusReturns(result1 []clocker.Lock, result2 error) {
+	fake.StatusStub = nil
+	fake.statusReturns = struct {
+		result1 []clocker.Lock
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocker) StatusReturnsOnCall(i int, result1 []clocker.Lock, result2 error) {
+	fake.StatusStub = nil
+	if fake.statusReturnsOnCall == nil {
+		fake.statusReturnsOnCall = make(map[int]struct {
+			result1 []clocker.Lock
+			result2 error
+		})
+	}
+	fake.statusReturnsOnCall[i] = struct {
+		result1 []clocker.Lock
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocker) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.statusMutex.RLock()
+	defer fake.statusMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeLocker) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,76 @@
+// Package api serves the state of the pools over HTTP, for dashboards and
+// scripts which would otherwise scrape the chat responses.
+package api
+
+import (
+	"time"
+
+	clocker "github.com/mdelillo/claimer/locker"
+)
+
+// Pool is the machine-readable representation of a locker.Lock. Times are
+// in RFC 3339 and are omitted when they do not apply.
+type Pool struct {
+	Name         string        `json:"name"`
+	Claimed      bool          `json:"claimed"`
+	Owner        string        `json:"owner,omitempty"`
+	Since        *time.Time    `json:"since,omitempty"`
+	Message      string        `json:"message,omitempty"`
+	Expires      *time.Time    `json:"expires,omitempty"`
+	Description  string        `json:"description,omitempty"`
+	Team         string        `json:"team,omitempty"`
+	Tags         []string      `json:"tags"`
+	Links        []string      `json:"links,omitempty"`
+	Disabled     *Disabled     `json:"disabled,omitempty"`
+	Reservations []Reservation `json:"reservations,omitempty"`
+}
+
+type Disabled struct {
+	By     string    `json:"by"`
+	Reason string    `json:"reason,omitempty"`
+	Since  time.Time `json:"since"`
+}
+
+type Reservation struct {
+	Owner   string    `json:"owner"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Message string    `json:"message,omitempty"`
+}
+
+// Pools converts locks to their representation. Tags are always a list, so
+// that consumers do not have to handle null.
+func Pools(locks []clocker.Lock) []Pool {
+	pools := []Pool{}
+	for _, lock := range locks {
+		pool := Pool{
+			Name:        lock.Name,
+			Claimed:     lock.Claimed,
+			Owner:       lock.Owner,
+			Message:     lock.Message,
+			Description: lock.Description,
+			Team:        lock.Team,
+			Tags:        append([]string{}, lock.Tags...),
+			Links:       lock.Links,
+		}
+		if since, err := time.Parse(clocker.DateFormat, lock.Date); err == nil {
+			pool.Since = &since
+		}
+		if !lock.Expires.IsZero() {
+			expires := lock.Expires
+			pool.Expires = &expires
+		}
+		if lock.Maintenance != nil {
+			pool.Disabled = &Disabled{
+				By:     lock.Maintenance.By,
+				Reason: lock.Maintenance.Reason,
+				Since:  lock.Maintenance.Since,
+			}
+		}
+		for _, r := range lock.Reservations {
+			pool.Reservations = append(pool.Reservations, Reservation{Owner: r.Owner, Start: r.Start, End: r.End, Message: r.Message})
+		}
+		pools = append(pools, pool)
+	}
+	return pools
+}
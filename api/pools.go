@@ -0,0 +1,177 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/mdelillo/claimer/bot/commands"
+	clocker "github.com/mdelillo/claimer/locker"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+//go:generate counterfeiter . actions
+type actions interface {
+	Claim(pools []string, username, message string) (commands.Result, error)
+	Release(pools []string, username string) (commands.Result, error)
+	Create(pool, username string, metadata clocker.Metadata) (commands.Result, error)
+	Destroy(pool, username string) (commands.Result, error)
+}
+
+// Result is the body of the responses to requests changing pools. Message is
+// what claimer would have said in chat.
+type Result struct {
+	Message string `json:"message,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type claimRequest struct {
+	Message string `json:"message"`
+}
+
+type createRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"`
+}
+
+// LoadTokens reads a yaml file mapping API tokens to the usernames that
+// requests made with them act as
+func LoadTokens(path string) (map[string]string, error) {
+	var tokens map[string]string
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read tokens file")
+	}
+	if err := yaml.Unmarshal(contents, &tokens); err != nil {
+		return nil, errors.Wrap(err, "failed to parse tokens file")
+	}
+	return tokens, nil
+}
+
+// pools serves GET and POST /pools
+func (s *server) pools(w http.ResponseWriter, r *http.Request) {
+	username, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.status(w, r)
+	case http.MethodPost:
+		var body createRequest
+		if !s.decode(w, r, &body) {
+			return
+		}
+		if !validName(body.Name) {
+			s.writeJson(w, http.StatusBadRequest, Result{Error: "must specify a valid name for the pool"})
+			return
+		}
+		result, err := s.actions.Create(body.Name, username, clocker.Metadata{Description: body.Description, Tags: body.Tags})
+		s.respond(w, "create", body.Name, username, http.StatusCreated, result, err)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// pool serves DELETE /pools/{name} and POST /pools/{name}/claim and
+// /pools/{name}/release. Names can contain slashes, e.g. aws/us-east/env-1.
+func (s *server) pool(w http.ResponseWriter, r *http.Request) {
+	username, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/pools/")
+	action := ""
+	for _, a := range []string{"claim", "release"} {
+		if strings.HasSuffix(path, "/"+a) {
+			path, action = strings.TrimSuffix(path, "/"+a), a
+		}
+	}
+	if !validName(path) {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch {
+	case action == "" && r.Method == http.MethodDelete:
+		result, err := s.actions.Destroy(path, username)
+		s.respond(w, "destroy", path, username, http.StatusOK, result, err)
+	case action == "":
+		w.Header().Set("Allow", http.MethodDelete)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	case r.Method != http.MethodPost:
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	case action == "claim":
+		var body claimRequest
+		if !s.decode(w, r, &body) {
+			return
+		}
+		result, err := s.actions.Claim([]string{path}, username, body.Message)
+		s.respond(w, "claim", path, username, http.StatusOK, result, err)
+	default:
+		result, err := s.actions.Release([]string{path}, username)
+		s.respond(w, "release", path, username, http.StatusOK, result, err)
+	}
+}
+
+// respond writes the result of an action. A refused action responds with
+// what claimer would have said in chat as the error: 404 if the pool does
+// not exist, 400 if the request is missing something and 409 otherwise.
+func (s *server) respond(w http.ResponseWriter, action, pool, username string, code int, result commands.Result, err error) {
+	if err != nil {
+		s.logger.Errorf("failed to %s %s: %s", action, pool, err)
+		s.writeJson(w, http.StatusInternalServerError, Result{Error: "failed to " + action + " " + pool})
+		return
+	}
+
+	switch result.Refusal {
+	case "":
+		s.logger.Infof("%s: %s through the API", username, result.Response)
+		s.writeJson(w, code, Result{Message: result.Response})
+	case commands.RefusedNotFound:
+		s.writeJson(w, http.StatusNotFound, Result{Error: result.Response})
+	case commands.RefusedInvalid:
+		s.writeJson(w, http.StatusBadRequest, Result{Error: result.Response})
+	default:
+		s.writeJson(w, http.StatusConflict, Result{Error: result.Response})
+	}
+}
+
+// authenticate returns the user that the bearer token in the request belongs
+// to, or responds with 401 if there is none
+func (s *server) authenticate(w http.ResponseWriter, r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	if strings.HasPrefix(header, "Bearer ") {
+		if username, ok := s.tokens[strings.TrimPrefix(header, "Bearer ")]; ok && username != "" {
+			return username, true
+		}
+	}
+	w.Header().Set("WWW-Authenticate", `Bearer realm="claimer"`)
+	s.writeJson(w, http.StatusUnauthorized, Result{Error: "a valid API token is required"})
+	return "", false
+}
+
+func (s *server) decode(w http.ResponseWriter, r *http.Request, body interface{}) bool {
+	if r.ContentLength == 0 {
+		return true
+	}
+	if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+		s.writeJson(w, http.StatusBadRequest, Result{Error: "failed to parse request body: " + err.Error()})
+		return false
+	}
+	return true
+}
+
+// validName checks that a pool name is a single word which is not an option,
+// as pool names are in chat, and a path which stays inside the repo
+func validName(name string) bool {
+	return len(strings.Fields(name)) == 1 && !strings.HasPrefix(name, "-") && clocker.ValidPoolName(name)
+}
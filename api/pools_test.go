@@ -0,0 +1,293 @@
+package api_test
+
+import (
+	. "github.com/mdelillo/claimer/api"
+
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mdelillo/claimer/api/apifakes"
+	"github.com/mdelillo/claimer/bot/commands"
+	clocker "github.com/mdelillo/claimer/locker"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("Pools", func() {
+	var (
+		locker  *apifakes.FakeLocker
+		actions *apifakes.FakeActions
+		logger  *logrus.Logger
+		logHook *logrustest.Hook
+		handler http.Handler
+	)
+
+	request := func(method, path, token, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		handler.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	BeforeEach(func() {
+		locker = new(apifakes.FakeLocker)
+		actions = new(apifakes.FakeActions)
+		logger, logHook = logrustest.NewNullLogger()
+		handler = NewServer(locker, actions, map[string]string{"some-token": "some-user"}, true, logger)
+	})
+
+	AfterEach(func() {
+		logHook.Reset()
+	})
+
+	Context("when the request has no token", func() {
+		It("responds with 401", func() {
+			response := request("GET", "/pools", "", "")
+			Expect(response.Code).To(Equal(http.StatusUnauthorized))
+			Expect(response.Header().Get("WWW-Authenticate")).To(Equal(`Bearer realm="claimer"`))
+			Expect(response.Body.String()).To(MatchJSON(`{"error": "a valid API token is required"}`))
+			Expect(locker.StatusCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the token is unknown", func() {
+		It("responds with 401", func() {
+			Expect(request("POST", "/pools/pool-1/claim", "some-other-token", "").Code).To(Equal(http.StatusUnauthorized))
+			Expect(actions.ClaimCallCount()).To(Equal(0))
+		})
+	})
+
+	Describe("GET /pools", func() {
+		It("responds with the status of every pool as JSON", func() {
+			locker.StatusReturns([]clocker.Lock{{Name: "pool-1", Claimed: true, Owner: "some-owner"}}, nil)
+
+			response := request("GET", "/pools", "some-token", "")
+			Expect(response.Code).To(Equal(http.StatusOK))
+			Expect(response.Body.String()).To(MatchJSON(`[{"name": "pool-1", "claimed": true, "owner": "some-owner", "tags": []}]`))
+		})
+	})
+
+	Describe("POST /pools/{name}/claim", func() {
+		It("claims the pool as the user the token belongs to", func() {
+			actions.ClaimReturns(commands.Result{Response: "Claimed aws/pool-1"}, nil)
+
+			response := request("POST", "/pools/aws/pool-1/claim", "some-token", `{"message": "some message"}`)
+			Expect(response.Code).To(Equal(http.StatusOK))
+			Expect(response.Body.String()).To(MatchJSON(`{"message": "Claimed aws/pool-1"}`))
+
+			Expect(actions.ClaimCallCount()).To(Equal(1))
+			pools, username, message := actions.ClaimArgsForCall(0)
+			Expect(pools).To(Equal([]string{"aws/pool-1"}))
+			Expect(username).To(Equal("some-user"))
+			Expect(message).To(Equal("some message"))
+		})
+
+		It("does not need a body", func() {
+			request("POST", "/pools/pool-1/claim", "some-token", "")
+
+			pools, _, message := actions.ClaimArgsForCall(0)
+			Expect(pools).To(Equal([]string{"pool-1"}))
+			Expect(message).To(BeEmpty())
+		})
+
+		Context("when the message starts with the name of a pool", func() {
+			It("claims only the pool in the path", func() {
+				actions.ClaimReturns(commands.Result{Response: "Claimed pool-1"}, nil)
+
+				response := request("POST", "/pools/pool-1/claim", "some-token", `{"message": "pool-2 pool-3 are broken"}`)
+				Expect(response.Code).To(Equal(http.StatusOK))
+
+				pools, _, message := actions.ClaimArgsForCall(0)
+				Expect(pools).To(Equal([]string{"pool-1"}))
+				Expect(message).To(Equal("pool-2 pool-3 are broken"))
+			})
+		})
+
+		Context("when the claim is refused", func() {
+			It("responds with 409 and the reason", func() {
+				actions.ClaimReturns(commands.Result{Response: "pool-1 is already claimed", Refusal: commands.RefusedConflict}, nil)
+
+				response := request("POST", "/pools/pool-1/claim", "some-token", "")
+				Expect(response.Code).To(Equal(http.StatusConflict))
+				Expect(response.Body.String()).To(MatchJSON(`{"error": "pool-1 is already claimed"}`))
+				Expect(locker.StatusCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the pool does not exist", func() {
+			It("responds with 404 and the reason", func() {
+				actions.ClaimReturns(commands.Result{Response: "pool-2 does not exist", Refusal: commands.RefusedNotFound}, nil)
+
+				response := request("POST", "/pools/pool-2/claim", "some-token", "")
+				Expect(response.Code).To(Equal(http.StatusNotFound))
+				Expect(response.Body.String()).To(MatchJSON(`{"error": "pool-2 does not exist"}`))
+			})
+		})
+
+		Context("when the body is not JSON", func() {
+			It("responds with 400", func() {
+				Expect(request("POST", "/pools/pool-1/claim", "some-token", "some-body").Code).To(Equal(http.StatusBadRequest))
+				Expect(actions.ClaimCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the method is not POST", func() {
+			It("responds with 405", func() {
+				response := request("GET", "/pools/pool-1/claim", "some-token", "")
+				Expect(response.Code).To(Equal(http.StatusMethodNotAllowed))
+				Expect(response.Header().Get("Allow")).To(Equal("POST"))
+			})
+		})
+
+		Context("when claiming fails", func() {
+			It("responds with 500 and logs the error", func() {
+				actions.ClaimReturns(commands.Result{}, errors.New("some-error"))
+
+				response := request("POST", "/pools/pool-1/claim", "some-token", "")
+				Expect(response.Code).To(Equal(http.StatusInternalServerError))
+				Expect(response.Body.String()).To(MatchJSON(`{"error": "failed to claim pool-1"}`))
+				Expect(logHook.LastEntry().Message).To(Equal("failed to claim pool-1: some-error"))
+			})
+		})
+	})
+
+	Describe("POST /pools/{name}/release", func() {
+		It("releases the pool as the user the token belongs to", func() {
+			actions.ReleaseReturns(commands.Result{Response: "Released pool-1"}, nil)
+
+			response := request("POST", "/pools/pool-1/release", "some-token", "")
+			Expect(response.Code).To(Equal(http.StatusOK))
+			Expect(response.Body.String()).To(MatchJSON(`{"message": "Released pool-1"}`))
+
+			pools, username := actions.ReleaseArgsForCall(0)
+			Expect(pools).To(Equal([]string{"pool-1"}))
+			Expect(username).To(Equal("some-user"))
+		})
+
+		Context("when the release is refused", func() {
+			It("responds with 409 and the reason", func() {
+				actions.ReleaseReturns(commands.Result{Response: "pool-1 is not claimed", Refusal: commands.RefusedConflict}, nil)
+
+				response := request("POST", "/pools/pool-1/release", "some-token", "")
+				Expect(response.Code).To(Equal(http.StatusConflict))
+				Expect(response.Body.String()).To(MatchJSON(`{"error": "pool-1 is not claimed"}`))
+			})
+		})
+	})
+
+	Describe("POST /pools", func() {
+		It("creates the pool with its metadata", func() {
+			actions.CreateReturns(commands.Result{Response: "Created pool-1"}, nil)
+
+			response := request("POST", "/pools", "some-token", `{"name": "pool-1", "description": "some description", "tags": ["gcp", "us-east"]}`)
+			Expect(response.Code).To(Equal(http.StatusCreated))
+			Expect(response.Body.String()).To(MatchJSON(`{"message": "Created pool-1"}`))
+
+			pool, username, metadata := actions.CreateArgsForCall(0)
+			Expect(pool).To(Equal("pool-1"))
+			Expect(username).To(Equal("some-user"))
+			Expect(metadata).To(Equal(clocker.Metadata{Description: "some description", Tags: []string{"gcp", "us-east"}}))
+		})
+
+		Context("when the pool already exists", func() {
+			It("responds with 409", func() {
+				actions.CreateReturns(commands.Result{Response: "pool-1 already exists", Refusal: commands.RefusedConflict}, nil)
+
+				Expect(request("POST", "/pools", "some-token", `{"name": "pool-1"}`).Code).To(Equal(http.StatusConflict))
+			})
+		})
+
+		Context("when the name is missing or has spaces", func() {
+			It("responds with 400", func() {
+				Expect(request("POST", "/pools", "some-token", `{}`).Code).To(Equal(http.StatusBadRequest))
+				Expect(request("POST", "/pools", "some-token", `{"name": "pool 1"}`).Code).To(Equal(http.StatusBadRequest))
+				Expect(actions.CreateCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the name is a path outside the repo", func() {
+			It("responds with 400", func() {
+				for _, name := range []string{"../escaped", "/tmp/pool-1", "aws/../../escaped", "aws//env-1"} {
+					Expect(request("POST", "/pools", "some-token", `{"name": "`+name+`"}`).Code).To(Equal(http.StatusBadRequest), name)
+				}
+				Expect(actions.CreateCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the method is not GET or POST", func() {
+			It("responds with 405", func() {
+				response := request("PUT", "/pools", "some-token", "")
+				Expect(response.Code).To(Equal(http.StatusMethodNotAllowed))
+				Expect(response.Header().Get("Allow")).To(Equal("GET, POST"))
+			})
+		})
+	})
+
+	Describe("DELETE /pools/{name}", func() {
+		It("destroys the pool", func() {
+			actions.DestroyReturns(commands.Result{Response: "Destroyed pool-1"}, nil)
+
+			response := request("DELETE", "/pools/pool-1", "some-token", "")
+			Expect(response.Code).To(Equal(http.StatusOK))
+			Expect(response.Body.String()).To(MatchJSON(`{"message": "Destroyed pool-1"}`))
+
+			pool, username := actions.DestroyArgsForCall(0)
+			Expect(pool).To(Equal("pool-1"))
+			Expect(username).To(Equal("some-user"))
+		})
+
+		Context("when the pool does not exist", func() {
+			It("responds with 404", func() {
+				actions.DestroyReturns(commands.Result{Response: "pool-1 does not exist", Refusal: commands.RefusedNotFound}, nil)
+
+				Expect(request("DELETE", "/pools/pool-1", "some-token", "").Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when the method is not DELETE", func() {
+			It("responds with 405", func() {
+				response := request("GET", "/pools/pool-1", "some-token", "")
+				Expect(response.Code).To(Equal(http.StatusMethodNotAllowed))
+				Expect(response.Header().Get("Allow")).To(Equal("DELETE"))
+			})
+		})
+	})
+
+	Describe("LoadTokens", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "claimer-api-tokens")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("reads tokens and usernames from a yaml file", func() {
+			path := filepath.Join(dir, "tokens.yml")
+			Expect(ioutil.WriteFile(path, []byte("some-token: some-user\n"), 0600)).To(Succeed())
+
+			Expect(LoadTokens(path)).To(Equal(map[string]string{"some-token": "some-user"}))
+		})
+
+		Context("when the file does not exist", func() {
+			It("returns an error", func() {
+				_, err := LoadTokens(filepath.Join(dir, "missing.yml"))
+				Expect(err).To(MatchError(ContainSubstring("failed to read tokens file")))
+			})
+		})
+	})
+})
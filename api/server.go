@@ -0,0 +1,75 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	clocker "github.com/mdelillo/claimer/locker"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate counterfeiter . locker
+type locker interface {
+	Status() ([]clocker.Lock, error)
+}
+
+type server struct {
+	locker       locker
+	actions      actions
+	tokens       map[string]string
+	publicStatus bool
+	logger       *logrus.Logger
+}
+
+// NewServer returns a handler serving the status of every pool as JSON at
+// /status, and an API for changing pools at /pools. Requests to the API must
+// carry one of the tokens as a bearer token, and act as the user it maps to.
+// /status is deliberately public, so that dashboards need no token, unless
+// publicStatus is false.
+func NewServer(locker locker, actions actions, tokens map[string]string, publicStatus bool, logger *logrus.Logger) http.Handler {
+	s := &server{
+		locker:       locker,
+		actions:      actions,
+		tokens:       tokens,
+		publicStatus: publicStatus,
+		logger:       logger,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", s.getStatus)
+	mux.HandleFunc("/pools", s.pools)
+	mux.HandleFunc("/pools/", s.pool)
+	return mux
+}
+
+func (s *server) getStatus(w http.ResponseWriter, r *http.Request) {
+	if !s.publicStatus {
+		if _, ok := s.authenticate(w, r); !ok {
+			return
+		}
+	}
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	s.status(w, r)
+}
+
+func (s *server) status(w http.ResponseWriter, r *http.Request) {
+	locks, err := s.locker.Status()
+	if err != nil {
+		s.logger.Errorf("failed to get status of locks: %s", err)
+		http.Error(w, "failed to get status of locks", http.StatusInternalServerError)
+		return
+	}
+	s.writeJson(w, http.StatusOK, Pools(locks))
+}
+
+func (s *server) writeJson(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		s.logger.Errorf("failed to write response: %s", err)
+	}
+}
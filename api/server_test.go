@@ -0,0 +1,137 @@
+package api_test
+
+import (
+	. "github.com/mdelillo/claimer/api"
+
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/mdelillo/claimer/api/apifakes"
+	clocker "github.com/mdelillo/claimer/locker"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("Server", func() {
+	var (
+		locker  *apifakes.FakeLocker
+		actions *apifakes.FakeActions
+		logger  *logrus.Logger
+		logHook *logrustest.Hook
+		handler http.Handler
+	)
+
+	request := func(method, path string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(method, path, nil))
+		return recorder
+	}
+
+	BeforeEach(func() {
+		locker = new(apifakes.FakeLocker)
+		actions = new(apifakes.FakeActions)
+		logger, logHook = logrustest.NewNullLogger()
+		handler = NewServer(locker, actions, map[string]string{"some-token": "some-user"}, true, logger)
+	})
+
+	Describe("GET /status", func() {
+		It("responds with the status of every pool as JSON", func() {
+			since := time.Date(2017, 3, 1, 9, 0, 0, 0, time.UTC)
+			locker.StatusReturns([]clocker.Lock{
+				{
+					Name:     "pool-1",
+					Claimed:  true,
+					Owner:    "some-owner",
+					Date:     since.Format(clocker.DateFormat),
+					Message:  "some message",
+					Expires:  since.Add(time.Hour),
+					Metadata: clocker.Metadata{Description: "some description", Team: "some-team", Tags: []string{"gcp"}},
+				},
+				{Name: "pool-2"},
+				{
+					Name:         "pool-3",
+					Maintenance:  &clocker.Maintenance{By: "some-user", Reason: "repaving", Since: since},
+					Reservations: []clocker.Reservation{{Owner: "some-owner", Start: since, End: since.Add(time.Hour)}},
+				},
+			}, nil)
+
+			response := request("GET", "/status")
+			Expect(response.Code).To(Equal(http.StatusOK))
+			Expect(response.Header().Get("Content-Type")).To(Equal("application/json"))
+			Expect(response.Body.String()).To(MatchJSON(`[
+				{
+					"name": "pool-1",
+					"claimed": true,
+					"owner": "some-owner",
+					"since": "2017-03-01T09:00:00Z",
+					"message": "some message",
+					"expires": "2017-03-01T10:00:00Z",
+					"description": "some description",
+					"team": "some-team",
+					"tags": ["gcp"]
+				},
+				{"name": "pool-2", "claimed": false, "tags": []},
+				{
+					"name": "pool-3",
+					"claimed": false,
+					"tags": [],
+					"disabled": {"by": "some-user", "reason": "repaving", "since": "2017-03-01T09:00:00Z"},
+					"reservations": [{"owner": "some-owner", "start": "2017-03-01T09:00:00Z", "end": "2017-03-01T10:00:00Z"}]
+				}
+			]`))
+		})
+
+		It("responds with an empty list when there are no pools", func() {
+			response := request("GET", "/status")
+			Expect(response.Code).To(Equal(http.StatusOK))
+			Expect(response.Body.String()).To(MatchJSON(`[]`))
+		})
+
+		Context("when the method is not GET", func() {
+			It("responds with 405", func() {
+				response := request("POST", "/status")
+				Expect(response.Code).To(Equal(http.StatusMethodNotAllowed))
+				Expect(response.Header().Get("Allow")).To(Equal("GET"))
+				Expect(locker.StatusCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the status is not public", func() {
+			BeforeEach(func() {
+				handler = NewServer(locker, actions, map[string]string{"some-token": "some-user"}, false, logger)
+			})
+
+			It("requires a token", func() {
+				response := request("GET", "/status")
+				Expect(response.Code).To(Equal(http.StatusUnauthorized))
+				Expect(locker.StatusCallCount()).To(Equal(0))
+
+				req := httptest.NewRequest("GET", "/status", nil)
+				req.Header.Set("Authorization", "Bearer some-token")
+				recorder := httptest.NewRecorder()
+				handler.ServeHTTP(recorder, req)
+				Expect(recorder.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("when getting the status fails", func() {
+			It("responds with 500 and logs the error", func() {
+				locker.StatusReturns(nil, errors.New("some-error"))
+
+				response := request("GET", "/status")
+				Expect(response.Code).To(Equal(http.StatusInternalServerError))
+				Expect(logHook.LastEntry().Message).To(Equal("failed to get status of locks: some-error"))
+			})
+		})
+	})
+
+	Context("when the path is unknown", func() {
+		It("responds with 404", func() {
+			Expect(request("GET", "/some-path").Code).To(Equal(http.StatusNotFound))
+		})
+	})
+})
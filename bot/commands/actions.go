@@ -0,0 +1,229 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	clocker "github.com/mdelillo/claimer/locker"
+	. "github.com/mdelillo/claimer/translate"
+	"github.com/pkg/errors"
+)
+
+// Refusal says why a command did not change anything
+type Refusal string
+
+const (
+	// RefusedInvalid means the command was missing something, e.g. a pool
+	RefusedInvalid Refusal = "invalid"
+	// RefusedNotFound means a pool does not exist
+	RefusedNotFound Refusal = "not found"
+	// RefusedConflict means a pool is not in a state that allows the
+	// command, e.g. it is already claimed
+	RefusedConflict Refusal = "conflict"
+)
+
+// Result is the outcome of an action. Response is what claimer says in chat,
+// whether or not the action was refused.
+type Result struct {
+	Response string
+	Refusal  Refusal
+}
+
+// Refused returns whether the action did not change anything
+func (r Result) Refused() bool {
+	return r.Refusal != ""
+}
+
+// Actions changes pools for callers other than chat, such as the command
+// line and the API. They take their arguments separately rather than parsing
+// them from a message, but follow the same rules as the chat commands, which
+// use them too.
+type Actions interface {
+	Claim(pools []string, username, message string) (Result, error)
+	Release(pools []string, username string) (Result, error)
+	Create(pool, username string, metadata clocker.Metadata) (Result, error)
+	Destroy(pool, username string) (Result, error)
+}
+
+type actions struct {
+	locker locker
+}
+
+func NewActions(locker locker) Actions {
+	return &actions{locker: locker}
+}
+
+func succeeded(response string) Result {
+	return Result{Response: response}
+}
+
+func refused(refusal Refusal, response string) Result {
+	return Result{Response: response, Refusal: refusal}
+}
+
+// Claim claims all of the pools, or none of them if any cannot be claimed. A
+// single group of pools, such as "aws/us-east", claims its first available
+// pool.
+func (a *actions) Claim(pools []string, username, message string) (Result, error) {
+	pools = unique(pools)
+	if len(pools) == 0 {
+		return refused(RefusedInvalid, T("claim.no_pool", nil)), nil
+	}
+
+	locks, err := a.locker.Status()
+	if err != nil {
+		return Result{}, errors.Wrap(err, "failed to get status of locks")
+	}
+	if len(pools) == 1 && !poolExists(pools[0], locks) {
+		group := pools[0]
+		if len(filterLocks(locks, func(lock clocker.Lock) bool { return inGroup(lock.Name, group) })) > 0 {
+			return a.claimFromGroup(group, locks, username, message)
+		}
+	}
+	for _, pool := range pools {
+		if !poolExists(pool, locks) {
+			return refused(RefusedNotFound, T("claim.pool_does_not_exist", TArgs{"pool": pool})), nil
+		}
+	}
+
+	now := time.Now()
+	for _, pool := range pools {
+		if lock := getLock(pool, locks); lock.Maintenance != nil {
+			response := T("claim.pool_is_disabled", TArgs{"pool": pool, "user": lock.Maintenance.By})
+			if lock.Maintenance.Reason != "" {
+				response = fmt.Sprintf("%s (%s)", response, lock.Maintenance.Reason)
+			}
+			return refused(RefusedConflict, response), nil
+		}
+		if poolClaimed(pool, locks) {
+			return refused(RefusedConflict, T("claim.pool_is_already_claimed", TArgs{"pool": pool})), nil
+		}
+		if r := reservedByOther(*getLock(pool, locks), username, now); r != nil {
+			return refused(RefusedConflict, T("claim.pool_is_reserved", TArgs{"pool": pool, "owner": r.Owner, "end": r.End.Format(clocker.DateFormat)})), nil
+		}
+	}
+
+	if err := a.locker.ClaimLocks(pools, username, message); err != nil {
+		return Result{}, errors.Wrap(err, "failed to claim lock")
+	}
+
+	return succeeded(T("claim.success", TArgs{"pool": strings.Join(pools, ", ")})), nil
+}
+
+// claimFromGroup claims the first unclaimed pool in a group such as
+// "aws/us-east"
+func (a *actions) claimFromGroup(group string, locks []clocker.Lock, username, message string) (Result, error) {
+	now := time.Now()
+	var pools []string
+	for _, lock := range locks {
+		if available(lock) && inGroup(lock.Name, group) && reservedByOther(lock, username, now) == nil {
+			pools = append(pools, lock.Name)
+		}
+	}
+	if len(pools) == 0 {
+		return refused(RefusedConflict, T("claim.group_is_fully_claimed", TArgs{"group": group})), nil
+	}
+
+	pool, err := a.locker.ClaimAny(pools, username, message)
+	if err != nil {
+		return Result{}, errors.Wrap(err, "failed to claim lock")
+	}
+	if pool == "" {
+		return refused(RefusedConflict, T("claim.group_is_fully_claimed", TArgs{"group": group})), nil
+	}
+
+	return succeeded(T("claim.success", TArgs{"pool": pool})), nil
+}
+
+// Release releases all of the pools, or none of them if any is not claimed
+func (a *actions) Release(pools []string, username string) (Result, error) {
+	pools = unique(pools)
+	if len(pools) == 0 {
+		return refused(RefusedInvalid, T("release.no_pool", nil)), nil
+	}
+
+	locks, err := a.locker.Status()
+	if err != nil {
+		return Result{}, errors.Wrap(err, "failed to get status of locks")
+	}
+
+	for _, pool := range pools {
+		if !poolExists(pool, locks) {
+			return refused(RefusedNotFound, T("release.pool_does_not_exist", TArgs{"pool": pool})), nil
+		}
+		if lock := getLock(pool, locks); lock.Maintenance != nil {
+			return refused(RefusedConflict, T("release.pool_is_disabled", TArgs{"pool": pool})), nil
+		}
+		if !poolClaimed(pool, locks) {
+			return refused(RefusedConflict, T("release.pool_is_not_claimed", TArgs{"pool": pool})), nil
+		}
+	}
+
+	if err := a.locker.ReleaseLocks(pools, username); err != nil {
+		return Result{}, errors.Wrap(err, "failed to release lock")
+	}
+
+	return succeeded(T("release.success", TArgs{"pool": strings.Join(pools, ", ")})), nil
+}
+
+// Create creates a pool. Its name cannot be taken by a pool or a group of
+// pools, nor put it inside another pool.
+func (a *actions) Create(pool, username string, metadata clocker.Metadata) (Result, error) {
+	if pool == "" {
+		return refused(RefusedInvalid, T("create.no_pool", nil)), nil
+	}
+	if !clocker.ValidPoolName(pool) {
+		return refused(RefusedInvalid, T("create.invalid_name", TArgs{"pool": pool})), nil
+	}
+
+	locks, err := a.locker.Status()
+	if err != nil {
+		return Result{}, errors.Wrap(err, "failed to get status of locks")
+	}
+	if nameTaken(pool, locks) {
+		return refused(RefusedConflict, T("create.pool_already_exists", TArgs{"pool": pool})), nil
+	}
+	for _, lock := range locks {
+		if inGroup(pool, lock.Name) {
+			return refused(RefusedConflict, T("create.parent_is_pool", TArgs{"pool": pool, "parent": lock.Name})), nil
+		}
+	}
+
+	if err := a.locker.CreatePool(pool, username, metadata); err != nil {
+		return Result{}, errors.Wrap(err, "failed to create pool")
+	}
+
+	return succeeded(T("create.success", TArgs{"pool": pool})), nil
+}
+
+func (a *actions) Destroy(pool, username string) (Result, error) {
+	if pool == "" {
+		return refused(RefusedInvalid, T("destroy.no_pool", nil)), nil
+	}
+
+	locks, err := a.locker.Status()
+	if err != nil {
+		return Result{}, errors.Wrap(err, "failed to get status of locks")
+	}
+	if !poolExists(pool, locks) {
+		return refused(RefusedNotFound, T("destroy.pool_does_not_exist", TArgs{"pool": pool})), nil
+	}
+
+	if err := a.locker.DestroyPool(pool, username); err != nil {
+		return Result{}, errors.Wrap(err, "failed to destroy pool")
+	}
+
+	return succeeded(T("destroy.success", TArgs{"pool": pool})), nil
+}
+
+// unique drops empty and repeated pools, keeping their order
+func unique(pools []string) []string {
+	var result []string
+	for _, pool := range pools {
+		if pool != "" && !contains(result, pool) {
+			result = append(result, pool)
+		}
+	}
+	return result
+}
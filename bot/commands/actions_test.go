@@ -0,0 +1,162 @@
+package commands_test
+
+import (
+	. "github.com/mdelillo/claimer/bot/commands"
+
+	"errors"
+
+	"github.com/mdelillo/claimer/bot/commands/commandsfakes"
+	clocker "github.com/mdelillo/claimer/locker"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Actions", func() {
+	var (
+		locker  *commandsfakes.FakeLocker
+		actions Actions
+	)
+
+	BeforeEach(func() {
+		locker = new(commandsfakes.FakeLocker)
+		locker.StatusReturns([]clocker.Lock{
+			{Name: "pool-1"},
+			{Name: "pool-2"},
+			{Name: "pool-3", Claimed: true, Owner: "some-owner"},
+			{Name: "pool-4", Claimed: true, Maintenance: &clocker.Maintenance{By: "some-user"}},
+		}, nil)
+		actions = NewActions(locker)
+	})
+
+	Describe("Claim", func() {
+		It("claims the pools with the message", func() {
+			result, err := actions.Claim([]string{"pool-1", "pool-2", "pool-1"}, "some-username", "pool-3 is broken")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(Result{Response: "Claimed pool-1, pool-2"}))
+			Expect(result.Refused()).To(BeFalse())
+
+			Expect(locker.ClaimLocksCallCount()).To(Equal(1))
+			pools, username, message := locker.ClaimLocksArgsForCall(0)
+			Expect(pools).To(Equal([]string{"pool-1", "pool-2"}))
+			Expect(username).To(Equal("some-username"))
+			Expect(message).To(Equal("pool-3 is broken"))
+		})
+
+		It("refuses pools which are claimed, disabled or do not exist", func() {
+			result, err := actions.Claim([]string{"pool-1", "pool-3"}, "some-username", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(Result{Response: "pool-3 is already claimed", Refusal: RefusedConflict}))
+			Expect(result.Refused()).To(BeTrue())
+
+			result, err = actions.Claim([]string{"pool-4"}, "some-username", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(Result{Response: "pool-4 is disabled by some-user", Refusal: RefusedConflict}))
+
+			result, err = actions.Claim([]string{"pool-5"}, "some-username", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(Result{Response: "pool-5 does not exist", Refusal: RefusedNotFound}))
+
+			result, err = actions.Claim(nil, "some-username", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Refusal).To(Equal(RefusedInvalid))
+
+			Expect(locker.ClaimLocksCallCount()).To(Equal(0))
+		})
+
+		Context("when claiming fails", func() {
+			It("returns an error", func() {
+				locker.ClaimLocksReturns(errors.New("some-error"))
+
+				_, err := actions.Claim([]string{"pool-1"}, "some-username", "")
+				Expect(err).To(MatchError("failed to claim lock: some-error"))
+			})
+		})
+	})
+
+	Describe("Release", func() {
+		It("releases the pools", func() {
+			result, err := actions.Release([]string{"pool-3"}, "some-username")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(Result{Response: "Released pool-3"}))
+			Expect(locker.ReleaseLocksCallCount()).To(Equal(1))
+		})
+
+		It("refuses pools which are not claimed or do not exist", func() {
+			result, err := actions.Release([]string{"pool-3", "pool-1"}, "some-username")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(Result{Response: "pool-1 is not claimed", Refusal: RefusedConflict}))
+
+			result, err = actions.Release([]string{"pool-5"}, "some-username")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Refusal).To(Equal(RefusedNotFound))
+
+			Expect(locker.ReleaseLocksCallCount()).To(Equal(0))
+		})
+	})
+
+	Describe("Create", func() {
+		It("creates the pool with its metadata", func() {
+			metadata := clocker.Metadata{Description: "some description", Tags: []string{"gcp"}}
+
+			result, err := actions.Create("pool-5", "some-username", metadata)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(Result{Response: "Created pool-5"}))
+
+			pool, username, actualMetadata := locker.CreatePoolArgsForCall(0)
+			Expect(pool).To(Equal("pool-5"))
+			Expect(username).To(Equal("some-username"))
+			Expect(actualMetadata).To(Equal(metadata))
+		})
+
+		It("refuses pools which already exist", func() {
+			result, err := actions.Create("pool-1", "some-username", clocker.Metadata{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Refusal).To(Equal(RefusedConflict))
+			Expect(locker.CreatePoolCallCount()).To(Equal(0))
+		})
+
+		It("refuses names which are not valid pool names", func() {
+			for _, name := range []string{"../escaped", "/tmp/pool", "aws//env-1", ".git/hooks"} {
+				result, err := actions.Create(name, "some-username", clocker.Metadata{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(Result{Response: name + " is not a valid pool name", Refusal: RefusedInvalid}))
+			}
+			Expect(locker.StatusCallCount()).To(Equal(0))
+			Expect(locker.CreatePoolCallCount()).To(Equal(0))
+		})
+
+		It("refuses names of groups of pools", func() {
+			locker.StatusReturns([]clocker.Lock{{Name: "aws/env-1"}}, nil)
+
+			result, err := actions.Create("aws", "some-username", clocker.Metadata{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(Result{Response: "aws already exists", Refusal: RefusedConflict}))
+			Expect(locker.CreatePoolCallCount()).To(Equal(0))
+		})
+
+		It("refuses names inside a pool", func() {
+			for _, name := range []string{"pool-1/sub", "pool-1/sub/env-1"} {
+				result, err := actions.Create(name, "some-username", clocker.Metadata{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(Result{Response: name + " cannot be created inside pool-1, which is a pool", Refusal: RefusedConflict}))
+			}
+			Expect(locker.CreatePoolCallCount()).To(Equal(0))
+		})
+	})
+
+	Describe("Destroy", func() {
+		It("destroys the pool", func() {
+			result, err := actions.Destroy("pool-1", "some-username")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(Result{Response: "Destroyed pool-1"}))
+			Expect(locker.DestroyPoolCallCount()).To(Equal(1))
+		})
+
+		It("refuses pools which do not exist", func() {
+			result, err := actions.Destroy("pool-5", "some-username")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(Result{Response: "pool-5 does not exist", Refusal: RefusedNotFound}))
+			Expect(locker.DestroyPoolCallCount()).To(Equal(0))
+		})
+	})
+})
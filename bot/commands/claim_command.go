@@ -2,7 +2,9 @@ package commands
 
 import (
 	"strings"
+	"time"
 
+	clocker "github.com/mdelillo/claimer/locker"
 	. "github.com/mdelillo/claimer/translate"
 	"github.com/pkg/errors"
 )
@@ -13,6 +15,13 @@ type claimCommand struct {
 	username string
 }
 
+// tagSelector matches pools which have every tag in include and none of the
+// tags in exclude
+type tagSelector struct {
+	include []string
+	exclude []string
+}
+
 func (c *claimCommand) Execute() (string, error) {
 	args := strings.SplitN(c.args, " ", 2)
 	if len(c.args) < 1 {
@@ -20,24 +29,119 @@ func (c *claimCommand) Execute() (string, error) {
 	}
 	pool := args[0]
 
+	switch {
+	case pool == "--tag":
+		return c.claimByTag(strings.Fields(c.args)[1:])
+	case strings.HasPrefix(pool, "--tag="), strings.HasPrefix(pool, "any:"):
+		fields := strings.Fields(c.args)
+		fields[0] = strings.TrimPrefix(strings.TrimPrefix(fields[0], "--tag="), "any:")
+		return c.claimByTag(fields)
+	}
+
+	pools, message := splitPools(c.args)
+	result, err := NewActions(c.locker).Claim(pools, c.username, message)
+	return result.Response, err
+}
+
+// splitPools splits the arguments of a claim into the pools, separated by
+// commas as in "pool-1, pool-2", and the message which follows them
+func splitPools(args string) ([]string, string) {
+	var pools []string
+	rest := strings.TrimSpace(args)
+	for rest != "" {
+		words := strings.SplitN(rest, " ", 2)
+		rest = ""
+		if len(words) > 1 {
+			rest = strings.TrimSpace(words[1])
+		}
+		pools = append(pools, strings.Split(words[0], ",")...)
+		if !strings.HasSuffix(words[0], ",") && !strings.HasPrefix(rest, ",") {
+			break
+		}
+	}
+	return pools, rest
+}
+
+// claimByTag claims the first unclaimed pool matching a selector such as
+// "gcp AND NOT us-east". Any words after the selector are the message.
+func (c *claimCommand) claimByTag(args []string) (string, error) {
+	selector, rest, ok := parseTagSelector(args)
+	if !ok {
+		return T("claim.no_tag", nil), nil
+	}
+
 	locks, err := c.locker.Status()
 	if err != nil {
 		return "", errors.Wrap(err, "failed to get status of locks")
 	}
-	if !poolExists(pool, locks) {
-		return T("claim.pool_does_not_exist", TArgs{"pool": pool}), nil
-	}
-	if poolClaimed(pool, locks) {
-		return T("claim.pool_is_already_claimed", TArgs{"pool": pool}), nil
+	now := time.Now()
+	candidates := filterLocks(locks, func(lock clocker.Lock) bool {
+		return available(lock) && selector.matches(lock.Tags) && reservedByOther(lock, c.username, now) == nil
+	})
+	if len(candidates) == 0 {
+		return T("claim.no_pool_matches", TArgs{"selector": selector.String()}), nil
 	}
 
-	var message string
-	if len(args) > 1 {
-		message = args[1]
+	var pools []string
+	for _, lock := range candidates {
+		pools = append(pools, lock.Name)
 	}
-	if err := c.locker.ClaimLock(pool, c.username, message); err != nil {
+	pool, err := c.locker.ClaimAny(pools, c.username, strings.Join(rest, " "))
+	if err != nil {
 		return "", errors.Wrap(err, "failed to claim lock")
 	}
+	if pool == "" {
+		return T("claim.no_pool_matches", TArgs{"selector": selector.String()}), nil
+	}
 
 	return T("claim.success", TArgs{"pool": pool}), nil
 }
+
+// parseTagSelector parses tags joined by AND, each optionally preceded by
+// NOT, and returns the words which follow them
+func parseTagSelector(args []string) (tagSelector, []string, bool) {
+	var selector tagSelector
+	for {
+		negated := len(args) > 0 && strings.EqualFold(args[0], "NOT")
+		if negated {
+			args = args[1:]
+		}
+		if len(args) == 0 || args[0] == "" {
+			return selector, nil, false
+		}
+		if negated {
+			selector.exclude = append(selector.exclude, args[0])
+		} else {
+			selector.include = append(selector.include, args[0])
+		}
+		args = args[1:]
+
+		if len(args) == 0 || !strings.EqualFold(args[0], "AND") {
+			return selector, args, true
+		}
+		args = args[1:]
+	}
+}
+
+func (s tagSelector) matches(tags []string) bool {
+	for _, tag := range s.include {
+		if !contains(tags, tag) {
+			return false
+		}
+	}
+	for _, tag := range s.exclude {
+		if contains(tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s tagSelector) String() string {
+	var terms []string
+	terms = append(terms, s.include...)
+	for _, tag := range s.exclude {
+		terms = append(terms, "NOT "+tag)
+	}
+	return strings.Join(terms, " AND ")
+}
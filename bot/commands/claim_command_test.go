@@ -4,6 +4,7 @@ import (
 	. "github.com/mdelillo/claimer/bot/commands"
 
 	"errors"
+	"time"
 
 	"github.com/mdelillo/claimer/bot/commands/commandsfakes"
 	clocker "github.com/mdelillo/claimer/locker"
@@ -35,9 +36,9 @@ var _ = Describe("ClaimCommand", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(slackResponse).To(Equal("Claimed " + pool))
 
-				Expect(locker.ClaimLockCallCount()).To(Equal(1))
-				actualPool, actualUsername, actualMessage := locker.ClaimLockArgsForCall(0)
-				Expect(actualPool).To(Equal(pool))
+				Expect(locker.ClaimLocksCallCount()).To(Equal(1))
+				actualPools, actualUsername, actualMessage := locker.ClaimLocksArgsForCall(0)
+				Expect(actualPools).To(Equal([]string{pool}))
 				Expect(actualUsername).To(Equal(username))
 				Expect(actualMessage).To(BeEmpty())
 			})
@@ -60,14 +61,78 @@ var _ = Describe("ClaimCommand", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(slackResponse).To(Equal("Claimed " + pool))
 
-				Expect(locker.ClaimLockCallCount()).To(Equal(1))
-				actualPool, actualUsername, actualMessage := locker.ClaimLockArgsForCall(0)
-				Expect(actualPool).To(Equal(pool))
+				Expect(locker.ClaimLocksCallCount()).To(Equal(1))
+				actualPools, actualUsername, actualMessage := locker.ClaimLocksArgsForCall(0)
+				Expect(actualPools).To(Equal([]string{pool}))
 				Expect(actualUsername).To(Equal(username))
 				Expect(actualMessage).To(Equal(message))
 			})
 		})
 
+		Context("when several pools are provided", func() {
+			BeforeEach(func() {
+				locker.StatusReturns(
+					[]clocker.Lock{
+						{Name: "pool-1", Claimed: false},
+						{Name: "pool-2", Claimed: false},
+						{Name: "pool-3", Claimed: true},
+					},
+					nil,
+				)
+			})
+
+			It("claims all of them at once", func() {
+				command := NewFactory(locker).NewCommand("claim", "pool-1, pool-2,pool-1 some message about pool-2", "some-username")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("Claimed pool-1, pool-2"))
+
+				Expect(locker.ClaimLocksCallCount()).To(Equal(1))
+				actualPools, actualUsername, actualMessage := locker.ClaimLocksArgsForCall(0)
+				Expect(actualPools).To(Equal([]string{"pool-1", "pool-2"}))
+				Expect(actualUsername).To(Equal("some-username"))
+				Expect(actualMessage).To(Equal("some message about pool-2"))
+			})
+
+			Context("when one of them is already claimed", func() {
+				It("claims none of them", func() {
+					command := NewFactory(locker).NewCommand("claim", "pool-1, pool-3, pool-2", "")
+
+					slackResponse, err := command.Execute()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(slackResponse).To(Equal("pool-3 is already claimed"))
+					Expect(locker.ClaimLocksCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when one of them does not exist", func() {
+				It("claims none of them", func() {
+					command := NewFactory(locker).NewCommand("claim", "pool-1,pool-4", "")
+
+					slackResponse, err := command.Execute()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(slackResponse).To(Equal("pool-4 does not exist"))
+					Expect(locker.ClaimLocksCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when the message starts with the name of a pool", func() {
+			It("claims only the first pool", func() {
+				locker.StatusReturns([]clocker.Lock{{Name: "pool-1"}, {Name: "pool-2"}}, nil)
+				command := NewFactory(locker).NewCommand("claim", "pool-1 pool-2 is broken so I need this", "some-username")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("Claimed pool-1"))
+
+				actualPools, _, actualMessage := locker.ClaimLocksArgsForCall(0)
+				Expect(actualPools).To(Equal([]string{"pool-1"}))
+				Expect(actualMessage).To(Equal("pool-2 is broken so I need this"))
+			})
+		})
+
 		Context("when no pool is specified", func() {
 			It("returns a slack response", func() {
 				command := NewFactory(locker).NewCommand("claim", "", "")
@@ -109,6 +174,80 @@ var _ = Describe("ClaimCommand", func() {
 			})
 		})
 
+		Context("when the pool is disabled", func() {
+			BeforeEach(func() {
+				locker.StatusReturns(
+					[]clocker.Lock{
+						{Name: "some-pool", Maintenance: &clocker.Maintenance{By: "some-other-user", Reason: "repaving"}},
+						{Name: "some-group/some-pool", Maintenance: &clocker.Maintenance{By: "some-other-user"}, Metadata: clocker.Metadata{Tags: []string{"some-tag"}}},
+					},
+					nil,
+				)
+			})
+
+			It("refuses to claim it", func() {
+				slackResponse, err := NewFactory(locker).NewCommand("claim", "some-pool", "some-user").Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("some-pool is disabled by some-other-user (repaving)"))
+
+				slackResponse, err = NewFactory(locker).NewCommand("claim", "some-group", "some-user").Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("every pool in some-group is claimed"))
+
+				slackResponse, err = NewFactory(locker).NewCommand("claim", "any:some-tag", "some-user").Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("no unclaimed pool matches some-tag"))
+
+				Expect(locker.ClaimLocksCallCount()).To(Equal(0))
+				Expect(locker.ClaimAnyCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the pool is reserved", func() {
+			var reservation clocker.Reservation
+
+			BeforeEach(func() {
+				reservation = clocker.Reservation{Owner: "some-other-user", Start: time.Now().Add(-time.Hour), End: time.Now().Add(time.Hour)}
+				locker.StatusReturns(
+					[]clocker.Lock{
+						{Name: "some-pool", Reservations: []clocker.Reservation{reservation}},
+						{Name: "some-group/some-pool", Reservations: []clocker.Reservation{reservation}},
+					},
+					nil,
+				)
+			})
+
+			It("refuses claims by anyone else while the reservation lasts", func() {
+				slackResponse, err := NewFactory(locker).NewCommand("claim", "some-pool", "some-user").Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("some-pool is reserved by some-other-user until " + reservation.End.Format(clocker.DateFormat)))
+
+				slackResponse, err = NewFactory(locker).NewCommand("claim", "some-group", "some-user").Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("every pool in some-group is claimed"))
+
+				Expect(locker.ClaimLocksCallCount()).To(Equal(0))
+				Expect(locker.ClaimAnyCallCount()).To(Equal(0))
+			})
+
+			It("lets the owner of the reservation claim the pool", func() {
+				slackResponse, err := NewFactory(locker).NewCommand("claim", "some-pool", "some-other-user").Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("Claimed some-pool"))
+			})
+
+			Context("when the reservation has not started", func() {
+				It("claims the pool", func() {
+					reservation.Start = time.Now().Add(time.Minute)
+					locker.StatusReturns([]clocker.Lock{{Name: "some-pool", Reservations: []clocker.Reservation{reservation}}}, nil)
+
+					slackResponse, err := NewFactory(locker).NewCommand("claim", "some-pool", "some-user").Execute()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(slackResponse).To(Equal("Claimed some-pool"))
+				})
+			})
+		})
+
 		Context("when checking the status fails", func() {
 			It("returns an error", func() {
 				locker.StatusReturns(nil, errors.New("some-error"))
@@ -129,7 +268,7 @@ var _ = Describe("ClaimCommand", func() {
 					[]clocker.Lock{{Name: pool, Claimed: false}},
 					nil,
 				)
-				locker.ClaimLockReturns(errors.New("some-error"))
+				locker.ClaimLocksReturns(errors.New("some-error"))
 
 				command := NewFactory(locker).NewCommand("claim", "some-pool", "")
 
@@ -138,5 +277,175 @@ var _ = Describe("ClaimCommand", func() {
 				Expect(slackResponse).To(BeEmpty())
 			})
 		})
+
+		Context("when a group is specified", func() {
+			BeforeEach(func() {
+				locker.StatusReturns(
+					[]clocker.Lock{
+						{Name: "aws/us-east/env-1", Claimed: true},
+						{Name: "aws/us-east/env-2", Claimed: false},
+						{Name: "aws/us-east/env-3", Claimed: false},
+						{Name: "aws/us-west/env-1", Claimed: true},
+					},
+					nil,
+				)
+				locker.ClaimAnyStub = func(pools []string, _, _ string) (string, error) {
+					return pools[0], nil
+				}
+			})
+
+			It("claims the first unclaimed pool in the group", func() {
+				slackResponse, err := NewFactory(locker).NewCommand("claim", "aws/us-east some message", "some-user").Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("Claimed aws/us-east/env-2"))
+
+				Expect(locker.ClaimAnyCallCount()).To(Equal(1))
+				pools, username, message := locker.ClaimAnyArgsForCall(0)
+				Expect(pools).To(Equal([]string{"aws/us-east/env-2", "aws/us-east/env-3"}))
+				Expect(username).To(Equal("some-user"))
+				Expect(message).To(Equal("some message"))
+			})
+
+			Context("when every pool in the group is claimed", func() {
+				It("returns a slack response", func() {
+					slackResponse, err := NewFactory(locker).NewCommand("claim", "aws/us-west", "").Execute()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(slackResponse).To(Equal("every pool in aws/us-west is claimed"))
+					Expect(locker.ClaimAnyCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the pools are claimed before the claim", func() {
+				It("returns a slack response", func() {
+					locker.ClaimAnyStub = nil
+					locker.ClaimAnyReturns("", nil)
+
+					slackResponse, err := NewFactory(locker).NewCommand("claim", "aws", "").Execute()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(slackResponse).To(Equal("every pool in aws is claimed"))
+				})
+			})
+
+			Context("when claiming fails", func() {
+				It("returns an error", func() {
+					locker.ClaimAnyStub = nil
+					locker.ClaimAnyReturns("", errors.New("some-error"))
+
+					_, err := NewFactory(locker).NewCommand("claim", "aws", "").Execute()
+					Expect(err).To(MatchError("failed to claim lock: some-error"))
+				})
+			})
+		})
+
+		Context("when claiming by tag", func() {
+			BeforeEach(func() {
+				locker.StatusReturns(
+					[]clocker.Lock{
+						{Name: "pool-1", Claimed: true, Metadata: clocker.Metadata{Tags: []string{"gcp"}}},
+						{Name: "pool-2", Claimed: false, Metadata: clocker.Metadata{Tags: []string{"gcp", "us-east"}}},
+						{Name: "pool-3", Claimed: false, Metadata: clocker.Metadata{Tags: []string{"aws"}}},
+						{Name: "pool-4", Claimed: false, Metadata: clocker.Metadata{Tags: []string{"gcp", "us-west"}}},
+						{Name: "pool-5", Claimed: false},
+					},
+					nil,
+				)
+				locker.ClaimAnyStub = func(pools []string, _, _ string) (string, error) {
+					return pools[0], nil
+				}
+			})
+
+			It("claims the first unclaimed pool with the tag", func() {
+				command := NewFactory(locker).NewCommand("claim", "any:gcp", "some-user")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("Claimed pool-2"))
+
+				Expect(locker.ClaimAnyCallCount()).To(Equal(1))
+				pools, username, message := locker.ClaimAnyArgsForCall(0)
+				Expect(pools).To(Equal([]string{"pool-2", "pool-4"}))
+				Expect(username).To(Equal("some-user"))
+				Expect(message).To(BeEmpty())
+				Expect(locker.ClaimLocksCallCount()).To(Equal(0))
+			})
+
+			It("supports AND, NOT and a message", func() {
+				for _, args := range []string{
+					"any:gcp AND NOT us-east some message",
+					"--tag gcp and not us-east some message",
+					"--tag=gcp AND NOT us-east some message",
+				} {
+					slackResponse, err := NewFactory(locker).NewCommand("claim", args, "").Execute()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(slackResponse).To(Equal("Claimed pool-4"))
+				}
+
+				Expect(locker.ClaimAnyCallCount()).To(Equal(3))
+				for i := 0; i < 3; i++ {
+					pools, _, message := locker.ClaimAnyArgsForCall(i)
+					Expect(pools).To(Equal([]string{"pool-4"}))
+					Expect(message).To(Equal("some message"))
+				}
+			})
+
+			It("matches pools without a tag", func() {
+				slackResponse, err := NewFactory(locker).NewCommand("claim", "any:NOT gcp", "").Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("Claimed pool-3"))
+
+				pools, _, _ := locker.ClaimAnyArgsForCall(0)
+				Expect(pools).To(Equal([]string{"pool-3", "pool-5"}))
+			})
+
+			Context("when no unclaimed pool matches", func() {
+				It("returns a slack response", func() {
+					slackResponse, err := NewFactory(locker).NewCommand("claim", "any:gcp AND NOT us-east AND NOT us-west", "").Execute()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(slackResponse).To(Equal("no unclaimed pool matches gcp AND NOT us-east AND NOT us-west"))
+					Expect(locker.ClaimAnyCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the matching pools are claimed before the claim", func() {
+				It("returns a slack response", func() {
+					locker.ClaimAnyStub = nil
+					locker.ClaimAnyReturns("", nil)
+
+					slackResponse, err := NewFactory(locker).NewCommand("claim", "any:aws", "").Execute()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(slackResponse).To(Equal("no unclaimed pool matches aws"))
+				})
+			})
+
+			Context("when no tag is specified", func() {
+				It("returns a slack response", func() {
+					for _, args := range []string{"any:", "--tag", "--tag=", "any:gcp AND", "--tag NOT"} {
+						slackResponse, err := NewFactory(locker).NewCommand("claim", args, "").Execute()
+						Expect(err).NotTo(HaveOccurred())
+						Expect(slackResponse).To(Equal("must specify tags to claim by, e.g. any:gcp AND NOT us-east"))
+					}
+					Expect(locker.StatusCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when checking the status fails", func() {
+				It("returns an error", func() {
+					locker.StatusReturns(nil, errors.New("some-error"))
+
+					_, err := NewFactory(locker).NewCommand("claim", "any:gcp", "").Execute()
+					Expect(err).To(MatchError("failed to get status of locks: some-error"))
+				})
+			})
+
+			Context("when claiming fails", func() {
+				It("returns an error", func() {
+					locker.ClaimAnyStub = nil
+					locker.ClaimAnyReturns("", errors.New("some-error"))
+
+					_, err := NewFactory(locker).NewCommand("claim", "any:gcp", "").Execute()
+					Expect(err).To(MatchError("failed to claim lock: some-error"))
+				})
+			})
+		})
 	})
 })
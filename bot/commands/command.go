@@ -1,6 +1,11 @@
 package commands
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
 	clocker "github.com/mdelillo/claimer/locker"
 )
 
@@ -26,3 +31,79 @@ func poolClaimed(pool string, locks []clocker.Lock) bool {
 	}
 	return false
 }
+
+// available returns whether a pool is neither claimed nor disabled
+func available(lock clocker.Lock) bool {
+	return !lock.Claimed && lock.Maintenance == nil
+}
+
+// reservedByOther returns the reservation of a pool by someone other than
+// user which covers now, if there is one
+func reservedByOther(lock clocker.Lock, user string, now time.Time) *clocker.Reservation {
+	for _, reservation := range lock.Reservations {
+		if reservation.Owner != user && reservation.Active(now) {
+			return &reservation
+		}
+	}
+	return nil
+}
+
+// nameTaken returns whether a pool or a group of pools already has the name
+func nameTaken(name string, locks []clocker.Lock) bool {
+	for _, lock := range locks {
+		if lock.Name == name || inGroup(lock.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// inGroup returns whether a pool is in a group of pools, e.g.
+// "aws/us-east/env-1" is in "aws" and in "aws/us-east"
+func inGroup(pool, group string) bool {
+	return strings.HasPrefix(pool, strings.TrimSuffix(group, "/")+"/")
+}
+
+func contains(list []string, item string) bool {
+	for _, i := range list {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
+
+// parseDuration behaves like time.ParseDuration but also accepts a number of
+// days, e.g. "3d"
+func parseDuration(value string) (time.Duration, error) {
+	if strings.HasSuffix(value, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(value)
+}
+
+// formatDuration renders a duration in days, hours and minutes, e.g. "2d 3h 5m"
+func formatDuration(duration time.Duration) string {
+	duration = duration.Round(time.Minute)
+	days := duration / (24 * time.Hour)
+	duration -= days * 24 * time.Hour
+	hours := duration / time.Hour
+	duration -= hours * time.Hour
+	minutes := duration / time.Minute
+
+	var parts []string
+	if days > 0 {
+		parts = append(parts, fmt.Sprintf("%dd", days))
+	}
+	if hours > 0 {
+		parts = append(parts, fmt.Sprintf("%dh", hours))
+	}
+	if minutes > 0 || len(parts) == 0 {
+		parts = append(parts, fmt.Sprintf("%dm", minutes))
+	}
+	return strings.Join(parts, " ")
+}
@@ -3,29 +3,59 @@ package commandsfakes
 
 import (
 	"sync"
+	"time"
 
 	clocker "github.com/mdelillo/claimer/locker"
 )
 
 type FakeLocker struct {
-	ClaimLockStub        func(pool, username, message string) error
-	claimLockMutex       sync.RWMutex
-	claimLockArgsForCall []struct {
+	AnnotateLockStub        func(pool, username, message string) error
+	annotateLockMutex       sync.RWMutex
+	annotateLockArgsForCall []struct {
 		pool     string
 		username string
 		message  string
 	}
-	claimLockReturns struct {
+	annotateLockReturns struct {
 		result1 error
 	}
-	claimLockReturnsOnCall map[int]struct {
+	annotateLockReturnsOnCall map[int]struct {
 		result1 error
 	}
-	CreatePoolStub        func(pool, username string) error
+	ClaimAnyStub        func(pools []string, username, message string) (string, error)
+	claimAnyMutex       sync.RWMutex
+	claimAnyArgsForCall []struct {
+		pools    []string
+		username string
+		message  string
+	}
+	claimAnyReturns struct {
+		result1 string
+		result2 error
+	}
+	claimAnyReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	ClaimLocksStub        func(pools []string, username, message string) error
+	claimLocksMutex       sync.RWMutex
+	claimLocksArgsForCall []struct {
+		pools    []string
+		username string
+		message  string
+	}
+	claimLocksReturns struct {
+		result1 error
+	}
+	claimLocksReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CreatePoolStub        func(pool, username string, metadata clocker.Metadata) error
 	createPoolMutex       sync.RWMutex
 	createPoolArgsForCall []struct {
 		pool     string
 		username string
+		metadata clocker.Metadata
 	}
 	createPoolReturns struct {
 		result1 error
@@ -45,16 +75,117 @@ type FakeLocker struct {
 	destroyPoolReturnsOnCall map[int]struct {
 		result1 error
 	}
-	ReleaseLockStub        func(pool, username string) error
-	releaseLockMutex       sync.RWMutex
-	releaseLockArgsForCall []struct {
+	DisablePoolStub        func(pool, username, reason string) error
+	disablePoolMutex       sync.RWMutex
+	disablePoolArgsForCall []struct {
+		pool     string
+		username string
+		reason   string
+	}
+	disablePoolReturns struct {
+		result1 error
+	}
+	disablePoolReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DoctorStub        func(fix bool, username string) ([]clocker.Problem, error)
+	doctorMutex       sync.RWMutex
+	doctorArgsForCall []struct {
+		fix      bool
+		username string
+	}
+	doctorReturns struct {
+		result1 []clocker.Problem
+		result2 error
+	}
+	doctorReturnsOnCall map[int]struct {
+		result1 []clocker.Problem
+		result2 error
+	}
+	EnablePoolStub        func(pool, username string) error
+	enablePoolMutex       sync.RWMutex
+	enablePoolArgsForCall []struct {
+		pool     string
+		username string
+	}
+	enablePoolReturns struct {
+		result1 error
+	}
+	enablePoolReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ExtendLockStub        func(pool, username string, expires time.Time) error
+	extendLockMutex       sync.RWMutex
+	extendLockArgsForCall []struct {
+		pool     string
+		username string
+		expires  time.Time
+	}
+	extendLockReturns struct {
+		result1 error
+	}
+	extendLockReturnsOnCall map[int]struct {
+		result1 error
+	}
+	HistoriesStub        func() ([]clocker.History, error)
+	historiesMutex       sync.RWMutex
+	historiesArgsForCall []struct{}
+	historiesReturns     struct {
+		result1 []clocker.History
+		result2 error
+	}
+	historiesReturnsOnCall map[int]struct {
+		result1 []clocker.History
+		result2 error
+	}
+	HistoryStub        func(pool string) (clocker.History, error)
+	historyMutex       sync.RWMutex
+	historyArgsForCall []struct {
+		pool string
+	}
+	historyReturns struct {
+		result1 clocker.History
+		result2 error
+	}
+	historyReturnsOnCall map[int]struct {
+		result1 clocker.History
+		result2 error
+	}
+	ReleaseLocksStub        func(pools []string, username string) error
+	releaseLocksMutex       sync.RWMutex
+	releaseLocksArgsForCall []struct {
+		pools    []string
+		username string
+	}
+	releaseLocksReturns struct {
+		result1 error
+	}
+	releaseLocksReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RenamePoolStub        func(pool, newName, username string) error
+	renamePoolMutex       sync.RWMutex
+	renamePoolArgsForCall []struct {
 		pool     string
+		newName  string
 		username string
 	}
-	releaseLockReturns struct {
+	renamePoolReturns struct {
 		result1 error
 	}
-	releaseLockReturnsOnCall map[int]struct {
+	renamePoolReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ReserveStub        func(pool string, reservation clocker.Reservation) error
+	reserveMutex       sync.RWMutex
+	reserveArgsForCall []struct {
+		pool        string
+		reservation clocker.Reservation
+	}
+	reserveReturns struct {
+		result1 error
+	}
+	reserveReturnsOnCall map[int]struct {
 		result1 error
 	}
 	StatusStub        func() (locks []clocker.Lock, err error)
@@ -72,67 +203,181 @@ type FakeLocker struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeLocker) ClaimLock(pool string, username string, message string) error {
-	fake.claimLockMutex.Lock()
-	ret, specificReturn := fake.claimLockReturnsOnCall[len(fake.claimLockArgsForCall)]
-	fake.claimLockArgsForCall = append(fake.claimLockArgsForCall, struct {
+func (fake *FakeLocker) AnnotateLock(pool string, username string, message string) error {
+	fake.annotateLockMutex.Lock()
+	ret, specificReturn := fake.annotateLockReturnsOnCall[len(fake.annotateLockArgsForCall)]
+	fake.annotateLockArgsForCall = append(fake.annotateLockArgsForCall, struct {
 		pool     string
 		username string
 		message  string
 	}{pool, username, message})
-	fake.recordInvocation("ClaimLock", []interface{}{pool, username, message})
-	fake.claimLockMutex.Unlock()
-	if fake.ClaimLockStub != nil {
-		return fake.ClaimLockStub(pool, username, message)
+	fake.recordInvocation("AnnotateLock", []interface{}{pool, username, message})
+	fake.annotateLockMutex.Unlock()
+	if fake.AnnotateLockStub != nil {
+		return fake.AnnotateLockStub(pool, username, message)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.claimLockReturns.result1
+	return fake.annotateLockReturns.result1
 }
 
-func (fake *FakeLocker) ClaimLockCallCount() int {
-	fake.claimLockMutex.RLock()
-	defer fake.claimLockMutex.RUnlock()
-	return len(fake.claimLockArgsForCall)
+func (fake *FakeLocker) AnnotateLockCallCount() int {
+	fake.annotateLockMutex.RLock()
+	defer fake.annotateLockMutex.RUnlock()
+	return len(fake.annotateLockArgsForCall)
 }
 
-func (fake *FakeLocker) ClaimLockArgsForCall(i int) (string, string, string) {
-	fake.claimLockMutex.RLock()
-	defer fake.claimLockMutex.RUnlock()
-	return fake.claimLockArgsForCall[i].pool, fake.claimLockArgsForCall[i].username, fake.claimLockArgsForCall[i].message
+func (fake *FakeLocker) AnnotateLockArgsForCall(i int) (string, string, string) {
+	fake.annotateLockMutex.RLock()
+	defer fake.annotateLockMutex.RUnlock()
+	return fake.annotateLockArgsForCall[i].pool, fake.annotateLockArgsForCall[i].username, fake.annotateLockArgsForCall[i].message
 }
 
-func (fake *FakeLocker) ClaimLockReturns(result1 error) {
-	fake.ClaimLockStub = nil
-	fake.claimLockReturns = struct {
+func (fake *FakeLocker) AnnotateLockReturns(result1 error) {
+	fake.AnnotateLockStub = nil
+	fake.annotateLockReturns = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *FakeLocker) ClaimLockReturnsOnCall(i int, result1 error) {
-	fake.ClaimLockStub = nil
-	if fake.claimLockReturnsOnCall == nil {
-		fake.claimLockReturnsOnCall = make(map[int]struct {
+func (fake *FakeLocker) AnnotateLockReturnsOnCall(i int, result1 error) {
+	fake.AnnotateLockStub = nil
+	if fake.annotateLockReturnsOnCall == nil {
+		fake.annotateLockReturnsOnCall = make(map[int]struct {
 			result1 error
 		})
 	}
-	fake.claimLockReturnsOnCall[i] = struct {
+	fake.annotateLockReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *FakeLocker) CreatePool(pool string, username string) error {
+func (fake *FakeLocker) ClaimAny(pools []string, username string, message string) (string, error) {
+	var poolsCopy []string
+	if pools != nil {
+		poolsCopy = make([]string, len(pools))
+		copy(poolsCopy, pools)
+	}
+	fake.claimAnyMutex.Lock()
+	ret, specificReturn := fake.claimAnyReturnsOnCall[len(fake.claimAnyArgsForCall)]
+	fake.claimAnyArgsForCall = append(fake.claimAnyArgsForCall, struct {
+		pools    []string
+		username string
+		message  string
+	}{poolsCopy, username, message})
+	fake.recordInvocation("ClaimAny", []interface{}{poolsCopy, username, message})
+	fake.claimAnyMutex.Unlock()
+	if fake.ClaimAnyStub != nil {
+		return fake.ClaimAnyStub(pools, username, message)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.claimAnyReturns.result1, fake.claimAnyReturns.result2
+}
+
+func (fake *FakeLocker) ClaimAnyCallCount() int {
+	fake.claimAnyMutex.RLock()
+	defer fake.claimAnyMutex.RUnlock()
+	return len(fake.claimAnyArgsForCall)
+}
+
+func (fake *FakeLocker) ClaimAnyArgsForCall(i int) ([]string, string, string) {
+	fake.claimAnyMutex.RLock()
+	defer fake.claimAnyMutex.RUnlock()
+	return fake.claimAnyArgsForCall[i].pools, fake.claimAnyArgsForCall[i].username, fake.claimAnyArgsForCall[i].message
+}
+
+func (fake *FakeLocker) ClaimAnyReturns(result1 string, result2 error) {
+	fake.ClaimAnyStub = nil
+	fake.claimAnyReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocker) ClaimAnyReturnsOnCall(i int, result1 string, result2 error) {
+	fake.ClaimAnyStub = nil
+	if fake.claimAnyReturnsOnCall == nil {
+		fake.claimAnyReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.claimAnyReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocker) ClaimLocks(pools []string, username string, message string) error {
+	var poolsCopy []string
+	if pools != nil {
+		poolsCopy = make([]string, len(pools))
+		copy(poolsCopy, pools)
+	}
+	fake.claimLocksMutex.Lock()
+	ret, specificReturn := fake.claimLocksReturnsOnCall[len(fake.claimLocksArgsForCall)]
+	fake.claimLocksArgsForCall = append(fake.claimLocksArgsForCall, struct {
+		pools    []string
+		username string
+		message  string
+	}{poolsCopy, username, message})
+	fake.recordInvocation("ClaimLocks", []interface{}{poolsCopy, username, message})
+	fake.claimLocksMutex.Unlock()
+	if fake.ClaimLocksStub != nil {
+		return fake.ClaimLocksStub(pools, username, message)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.claimLocksReturns.result1
+}
+
+func (fake *FakeLocker) ClaimLocksCallCount() int {
+	fake.claimLocksMutex.RLock()
+	defer fake.claimLocksMutex.RUnlock()
+	return len(fake.claimLocksArgsForCall)
+}
+
+func (fake *FakeLocker) ClaimLocksArgsForCall(i int) ([]string, string, string) {
+	fake.claimLocksMutex.RLock()
+	defer fake.claimLocksMutex.RUnlock()
+	return fake.claimLocksArgsForCall[i].pools, fake.claimLocksArgsForCall[i].username, fake.claimLocksArgsForCall[i].message
+}
+
+func (fake *FakeLocker) ClaimLocksReturns(result1 error) {
+	fake.ClaimLocksStub = nil
+	fake.claimLocksReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLocker) ClaimLocksReturnsOnCall(i int, result1 error) {
+	fake.ClaimLocksStub = nil
+	if fake.claimLocksReturnsOnCall == nil {
+		fake.claimLocksReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.claimLocksReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLocker) CreatePool(pool string, username string, metadata clocker.Metadata) error {
 	fake.createPoolMutex.Lock()
 	ret, specificReturn := fake.createPoolReturnsOnCall[len(fake.createPoolArgsForCall)]
 	fake.createPoolArgsForCall = append(fake.createPoolArgsForCall, struct {
 		pool     string
 		username string
-	}{pool, username})
-	fake.recordInvocation("CreatePool", []interface{}{pool, username})
+		metadata clocker.Metadata
+	}{pool, username, metadata})
+	fake.recordInvocation("CreatePool", []interface{}{pool, username, metadata})
 	fake.createPoolMutex.Unlock()
 	if fake.CreatePoolStub != nil {
-		return fake.CreatePoolStub(pool, username)
+		return fake.CreatePoolStub(pool, username, metadata)
 	}
 	if specificReturn {
 		return ret.result1
@@ -146,10 +391,10 @@ func (fake *FakeLocker) CreatePoolCallCount() int {
 	return len(fake.createPoolArgsForCall)
 }
 
-func (fake *FakeLocker) CreatePoolArgsForCall(i int) (string, string) {
+func (fake *FakeLocker) CreatePoolArgsForCall(i int) (string, string, clocker.Metadata) {
 	fake.createPoolMutex.RLock()
 	defer fake.createPoolMutex.RUnlock()
-	return fake.createPoolArgsForCall[i].pool, fake.createPoolArgsForCall[i].username
+	return fake.createPoolArgsForCall[i].pool, fake.createPoolArgsForCall[i].username, fake.createPoolArgsForCall[i].metadata
 }
 
 func (fake *FakeLocker) CreatePoolReturns(result1 error) {
@@ -220,51 +465,450 @@ func (fake *FakeLocker) DestroyPoolReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeLocker) ReleaseLock(pool string, username string) error {
-	fake.releaseLockMutex.Lock()
-	ret, specificReturn := fake.releaseLockReturnsOnCall[len(fake.releaseLockArgsForCall)]
-	fake.releaseLockArgsForCall = append(fake.releaseLockArgsForCall, struct {
+func (fake *FakeLocker) DisablePool(pool string, username string, reason string) error {
+	fake.disablePoolMutex.Lock()
+	ret, specificReturn := fake.disablePoolReturnsOnCall[len(fake.disablePoolArgsForCall)]
+	fake.disablePoolArgsForCall = append(fake.disablePoolArgsForCall, struct {
+		pool     string
+		username string
+		reason   string
+	}{pool, username, reason})
+	fake.recordInvocation("DisablePool", []interface{}{pool, username, reason})
+	fake.disablePoolMutex.Unlock()
+	if fake.DisablePoolStub != nil {
+		return fake.DisablePoolStub(pool, username, reason)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.disablePoolReturns.result1
+}
+
+func (fake *FakeLocker) DisablePoolCallCount() int {
+	fake.disablePoolMutex.RLock()
+	defer fake.disablePoolMutex.RUnlock()
+	return len(fake.disablePoolArgsForCall)
+}
+
+func (fake *FakeLocker) DisablePoolArgsForCall(i int) (string, string, string) {
+	fake.disablePoolMutex.RLock()
+	defer fake.disablePoolMutex.RUnlock()
+	return fake.disablePoolArgsForCall[i].pool, fake.disablePoolArgsForCall[i].username, fake.disablePoolArgsForCall[i].reason
+}
+
+func (fake *FakeLocker) DisablePoolReturns(result1 error) {
+	fake.DisablePoolStub = nil
+	fake.disablePoolReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLocker) DisablePoolReturnsOnCall(i int, result1 error) {
+	fake.DisablePoolStub = nil
+	if fake.disablePoolReturnsOnCall == nil {
+		fake.disablePoolReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.disablePoolReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLocker) Doctor(fix bool, username string) ([]clocker.Problem, error) {
+	fake.doctorMutex.Lock()
+	ret, specificReturn := fake.doctorReturnsOnCall[len(fake.doctorArgsForCall)]
+	fake.doctorArgsForCall = append(fake.doctorArgsForCall, struct {
+		fix      bool
+		username string
+	}{fix, username})
+	fake.recordInvocation("Doctor", []interface{}{fix, username})
+	fake.doctorMutex.Unlock()
+	if fake.DoctorStub != nil {
+		return fake.DoctorStub(fix, username)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.doctorReturns.result1, fake.doctorReturns.result2
+}
+
+func (fake *FakeLocker) DoctorCallCount() int {
+	fake.doctorMutex.RLock()
+	defer fake.doctorMutex.RUnlock()
+	return len(fake.doctorArgsForCall)
+}
+
+func (fake *FakeLocker) DoctorArgsForCall(i int) (bool, string) {
+	fake.doctorMutex.RLock()
+	defer fake.doctorMutex.RUnlock()
+	return fake.doctorArgsForCall[i].fix, fake.doctorArgsForCall[i].username
+}
+
+func (fake *FakeLocker) DoctorReturns(result1 []clocker.Problem, result2 error) {
+	fake.DoctorStub = nil
+	fake.doctorReturns = struct {
+		result1 []clocker.Problem
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocker) DoctorReturnsOnCall(i int, result1 []clocker.Problem, result2 error) {
+	fake.DoctorStub = nil
+	if fake.doctorReturnsOnCall == nil {
+		fake.doctorReturnsOnCall = make(map[int]struct {
+			result1 []clocker.Problem
+			result2 error
+		})
+	}
+	fake.doctorReturnsOnCall[i] = struct {
+		result1 []clocker.Problem
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocker) EnablePool(pool string, username string) error {
+	fake.enablePoolMutex.Lock()
+	ret, specificReturn := fake.enablePoolReturnsOnCall[len(fake.enablePoolArgsForCall)]
+	fake.enablePoolArgsForCall = append(fake.enablePoolArgsForCall, struct {
 		pool     string
 		username string
 	}{pool, username})
-	fake.recordInvocation("ReleaseLock", []interface{}{pool, username})
-	fake.releaseLockMutex.Unlock()
-	if fake.ReleaseLockStub != nil {
-		return fake.ReleaseLockStub(pool, username)
+	fake.recordInvocation("EnablePool", []interface{}{pool, username})
+	fake.enablePoolMutex.Unlock()
+	if fake.EnablePoolStub != nil {
+		return fake.EnablePoolStub(pool, username)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.enablePoolReturns.result1
+}
+
+func (fake *FakeLocker) EnablePoolCallCount() int {
+	fake.enablePoolMutex.RLock()
+	defer fake.enablePoolMutex.RUnlock()
+	return len(fake.enablePoolArgsForCall)
+}
+
+func (fake *FakeLocker) EnablePoolArgsForCall(i int) (string, string) {
+	fake.enablePoolMutex.RLock()
+	defer fake.enablePoolMutex.RUnlock()
+	return fake.enablePoolArgsForCall[i].pool, fake.enablePoolArgsForCall[i].username
+}
+
+func (fake *FakeLocker) EnablePoolReturns(result1 error) {
+	fake.EnablePoolStub = nil
+	fake.enablePoolReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLocker) EnablePoolReturnsOnCall(i int, result1 error) {
+	fake.EnablePoolStub = nil
+	if fake.enablePoolReturnsOnCall == nil {
+		fake.enablePoolReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.enablePoolReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLocker) ExtendLock(pool string, username string, expires time.Time) error {
+	fake.extendLockMutex.Lock()
+	ret, specificReturn := fake.extendLockReturnsOnCall[len(fake.extendLockArgsForCall)]
+	fake.extendLockArgsForCall = append(fake.extendLockArgsForCall, struct {
+		pool     string
+		username string
+		expires  time.Time
+	}{pool, username, expires})
+	fake.recordInvocation("ExtendLock", []interface{}{pool, username, expires})
+	fake.extendLockMutex.Unlock()
+	if fake.ExtendLockStub != nil {
+		return fake.ExtendLockStub(pool, username, expires)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.extendLockReturns.result1
+}
+
+func (fake *FakeLocker) ExtendLockCallCount() int {
+	fake.extendLockMutex.RLock()
+	defer fake.extendLockMutex.RUnlock()
+	return len(fake.extendLockArgsForCall)
+}
+
+func (fake *FakeLocker) ExtendLockArgsForCall(i int) (string, string, time.Time) {
+	fake.extendLockMutex.RLock()
+	defer fake.extendLockMutex.RUnlock()
+	return fake.extendLockArgsForCall[i].pool, fake.extendLockArgsForCall[i].username, fake.extendLockArgsForCall[i].expires
+}
+
+func (fake *FakeLocker) ExtendLockReturns(result1 error) {
+	fake.ExtendLockStub = nil
+	fake.extendLockReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLocker) ExtendLockReturnsOnCall(i int, result1 error) {
+	fake.ExtendLockStub = nil
+	if fake.extendLockReturnsOnCall == nil {
+		fake.extendLockReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.extendLockReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLocker) Histories() ([]clocker.History, error) {
+	fake.historiesMutex.Lock()
+	ret, specificReturn := fake.historiesReturnsOnCall[len(fake.historiesArgsForCall)]
+	fake.historiesArgsForCall = append(fake.historiesArgsForCall, struct{}{})
+	fake.recordInvocation("Histories", []interface{}{})
+	fake.historiesMutex.Unlock()
+	if fake.HistoriesStub != nil {
+		return fake.HistoriesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.historiesReturns.result1, fake.historiesReturns.result2
+}
+
+func (fake *FakeLocker) HistoriesCallCount() int {
+	fake.historiesMutex.RLock()
+	defer fake.historiesMutex.RUnlock()
+	return len(fake.historiesArgsForCall)
+}
+
+func (fake *FakeLocker) HistoriesReturns(result1 []clocker.History, result2 error) {
+	fake.HistoriesStub = nil
+	fake.historiesReturns = struct {
+		result1 []clocker.History
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocker) HistoriesReturnsOnCall(i int, result1 []clocker.History, result2 error) {
+	fake.HistoriesStub = nil
+	if fake.historiesReturnsOnCall == nil {
+		fake.historiesReturnsOnCall = make(map[int]struct {
+			result1 []clocker.History
+			result2 error
+		})
+	}
+	fake.historiesReturnsOnCall[i] = struct {
+		result1 []clocker.History
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocker) History(pool string) (clocker.History, error) {
+	fake.historyMutex.Lock()
+	ret, specificReturn := fake.historyReturnsOnCall[len(fake.historyArgsForCall)]
+	fake.historyArgsForCall = append(fake.historyArgsForCall, struct {
+		pool string
+	}{pool})
+	fake.recordInvocation("History", []interface{}{pool})
+	fake.historyMutex.Unlock()
+	if fake.HistoryStub != nil {
+		return fake.HistoryStub(pool)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.historyReturns.result1, fake.historyReturns.result2
+}
+
+func (fake *FakeLocker) HistoryCallCount() int {
+	fake.historyMutex.RLock()
+	defer fake.historyMutex.RUnlock()
+	return len(fake.historyArgsForCall)
+}
+
+func (fake *FakeLocker) HistoryArgsForCall(i int) string {
+	fake.historyMutex.RLock()
+	defer fake.historyMutex.RUnlock()
+	return fake.historyArgsForCall[i].pool
+}
+
+func (fake *FakeLocker) HistoryReturns(result1 clocker.History, result2 error) {
+	fake.HistoryStub = nil
+	fake.historyReturns = struct {
+		result1 clocker.History
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocker) HistoryReturnsOnCall(i int, result1 clocker.History, result2 error) {
+	fake.HistoryStub = nil
+	if fake.historyReturnsOnCall == nil {
+		fake.historyReturnsOnCall = make(map[int]struct {
+			result1 clocker.History
+			result2 error
+		})
+	}
+	fake.historyReturnsOnCall[i] = struct {
+		result1 clocker.History
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocker) ReleaseLocks(pools []string, username string) error {
+	var poolsCopy []string
+	if pools != nil {
+		poolsCopy = make([]string, len(pools))
+		copy(poolsCopy, pools)
+	}
+	fake.releaseLocksMutex.Lock()
+	ret, specificReturn := fake.releaseLocksReturnsOnCall[len(fake.releaseLocksArgsForCall)]
+	fake.releaseLocksArgsForCall = append(fake.releaseLocksArgsForCall, struct {
+		pools    []string
+		username string
+	}{poolsCopy, username})
+	fake.recordInvocation("ReleaseLocks", []interface{}{poolsCopy, username})
+	fake.releaseLocksMutex.Unlock()
+	if fake.ReleaseLocksStub != nil {
+		return fake.ReleaseLocksStub(pools, username)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.releaseLocksReturns.result1
+}
+
+func (fake *FakeLocker) ReleaseLocksCallCount() int {
+	fake.releaseLocksMutex.RLock()
+	defer fake.releaseLocksMutex.RUnlock()
+	return len(fake.releaseLocksArgsForCall)
+}
+
+func (fake *FakeLocker) ReleaseLocksArgsForCall(i int) ([]string, string) {
+	fake.releaseLocksMutex.RLock()
+	defer fake.releaseLocksMutex.RUnlock()
+	return fake.releaseLocksArgsForCall[i].pools, fake.releaseLocksArgsForCall[i].username
+}
+
+func (fake *FakeLocker) ReleaseLocksReturns(result1 error) {
+	fake.ReleaseLocksStub = nil
+	fake.releaseLocksReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLocker) ReleaseLocksReturnsOnCall(i int, result1 error) {
+	fake.ReleaseLocksStub = nil
+	if fake.releaseLocksReturnsOnCall == nil {
+		fake.releaseLocksReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.releaseLocksReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLocker) RenamePool(pool string, newName string, username string) error {
+	fake.renamePoolMutex.Lock()
+	ret, specificReturn := fake.renamePoolReturnsOnCall[len(fake.renamePoolArgsForCall)]
+	fake.renamePoolArgsForCall = append(fake.renamePoolArgsForCall, struct {
+		pool     string
+		newName  string
+		username string
+	}{pool, newName, username})
+	fake.recordInvocation("RenamePool", []interface{}{pool, newName, username})
+	fake.renamePoolMutex.Unlock()
+	if fake.RenamePoolStub != nil {
+		return fake.RenamePoolStub(pool, newName, username)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.renamePoolReturns.result1
+}
+
+func (fake *FakeLocker) RenamePoolCallCount() int {
+	fake.renamePoolMutex.RLock()
+	defer fake.renamePoolMutex.RUnlock()
+	return len(fake.renamePoolArgsForCall)
+}
+
+func (fake *FakeLocker) RenamePoolArgsForCall(i int) (string, string, string) {
+	fake.renamePoolMutex.RLock()
+	defer fake.renamePoolMutex.RUnlock()
+	return fake.renamePoolArgsForCall[i].pool, fake.renamePoolArgsForCall[i].newName, fake.renamePoolArgsForCall[i].username
+}
+
+func (fake *FakeLocker) RenamePoolReturns(result1 error) {
+	fake.RenamePoolStub = nil
+	fake.renamePoolReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLocker) RenamePoolReturnsOnCall(i int, result1 error) {
+	fake.RenamePoolStub = nil
+	if fake.renamePoolReturnsOnCall == nil {
+		fake.renamePoolReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.renamePoolReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLocker) Reserve(pool string, reservation clocker.Reservation) error {
+	fake.reserveMutex.Lock()
+	ret, specificReturn := fake.reserveReturnsOnCall[len(fake.reserveArgsForCall)]
+	fake.reserveArgsForCall = append(fake.reserveArgsForCall, struct {
+		pool        string
+		reservation clocker.Reservation
+	}{pool, reservation})
+	fake.recordInvocation("Reserve", []interface{}{pool, reservation})
+	fake.reserveMutex.Unlock()
+	if fake.ReserveStub != nil {
+		return fake.ReserveStub(pool, reservation)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.releaseLockReturns.result1
+	return fake.reserveReturns.result1
 }
 
-func (fake *FakeLocker) ReleaseLockCallCount() int {
-	fake.releaseLockMutex.RLock()
-	defer fake.releaseLockMutex.RUnlock()
-	return len(fake.releaseLockArgsForCall)
+func (fake *FakeLocker) ReserveCallCount() int {
+	fake.reserveMutex.RLock()
+	defer fake.reserveMutex.RUnlock()
+	return len(fake.reserveArgsForCall)
 }
 
-func (fake *FakeLocker) ReleaseLockArgsForCall(i int) (string, string) {
-	fake.releaseLockMutex.RLock()
-	defer fake.releaseLockMutex.RUnlock()
-	return fake.releaseLockArgsForCall[i].pool, fake.releaseLockArgsForCall[i].username
+func (fake *FakeLocker) ReserveArgsForCall(i int) (string, clocker.Reservation) {
+	fake.reserveMutex.RLock()
+	defer fake.reserveMutex.RUnlock()
+	return fake.reserveArgsForCall[i].pool, fake.reserveArgsForCall[i].reservation
 }
 
-func (fake *FakeLocker) ReleaseLockReturns(result1 error) {
-	fake.ReleaseLockStub = nil
-	fake.releaseLockReturns = struct {
+func (fake *FakeLocker) ReserveReturns(result1 error) {
+	fake.ReserveStub = nil
+	fake.reserveReturns = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *FakeLocker) ReleaseLockReturnsOnCall(i int, result1 error) {
-	fake.ReleaseLockStub = nil
-	if fake.releaseLockReturnsOnCall == nil {
-		fake.releaseLockReturnsOnCall = make(map[int]struct {
+func (fake *FakeLocker) ReserveReturnsOnCall(i int, result1 error) {
+	fake.ReserveStub = nil
+	if fake.reserveReturnsOnCall == nil {
+		fake.reserveReturnsOnCall = make(map[int]struct {
 			result1 error
 		})
 	}
-	fake.releaseLockReturnsOnCall[i] = struct {
+	fake.reserveReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
@@ -315,14 +959,34 @@ func (fake *FakeLocker) StatusReturnsOnCall(i int, result1 []clocker.Lock, resul
 func (fake *FakeLocker) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.claimLockMutex.RLock()
-	defer fake.claimLockMutex.RUnlock()
+	fake.annotateLockMutex.RLock()
+	defer fake.annotateLockMutex.RUnlock()
+	fake.claimAnyMutex.RLock()
+	defer fake.claimAnyMutex.RUnlock()
+	fake.claimLocksMutex.RLock()
+	defer fake.claimLocksMutex.RUnlock()
 	fake.createPoolMutex.RLock()
 	defer fake.createPoolMutex.RUnlock()
 	fake.destroyPoolMutex.RLock()
 	defer fake.destroyPoolMutex.RUnlock()
-	fake.releaseLockMutex.RLock()
-	defer fake.releaseLockMutex.RUnlock()
+	fake.disablePoolMutex.RLock()
+	defer fake.disablePoolMutex.RUnlock()
+	fake.doctorMutex.RLock()
+	defer fake.doctorMutex.RUnlock()
+	fake.enablePoolMutex.RLock()
+	defer fake.enablePoolMutex.RUnlock()
+	fake.extendLockMutex.RLock()
+	defer fake.extendLockMutex.RUnlock()
+	fake.historiesMutex.RLock()
+	defer fake.historiesMutex.RUnlock()
+	fake.historyMutex.RLock()
+	defer fake.historyMutex.RUnlock()
+	fake.releaseLocksMutex.RLock()
+	defer fake.releaseLocksMutex.RUnlock()
+	fake.renamePoolMutex.RLock()
+	defer fake.renamePoolMutex.RUnlock()
+	fake.reserveMutex.RLock()
+	defer fake.reserveMutex.RUnlock()
 	fake.statusMutex.RLock()
 	defer fake.statusMutex.RUnlock()
 	return fake.invocations
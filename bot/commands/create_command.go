@@ -3,8 +3,7 @@ package commands
 import (
 	"strings"
 
-	. "github.com/mdelillo/claimer/translate"
-	"github.com/pkg/errors"
+	clocker "github.com/mdelillo/claimer/locker"
 )
 
 type createCommand struct {
@@ -14,23 +13,30 @@ type createCommand struct {
 }
 
 func (c *createCommand) Execute() (string, error) {
+	var pool string
+	var metadata clocker.Metadata
 	args := strings.Fields(c.args)
-	if len(c.args) < 1 {
-		return T("create.no_pool", nil), nil
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--description":
+			// the description runs until the next option
+			var words []string
+			for i+1 < len(args) && !strings.HasPrefix(args[i+1], "--") {
+				i++
+				words = append(words, args[i])
+			}
+			metadata.Description = strings.Join(words, " ")
+		case args[i] == "--tag":
+			if i+1 < len(args) {
+				i++
+				metadata.Tags = append(metadata.Tags, args[i])
+			}
+		case strings.HasPrefix(args[i], "--tag="):
+			metadata.Tags = append(metadata.Tags, strings.TrimPrefix(args[i], "--tag="))
+		case pool == "":
+			pool = args[i]
+		}
 	}
-	pool := args[0]
-
-	locks, err := c.locker.Status()
-	if err != nil {
-		return "", errors.Wrap(err, "failed to get status of locks")
-	}
-	if poolExists(pool, locks) {
-		return T("create.pool_already_exists", TArgs{"pool": pool}), nil
-	}
-
-	if err := c.locker.CreatePool(pool, c.username); err != nil {
-		return "", errors.Wrap(err, "failed to create pool")
-	}
-
-	return T("create.success", TArgs{"pool": pool}), nil
+	result, err := NewActions(c.locker).Create(pool, c.username, metadata)
+	return result.Response, err
 }
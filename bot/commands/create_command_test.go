@@ -32,9 +32,26 @@ var _ = Describe("CreateCommand", func() {
 			Expect(slackResponse).To(Equal("Created " + pool))
 
 			Expect(locker.CreatePoolCallCount()).To(Equal(1))
-			actualPool, actualUsername := locker.CreatePoolArgsForCall(0)
+			actualPool, actualUsername, actualMetadata := locker.CreatePoolArgsForCall(0)
 			Expect(actualPool).To(Equal(pool))
 			Expect(actualUsername).To(Equal(username))
+			Expect(actualMetadata).To(Equal(clocker.Metadata{}))
+		})
+
+		It("creates the pool with a description and tags", func() {
+			command := NewFactory(locker).NewCommand("create", "--tag gcp some-pool --description Some long description --tag=us-east", "")
+
+			slackResponse, err := command.Execute()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(slackResponse).To(Equal("Created some-pool"))
+
+			Expect(locker.CreatePoolCallCount()).To(Equal(1))
+			actualPool, _, actualMetadata := locker.CreatePoolArgsForCall(0)
+			Expect(actualPool).To(Equal("some-pool"))
+			Expect(actualMetadata).To(Equal(clocker.Metadata{
+				Description: "Some long description",
+				Tags:        []string{"gcp", "us-east"},
+			}))
 		})
 
 		Context("when no pool is specified", func() {
@@ -47,6 +64,17 @@ var _ = Describe("CreateCommand", func() {
 			})
 		})
 
+		Context("when only options are specified", func() {
+			It("returns a slack response", func() {
+				command := NewFactory(locker).NewCommand("create", "--tag gcp", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("must specify name of pool to create"))
+				Expect(locker.CreatePoolCallCount()).To(Equal(0))
+			})
+		})
+
 		Context("when checking the status fails", func() {
 			It("returns an error", func() {
 				locker.StatusReturns(nil, errors.New("some-error"))
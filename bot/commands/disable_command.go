@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"strings"
+
+	. "github.com/mdelillo/claimer/translate"
+	"github.com/pkg/errors"
+)
+
+type disableCommand struct {
+	locker   locker
+	args     string
+	username string
+}
+
+func (c *disableCommand) Execute() (string, error) {
+	args := strings.SplitN(c.args, " ", 2)
+	if len(c.args) < 1 {
+		return T("disable.no_pool", nil), nil
+	}
+	pool := args[0]
+	var reason string
+	if len(args) > 1 {
+		reason = args[1]
+	}
+
+	locks, err := c.locker.Status()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get status of locks")
+	}
+	if !poolExists(pool, locks) {
+		return T("disable.pool_does_not_exist", TArgs{"pool": pool}), nil
+	}
+	lock := getLock(pool, locks)
+	if lock.Maintenance != nil {
+		return T("disable.pool_is_already_disabled", TArgs{"pool": pool}), nil
+	}
+	if lock.Claimed {
+		return T("disable.pool_is_claimed", TArgs{"pool": pool, "owner": lock.Owner}), nil
+	}
+
+	if err := c.locker.DisablePool(pool, c.username, reason); err != nil {
+		return "", errors.Wrap(err, "failed to disable pool")
+	}
+
+	return T("disable.success", TArgs{"pool": pool}), nil
+}
@@ -0,0 +1,104 @@
+package commands_test
+
+import (
+	. "github.com/mdelillo/claimer/bot/commands"
+
+	"errors"
+
+	"github.com/mdelillo/claimer/bot/commands/commandsfakes"
+	clocker "github.com/mdelillo/claimer/locker"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DisableCommand", func() {
+	Describe("Execute", func() {
+		var locker *commandsfakes.FakeLocker
+
+		BeforeEach(func() {
+			locker = new(commandsfakes.FakeLocker)
+			locker.StatusReturns([]clocker.Lock{{Name: "some-pool"}}, nil)
+		})
+
+		It("disables the pool and returns a slack response", func() {
+			command := NewFactory(locker).NewCommand("disable", "some-pool being repaved", "some-username")
+
+			slackResponse, err := command.Execute()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(slackResponse).To(Equal("Disabled some-pool"))
+
+			Expect(locker.DisablePoolCallCount()).To(Equal(1))
+			actualPool, actualUsername, actualReason := locker.DisablePoolArgsForCall(0)
+			Expect(actualPool).To(Equal("some-pool"))
+			Expect(actualUsername).To(Equal("some-username"))
+			Expect(actualReason).To(Equal("being repaved"))
+		})
+
+		It("does not need a reason", func() {
+			_, err := NewFactory(locker).NewCommand("disable", "some-pool", "some-username").Execute()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, actualReason := locker.DisablePoolArgsForCall(0)
+			Expect(actualReason).To(BeEmpty())
+		})
+
+		Context("when no pool is specified", func() {
+			It("returns a slack response", func() {
+				slackResponse, err := NewFactory(locker).NewCommand("disable", "", "").Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("must specify pool to disable"))
+			})
+		})
+
+		Context("when the pool does not exist", func() {
+			It("returns a slack response", func() {
+				slackResponse, err := NewFactory(locker).NewCommand("disable", "some-other-pool", "").Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("some-other-pool does not exist"))
+				Expect(locker.DisablePoolCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the pool is already disabled", func() {
+			It("returns a slack response", func() {
+				locker.StatusReturns([]clocker.Lock{{Name: "some-pool", Maintenance: &clocker.Maintenance{By: "some-user"}}}, nil)
+
+				slackResponse, err := NewFactory(locker).NewCommand("disable", "some-pool", "").Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("some-pool is already disabled"))
+				Expect(locker.DisablePoolCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the pool is claimed", func() {
+			It("returns a slack response", func() {
+				locker.StatusReturns([]clocker.Lock{{Name: "some-pool", Claimed: true, Owner: "some-owner"}}, nil)
+
+				slackResponse, err := NewFactory(locker).NewCommand("disable", "some-pool", "").Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("some-pool is claimed by some-owner, it must be released first"))
+				Expect(locker.DisablePoolCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when checking the status fails", func() {
+			It("returns an error", func() {
+				locker.StatusReturns(nil, errors.New("some-error"))
+
+				slackResponse, err := NewFactory(locker).NewCommand("disable", "some-pool", "").Execute()
+				Expect(err).To(MatchError("failed to get status of locks: some-error"))
+				Expect(slackResponse).To(BeEmpty())
+			})
+		})
+
+		Context("when disabling the pool fails", func() {
+			It("returns an error", func() {
+				locker.DisablePoolReturns(errors.New("some-error"))
+
+				slackResponse, err := NewFactory(locker).NewCommand("disable", "some-pool", "").Execute()
+				Expect(err).To(MatchError("failed to disable pool: some-error"))
+				Expect(slackResponse).To(BeEmpty())
+			})
+		})
+	})
+})
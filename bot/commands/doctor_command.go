@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/mdelillo/claimer/translate"
+	"github.com/pkg/errors"
+)
+
+type doctorCommand struct {
+	locker   locker
+	args     string
+	username string
+}
+
+func (d *doctorCommand) Execute() (string, error) {
+	fix := contains(strings.Fields(d.args), "--fix")
+
+	problems, err := d.locker.Doctor(fix, d.username)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to check pools")
+	}
+	if len(problems) == 0 {
+		return T("doctor.healthy", nil), nil
+	}
+
+	lines := []string{T("doctor.header", nil)}
+	fixable := false
+	for _, problem := range problems {
+		line := T("doctor."+string(problem.Kind), TArgs{"pool": problem.Pool, "file": problem.File})
+		if problem.Fixed {
+			line = fmt.Sprintf("%s %s", line, T("doctor.fixed", nil))
+		}
+		lines = append(lines, line)
+		fixable = fixable || problem.Fixable && !problem.Fixed
+	}
+	if fixable {
+		lines = append(lines, T("doctor.fix_hint", nil))
+	}
+	return strings.Join(lines, "\n"), nil
+}
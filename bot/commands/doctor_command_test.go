@@ -0,0 +1,97 @@
+package commands_test
+
+import (
+	. "github.com/mdelillo/claimer/bot/commands"
+
+	"errors"
+
+	"github.com/mdelillo/claimer/bot/commands/commandsfakes"
+	clocker "github.com/mdelillo/claimer/locker"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DoctorCommand", func() {
+	Describe("Execute", func() {
+		var locker *commandsfakes.FakeLocker
+
+		BeforeEach(func() {
+			locker = new(commandsfakes.FakeLocker)
+		})
+
+		It("lists the problems with pools", func() {
+			locker.DoctorReturns([]clocker.Problem{
+				{Kind: clocker.ProblemMissingDir, Pool: "pool-a", File: "pool-a/claimed", Fixable: true},
+				{Kind: clocker.ProblemNoLock, Pool: "pool-b", File: "pool-b/unclaimed/pool-b", Fixable: true},
+				{Kind: clocker.ProblemTooManyLocks, Pool: "pool-c", File: "pool-c/claimed/lock-a, pool-c/unclaimed/lock-b"},
+				{Kind: clocker.ProblemOrphanedLock, Pool: "pool-d", File: "pool-d/lock"},
+				{Kind: clocker.ProblemStrayFile, Pool: "pool-e", File: "pool-e/some-dir"},
+				{Kind: clocker.ProblemLockNameMismatch, Pool: "pool-f", File: "pool-f/unclaimed/lock"},
+				{Kind: clocker.ProblemStaleClaimFile, Pool: "pool-g", File: "pool-g/claim.yml"},
+			}, nil)
+
+			command := NewFactory(locker).NewCommand("doctor", "", "some-user")
+
+			slackResponse, err := command.Execute()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(slackResponse).To(Equal("Found problems with these pools:\n" +
+				"pool-a: pool-a/claimed is missing\n" +
+				"pool-b: has no lock\n" +
+				"pool-c: has more than one lock (pool-c/claimed/lock-a, pool-c/unclaimed/lock-b)\n" +
+				"pool-d: pool-d/lock is not in claimed or unclaimed\n" +
+				"pool-e: pool-e/some-dir should not be there\n" +
+				"pool-f: lock pool-f/unclaimed/lock is not named after the pool\n" +
+				"pool-g: has a claim file but is not claimed\n" +
+				"Run `doctor --fix` to repair what can be repaired",
+			))
+
+			Expect(locker.DoctorCallCount()).To(Equal(1))
+			fix, username := locker.DoctorArgsForCall(0)
+			Expect(fix).To(BeFalse())
+			Expect(username).To(Equal("some-user"))
+		})
+
+		Context("when --fix is given", func() {
+			It("repairs the pools and shows what was fixed", func() {
+				locker.DoctorReturns([]clocker.Problem{
+					{Kind: clocker.ProblemNoLock, Pool: "pool-b", File: "pool-b/unclaimed/pool-b", Fixable: true, Fixed: true},
+					{Kind: clocker.ProblemStrayFile, Pool: "pool-e", File: "pool-e/some-dir"},
+				}, nil)
+
+				command := NewFactory(locker).NewCommand("doctor", "--fix", "some-user")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("Found problems with these pools:\n" +
+					"pool-b: has no lock (fixed)\n" +
+					"pool-e: pool-e/some-dir should not be there",
+				))
+
+				fix, _ := locker.DoctorArgsForCall(0)
+				Expect(fix).To(BeTrue())
+			})
+		})
+
+		Context("when there are no problems", func() {
+			It("returns a slack response", func() {
+				command := NewFactory(locker).NewCommand("doctor", "", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("No problems found"))
+			})
+		})
+
+		Context("when checking the pools fails", func() {
+			It("returns an error", func() {
+				locker.DoctorReturns(nil, errors.New("some-error"))
+
+				command := NewFactory(locker).NewCommand("doctor", "", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).To(MatchError("failed to check pools: some-error"))
+				Expect(slackResponse).To(BeEmpty())
+			})
+		})
+	})
+})
@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"strings"
+
+	. "github.com/mdelillo/claimer/translate"
+	"github.com/pkg/errors"
+)
+
+type enableCommand struct {
+	locker   locker
+	args     string
+	username string
+}
+
+func (c *enableCommand) Execute() (string, error) {
+	args := strings.Fields(c.args)
+	if len(args) < 1 {
+		return T("enable.no_pool", nil), nil
+	}
+	pool := args[0]
+
+	locks, err := c.locker.Status()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get status of locks")
+	}
+	if !poolExists(pool, locks) {
+		return T("enable.pool_does_not_exist", TArgs{"pool": pool}), nil
+	}
+	if getLock(pool, locks).Maintenance == nil {
+		return T("enable.pool_is_not_disabled", TArgs{"pool": pool}), nil
+	}
+
+	if err := c.locker.EnablePool(pool, c.username); err != nil {
+		return "", errors.Wrap(err, "failed to enable pool")
+	}
+
+	return T("enable.success", TArgs{"pool": pool}), nil
+}
@@ -0,0 +1,84 @@
+package commands_test
+
+import (
+	. "github.com/mdelillo/claimer/bot/commands"
+
+	"errors"
+
+	"github.com/mdelillo/claimer/bot/commands/commandsfakes"
+	clocker "github.com/mdelillo/claimer/locker"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EnableCommand", func() {
+	Describe("Execute", func() {
+		var locker *commandsfakes.FakeLocker
+
+		BeforeEach(func() {
+			locker = new(commandsfakes.FakeLocker)
+			locker.StatusReturns([]clocker.Lock{{Name: "some-pool", Maintenance: &clocker.Maintenance{By: "some-user"}}}, nil)
+		})
+
+		It("enables the pool and returns a slack response", func() {
+			command := NewFactory(locker).NewCommand("enable", "some-pool", "some-username")
+
+			slackResponse, err := command.Execute()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(slackResponse).To(Equal("Enabled some-pool"))
+
+			Expect(locker.EnablePoolCallCount()).To(Equal(1))
+			actualPool, actualUsername := locker.EnablePoolArgsForCall(0)
+			Expect(actualPool).To(Equal("some-pool"))
+			Expect(actualUsername).To(Equal("some-username"))
+		})
+
+		Context("when no pool is specified", func() {
+			It("returns a slack response", func() {
+				slackResponse, err := NewFactory(locker).NewCommand("enable", "", "").Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("must specify pool to enable"))
+			})
+		})
+
+		Context("when the pool does not exist", func() {
+			It("returns a slack response", func() {
+				slackResponse, err := NewFactory(locker).NewCommand("enable", "some-other-pool", "").Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("some-other-pool does not exist"))
+				Expect(locker.EnablePoolCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the pool is not disabled", func() {
+			It("returns a slack response", func() {
+				locker.StatusReturns([]clocker.Lock{{Name: "some-pool"}}, nil)
+
+				slackResponse, err := NewFactory(locker).NewCommand("enable", "some-pool", "").Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("some-pool is not disabled"))
+				Expect(locker.EnablePoolCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when checking the status fails", func() {
+			It("returns an error", func() {
+				locker.StatusReturns(nil, errors.New("some-error"))
+
+				slackResponse, err := NewFactory(locker).NewCommand("enable", "some-pool", "").Execute()
+				Expect(err).To(MatchError("failed to get status of locks: some-error"))
+				Expect(slackResponse).To(BeEmpty())
+			})
+		})
+
+		Context("when enabling the pool fails", func() {
+			It("returns an error", func() {
+				locker.EnablePoolReturns(errors.New("some-error"))
+
+				slackResponse, err := NewFactory(locker).NewCommand("enable", "some-pool", "").Execute()
+				Expect(err).To(MatchError("failed to enable pool: some-error"))
+				Expect(slackResponse).To(BeEmpty())
+			})
+		})
+	})
+})
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"strings"
+	"time"
+
+	clocker "github.com/mdelillo/claimer/locker"
+	. "github.com/mdelillo/claimer/translate"
+	"github.com/pkg/errors"
+)
+
+type extendCommand struct {
+	locker   locker
+	args     string
+	username string
+}
+
+func (e *extendCommand) Execute() (string, error) {
+	args := strings.Fields(e.args)
+	if len(args) < 1 {
+		return T("extend.no_pool", nil), nil
+	}
+	pool := args[0]
+	if len(args) < 2 {
+		return T("extend.no_duration", nil), nil
+	}
+	duration, err := parseDuration(args[1])
+	if err != nil || duration <= 0 {
+		return T("extend.invalid_duration", TArgs{"duration": args[1]}), nil
+	}
+
+	locks, err := e.locker.Status()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get status of locks")
+	}
+	if !poolExists(pool, locks) {
+		return T("extend.pool_does_not_exist", TArgs{"pool": pool}), nil
+	}
+	if !poolClaimed(pool, locks) {
+		return T("extend.pool_is_not_claimed", TArgs{"pool": pool}), nil
+	}
+	lock := getLock(pool, locks)
+	if lock.Owner != e.username {
+		return T("extend.not_owner", TArgs{"pool": pool, "owner": lock.Owner}), nil
+	}
+
+	expires := time.Now()
+	if lock.Expires.After(expires) {
+		expires = lock.Expires
+	}
+	expires = expires.Add(duration)
+	if err := e.locker.ExtendLock(pool, e.username, expires); err != nil {
+		return "", errors.Wrap(err, "failed to extend lock")
+	}
+
+	return T("extend.success", TArgs{"pool": pool, "expires": expires.Format(clocker.DateFormat)}), nil
+}
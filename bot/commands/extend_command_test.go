@@ -0,0 +1,183 @@
+package commands_test
+
+import (
+	. "github.com/mdelillo/claimer/bot/commands"
+
+	"errors"
+	"time"
+
+	"github.com/mdelillo/claimer/bot/commands/commandsfakes"
+	clocker "github.com/mdelillo/claimer/locker"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ExtendCommand", func() {
+	Describe("Execute", func() {
+		var locker *commandsfakes.FakeLocker
+
+		BeforeEach(func() {
+			locker = new(commandsfakes.FakeLocker)
+		})
+
+		It("extends the claim from now and returns a slack response", func() {
+			pool := "some-pool"
+			username := "some-username"
+
+			locker.StatusReturns(
+				[]clocker.Lock{{Name: pool, Claimed: true, Owner: username}},
+				nil,
+			)
+
+			command := NewFactory(locker).NewCommand("extend", pool+" 2h", username)
+
+			slackResponse, err := command.Execute()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(locker.ExtendLockCallCount()).To(Equal(1))
+			actualPool, actualUsername, expires := locker.ExtendLockArgsForCall(0)
+			Expect(actualPool).To(Equal(pool))
+			Expect(actualUsername).To(Equal(username))
+			Expect(expires).To(BeTemporally("~", time.Now().Add(2*time.Hour), time.Minute))
+
+			Expect(slackResponse).To(Equal("Extended " + pool + " until " + expires.Format(clocker.DateFormat)))
+		})
+
+		Context("when the claim already has a future expiry", func() {
+			It("extends the claim from the existing expiry", func() {
+				pool := "some-pool"
+				username := "some-username"
+				existingExpiry := time.Now().Add(24 * time.Hour)
+
+				locker.StatusReturns(
+					[]clocker.Lock{{Name: pool, Claimed: true, Owner: username, Expires: existingExpiry}},
+					nil,
+				)
+
+				command := NewFactory(locker).NewCommand("extend", pool+" 3d", username)
+
+				_, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, expires := locker.ExtendLockArgsForCall(0)
+				Expect(expires).To(BeTemporally("==", existingExpiry.Add(72*time.Hour)))
+			})
+		})
+
+		Context("when no pool is specified", func() {
+			It("returns a slack response", func() {
+				command := NewFactory(locker).NewCommand("extend", "", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("must specify pool to extend"))
+			})
+		})
+
+		Context("when no duration is specified", func() {
+			It("returns a slack response", func() {
+				command := NewFactory(locker).NewCommand("extend", "some-pool", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("must specify duration to extend by"))
+			})
+		})
+
+		Context("when the duration is invalid", func() {
+			It("returns a slack response", func() {
+				command := NewFactory(locker).NewCommand("extend", "some-pool forever", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("forever is not a valid duration"))
+				Expect(locker.StatusCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the duration is not positive", func() {
+			It("returns a slack response", func() {
+				for _, duration := range []string{"-5d", "0h", "-30m"} {
+					command := NewFactory(locker).NewCommand("extend", "some-pool "+duration, "")
+
+					slackResponse, err := command.Execute()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(slackResponse).To(Equal(duration + " is not a valid duration"))
+				}
+				Expect(locker.StatusCallCount()).To(Equal(0))
+				Expect(locker.ExtendLockCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the pool does not exist", func() {
+			It("returns a slack response", func() {
+				locker.StatusReturns(nil, nil)
+
+				command := NewFactory(locker).NewCommand("extend", "some-pool 2h", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("some-pool does not exist"))
+			})
+		})
+
+		Context("when the pool is not claimed", func() {
+			It("returns a slack response", func() {
+				locker.StatusReturns(
+					[]clocker.Lock{{Name: "some-pool", Claimed: false}},
+					nil,
+				)
+
+				command := NewFactory(locker).NewCommand("extend", "some-pool 2h", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("some-pool is not claimed"))
+			})
+		})
+
+		Context("when the pool is claimed by someone else", func() {
+			It("returns a slack response", func() {
+				locker.StatusReturns(
+					[]clocker.Lock{{Name: "some-pool", Claimed: true, Owner: "some-other-user"}},
+					nil,
+				)
+
+				command := NewFactory(locker).NewCommand("extend", "some-pool 2h", "some-username")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("some-pool is claimed by some-other-user"))
+				Expect(locker.ExtendLockCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when checking the status fails", func() {
+			It("returns an error", func() {
+				locker.StatusReturns(nil, errors.New("some-error"))
+
+				command := NewFactory(locker).NewCommand("extend", "some-pool 2h", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).To(MatchError("failed to get status of locks: some-error"))
+				Expect(slackResponse).To(BeEmpty())
+			})
+		})
+
+		Context("when extending the lock fails", func() {
+			It("returns an error", func() {
+				locker.StatusReturns(
+					[]clocker.Lock{{Name: "some-pool", Claimed: true, Owner: "some-username"}},
+					nil,
+				)
+				locker.ExtendLockReturns(errors.New("some-error"))
+
+				command := NewFactory(locker).NewCommand("extend", "some-pool 2h", "some-username")
+
+				slackResponse, err := command.Execute()
+				Expect(err).To(MatchError("failed to extend lock: some-error"))
+				Expect(slackResponse).To(BeEmpty())
+			})
+		})
+	})
+})
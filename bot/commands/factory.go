@@ -1,6 +1,10 @@
 package commands
 
-import clocker "github.com/mdelillo/claimer/locker"
+import (
+	"time"
+
+	clocker "github.com/mdelillo/claimer/locker"
+)
 
 type Factory interface {
 	NewCommand(command string, args string, username string) Command
@@ -8,10 +12,20 @@ type Factory interface {
 
 //go:generate counterfeiter . locker
 type locker interface {
-	ClaimLock(pool, username, message string) error
-	CreatePool(pool, username string) error
+	AnnotateLock(pool, username, message string) error
+	ClaimAny(pools []string, username, message string) (string, error)
+	ClaimLocks(pools []string, username, message string) error
+	CreatePool(pool, username string, metadata clocker.Metadata) error
 	DestroyPool(pool, username string) error
-	ReleaseLock(pool, username string) error
+	DisablePool(pool, username, reason string) error
+	Doctor(fix bool, username string) ([]clocker.Problem, error)
+	EnablePool(pool, username string) error
+	ExtendLock(pool, username string, expires time.Time) error
+	Histories() ([]clocker.History, error)
+	History(pool string) (clocker.History, error)
+	ReleaseLocks(pools []string, username string) error
+	RenamePool(pool, newName, username string) error
+	Reserve(pool string, reservation clocker.Reservation) error
 	Status() (locks []clocker.Lock, err error)
 }
 
@@ -45,8 +59,49 @@ func (c *commandFactory) NewCommand(command string, args string, username string
 			args:     args,
 			username: username,
 		}
+	case "disable":
+		return &disableCommand{
+			locker:   c.locker,
+			args:     args,
+			username: username,
+		}
+	case "doctor":
+		return &doctorCommand{
+			locker:   c.locker,
+			args:     args,
+			username: username,
+		}
+	case "enable":
+		return &enableCommand{
+			locker:   c.locker,
+			args:     args,
+			username: username,
+		}
+	case "extend":
+		return &extendCommand{
+			locker:   c.locker,
+			args:     args,
+			username: username,
+		}
 	case "help":
 		return &helpCommand{}
+	case "history":
+		return &historyCommand{
+			locker: c.locker,
+			args:   args,
+		}
+	case "move":
+		return &moveCommand{
+			locker:   c.locker,
+			args:     args,
+			username: username,
+		}
+	case "note":
+		return &noteCommand{
+			locker:   c.locker,
+			args:     args,
+			username: username,
+		}
 	case "owner":
 		return &ownerCommand{
 			locker: c.locker,
@@ -58,9 +113,27 @@ func (c *commandFactory) NewCommand(command string, args string, username string
 			args:     args,
 			username: username,
 		}
+	case "rename":
+		return &renameCommand{
+			locker:   c.locker,
+			args:     args,
+			username: username,
+		}
+	case "reserve":
+		return &reserveCommand{
+			locker:   c.locker,
+			args:     args,
+			username: username,
+		}
+	case "stats":
+		return &statsCommand{
+			locker: c.locker,
+			args:   args,
+		}
 	case "status":
 		return &statusCommand{
 			locker:   c.locker,
+			args:     args,
 			username: username,
 		}
 	case "notify":
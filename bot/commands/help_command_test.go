@@ -17,13 +17,31 @@ var _ = Describe("HelpCommand", func() {
 			Expect(slackResponse).To(Equal(
 				"Available commands:\n" +
 					"```\n" +
-					"  claim <env> [<message>]   Claim an unclaimed environment\n" +
-					"  create <env>              Create a new environment\n" +
+					"  claim <env>[, <env>]... [<message>]\n" +
+					"                            Claim unclaimed environments, all or none of them\n" +
+					"  claim <group> [<message>] Claim any unclaimed environment in a group, e.g. aws/us-east\n" +
+					"  claim any:<tag> [AND [NOT] <tag>]... [<message>]\n" +
+					"                            Claim the first unclaimed environment with the tags\n" +
+					"  create <env> [--description <text>] [--tag <tag>]...\n" +
+					"                            Create a new environment\n" +
 					"  destroy <env>             Destroy an environment\n" +
+					"  disable <env> [<reason>]  Take an environment out of use, e.g. while repaving\n" +
+					"  doctor [--fix]            Find (and repair) malformed environments\n" +
+					"  enable <env>              Put a disabled environment back in use\n" +
+					"  extend <env> <duration>   Extend your claim on an environment (e.g. 4h, 2d)\n" +
+					"  history <env> [<count>]   Show who has claimed an environment recently\n" +
+					"  move <env> <group>        Move an environment into a group, keeping its claim\n" +
+					"  note <env> <message>      Update the message on your claim\n" +
 					"  notify                    Notify all owners of claimed environments\n" +
 					"  owner <env>               Show the user who claimed the environment\n" +
-					"  release <env>             Release a claimed environment\n" +
-					"  status                    Show claimed and unclaimed environments\n" +
+					"  release <env>[, <env>]...\n" +
+					"                            Release claimed environments, all or none of them\n" +
+					"  rename <env> <new-name>   Rename an environment, keeping its claim\n" +
+					"  reserve <env> <start> <end> [<message>]\n" +
+					"                            Reserve an environment, e.g. 2017-03-01T09:00 4h\n" +
+					"  stats [<env>] [--since <duration>]\n" +
+					"                            Show utilization and top claimers (default: last 30d)\n" +
+					"  status [<group>]          Show claimed and unclaimed environments\n" +
 					"  help                      Display this message\n" +
 					"```",
 			))
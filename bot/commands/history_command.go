@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	clocker "github.com/mdelillo/claimer/locker"
+	. "github.com/mdelillo/claimer/translate"
+	"github.com/pkg/errors"
+)
+
+const defaultHistoryLength = 10
+
+type historyCommand struct {
+	locker locker
+	args   string
+}
+
+func (h *historyCommand) Execute() (string, error) {
+	args := strings.Fields(h.args)
+	if len(args) < 1 {
+		return T("history.no_pool", nil), nil
+	}
+	pool := args[0]
+
+	count := defaultHistoryLength
+	if len(args) > 1 {
+		var err error
+		count, err = strconv.Atoi(args[1])
+		if err != nil || count < 1 {
+			return T("history.invalid_count", TArgs{"count": args[1]}), nil
+		}
+	}
+
+	locks, err := h.locker.Status()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get status of locks")
+	}
+	if !poolExists(pool, locks) {
+		return T("history.pool_does_not_exist", TArgs{"pool": pool}), nil
+	}
+
+	history, err := h.locker.History(pool)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get history")
+	}
+
+	if len(history.Claims) == 0 {
+		response := T("history.no_claims", TArgs{"pool": pool})
+		if !history.Created.IsZero() {
+			response += "\n" + createdLine(history)
+		}
+		return response, nil
+	}
+
+	now := time.Now()
+	lines := []string{T("history.header", TArgs{"pool": pool})}
+	for i := len(history.Claims) - 1; i >= 0 && len(lines) <= count; i-- {
+		lines = append(lines, claimLine(history.Claims[i], now))
+	}
+	if len(history.Claims) <= count && !history.Created.IsZero() {
+		lines = append(lines, createdLine(history))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func claimLine(claim clocker.Claim, now time.Time) string {
+	tArgs := TArgs{
+		"owner":    claim.Owner,
+		"date":     claim.Claimed.Format(clocker.DateFormat),
+		"duration": formatDuration(claim.Duration(now)),
+	}
+
+	var line string
+	if claim.Active() {
+		line = T("history.active_claim", tArgs)
+	} else {
+		line = T("history.claim", tArgs)
+		if claim.ReleasedBy != claim.Owner {
+			line = fmt.Sprintf("%s, %s", line, T("history.released_by", TArgs{"user": claim.ReleasedBy}))
+		}
+	}
+	if claim.Message != "" {
+		line = fmt.Sprintf("%s (%s)", line, claim.Message)
+	}
+	return line
+}
+
+func createdLine(history clocker.History) string {
+	return T("history.created", TArgs{
+		"user": history.Creator,
+		"date": history.Created.Format(clocker.DateFormat),
+	})
+}
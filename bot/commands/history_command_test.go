@@ -0,0 +1,150 @@
+package commands_test
+
+import (
+	. "github.com/mdelillo/claimer/bot/commands"
+
+	"errors"
+	"time"
+
+	"github.com/mdelillo/claimer/bot/commands/commandsfakes"
+	clocker "github.com/mdelillo/claimer/locker"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HistoryCommand", func() {
+	Describe("Execute", func() {
+		var (
+			locker *commandsfakes.FakeLocker
+			date   func(day int) time.Time
+		)
+
+		BeforeEach(func() {
+			locker = new(commandsfakes.FakeLocker)
+			locker.StatusReturns([]clocker.Lock{{Name: "some-pool"}}, nil)
+			date = func(day int) time.Time {
+				return time.Date(2017, 3, day, 12, 0, 0, 0, time.UTC)
+			}
+		})
+
+		It("responds with the recent claims of the pool, newest first", func() {
+			locker.HistoryReturns(clocker.History{
+				Pool:    "some-pool",
+				Creator: "some-creator",
+				Created: date(1),
+				Claims: []clocker.Claim{
+					{Owner: "some-user", Message: "some message", Claimed: date(2), Released: date(3).Add(90 * time.Minute), ReleasedBy: "some-user"},
+					{Owner: "some-other-user", Claimed: date(4), Released: date(5), ReleasedBy: "some-releaser"},
+					{Owner: "some-current-user", Claimed: time.Now().Add(-3 * time.Hour)},
+				},
+			}, nil)
+
+			command := NewFactory(locker).NewCommand("history", "some-pool", "")
+
+			slackResponse, err := command.Execute()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(slackResponse).To(MatchRegexp(
+				`^Recent claims of some-pool:\n` +
+					`some-current-user has claimed it since .* \(3h so far\)\n` +
+					`some-other-user claimed it on Sat Mar 4 12:00:00 2017 \+0000 for 1d, released by some-releaser\n` +
+					`some-user claimed it on Thu Mar 2 12:00:00 2017 \+0000 for 1d 1h 30m \(some message\)\n` +
+					`Created by some-creator on Wed Mar 1 12:00:00 2017 \+0000$`,
+			))
+
+			Expect(locker.HistoryCallCount()).To(Equal(1))
+			Expect(locker.HistoryArgsForCall(0)).To(Equal("some-pool"))
+		})
+
+		Context("when a count is provided", func() {
+			It("responds with at most that many claims", func() {
+				locker.HistoryReturns(clocker.History{
+					Pool:    "some-pool",
+					Creator: "some-creator",
+					Created: date(1),
+					Claims: []clocker.Claim{
+						{Owner: "some-user", Claimed: date(2), Released: date(3), ReleasedBy: "some-user"},
+						{Owner: "some-other-user", Claimed: date(4), Released: date(5), ReleasedBy: "some-other-user"},
+					},
+				}, nil)
+
+				command := NewFactory(locker).NewCommand("history", "some-pool 1", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal(
+					"Recent claims of some-pool:\n" +
+						"some-other-user claimed it on Sat Mar 4 12:00:00 2017 +0000 for 1d",
+				))
+			})
+		})
+
+		Context("when the pool has never been claimed", func() {
+			It("returns a slack response", func() {
+				locker.HistoryReturns(clocker.History{Pool: "some-pool", Creator: "some-creator", Created: date(1)}, nil)
+
+				command := NewFactory(locker).NewCommand("history", "some-pool", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal(
+					"some-pool has never been claimed\n" +
+						"Created by some-creator on Wed Mar 1 12:00:00 2017 +0000",
+				))
+			})
+		})
+
+		Context("when the count is invalid", func() {
+			It("returns a slack response", func() {
+				command := NewFactory(locker).NewCommand("history", "some-pool some-count", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("some-count is not a valid number of claims"))
+			})
+		})
+
+		Context("when no pool is specified", func() {
+			It("returns a slack response", func() {
+				command := NewFactory(locker).NewCommand("history", "", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("must specify pool"))
+			})
+		})
+
+		Context("when the pool does not exist", func() {
+			It("returns a slack response", func() {
+				command := NewFactory(locker).NewCommand("history", "some-other-pool", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("some-other-pool does not exist"))
+			})
+		})
+
+		Context("when checking the status fails", func() {
+			It("returns an error", func() {
+				locker.StatusReturns(nil, errors.New("some-error"))
+
+				command := NewFactory(locker).NewCommand("history", "some-pool", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).To(MatchError("failed to get status of locks: some-error"))
+				Expect(slackResponse).To(BeEmpty())
+			})
+		})
+
+		Context("when getting the history fails", func() {
+			It("returns an error", func() {
+				locker.HistoryReturns(clocker.History{}, errors.New("some-error"))
+
+				command := NewFactory(locker).NewCommand("history", "some-pool", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).To(MatchError("failed to get history: some-error"))
+				Expect(slackResponse).To(BeEmpty())
+			})
+		})
+	})
+})
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"path"
+	"strings"
+
+	clocker "github.com/mdelillo/claimer/locker"
+	. "github.com/mdelillo/claimer/translate"
+	"github.com/pkg/errors"
+)
+
+type moveCommand struct {
+	locker   locker
+	args     string
+	username string
+}
+
+// Execute moves a pool into a group, keeping the last part of its name. A
+// group of "/" moves the pool out of every group.
+func (c *moveCommand) Execute() (string, error) {
+	args := strings.Fields(c.args)
+	if len(args) < 1 {
+		return T("move.no_pool", nil), nil
+	}
+	if len(args) < 2 {
+		return T("move.no_group", nil), nil
+	}
+	pool := args[0]
+	newName := path.Join(strings.Trim(args[1], "/"), path.Base(pool))
+
+	if !clocker.ValidPoolName(newName) {
+		return T("move.invalid_name", TArgs{"pool": newName}), nil
+	}
+
+	locks, err := c.locker.Status()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get status of locks")
+	}
+	if !poolExists(pool, locks) {
+		return T("move.pool_does_not_exist", TArgs{"pool": pool}), nil
+	}
+	if nameTaken(newName, locks) {
+		return T("move.pool_already_exists", TArgs{"pool": newName}), nil
+	}
+
+	if err := c.locker.RenamePool(pool, newName, c.username); err != nil {
+		return "", errors.Wrap(err, "failed to move pool")
+	}
+
+	return T("move.success", TArgs{"pool": pool, "name": newName}), nil
+}
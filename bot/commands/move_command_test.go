@@ -0,0 +1,139 @@
+package commands_test
+
+import (
+	. "github.com/mdelillo/claimer/bot/commands"
+
+	"errors"
+
+	"github.com/mdelillo/claimer/bot/commands/commandsfakes"
+	clocker "github.com/mdelillo/claimer/locker"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MoveCommand", func() {
+	Describe("Execute", func() {
+		var locker *commandsfakes.FakeLocker
+
+		BeforeEach(func() {
+			locker = new(commandsfakes.FakeLocker)
+		})
+
+		It("moves the pool into the group and returns a slack response", func() {
+			username := "some-username"
+
+			locker.StatusReturns(
+				[]clocker.Lock{{Name: "aws/us-east/env-1", Claimed: true}},
+				nil,
+			)
+
+			command := NewFactory(locker).NewCommand("move", "aws/us-east/env-1 aws/us-west", username)
+
+			slackResponse, err := command.Execute()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(slackResponse).To(Equal("Moved aws/us-east/env-1 to aws/us-west/env-1"))
+
+			Expect(locker.RenamePoolCallCount()).To(Equal(1))
+			actualPool, actualNewName, actualUsername := locker.RenamePoolArgsForCall(0)
+			Expect(actualPool).To(Equal("aws/us-east/env-1"))
+			Expect(actualNewName).To(Equal("aws/us-west/env-1"))
+			Expect(actualUsername).To(Equal(username))
+		})
+
+		Context("when the group is /", func() {
+			It("moves the pool out of its groups", func() {
+				locker.StatusReturns([]clocker.Lock{{Name: "aws/env-1"}}, nil)
+
+				command := NewFactory(locker).NewCommand("move", "aws/env-1 /", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("Moved aws/env-1 to env-1"))
+				_, actualNewName, _ := locker.RenamePoolArgsForCall(0)
+				Expect(actualNewName).To(Equal("env-1"))
+			})
+		})
+
+		Context("when no pool is specified", func() {
+			It("returns a slack response", func() {
+				command := NewFactory(locker).NewCommand("move", "", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("must specify pool to move"))
+			})
+		})
+
+		Context("when no group is specified", func() {
+			It("returns a slack response", func() {
+				command := NewFactory(locker).NewCommand("move", "some-pool", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("must specify group to move to"))
+			})
+		})
+
+		Context("when the group would take the pool out of the repo", func() {
+			It("returns a slack response", func() {
+				command := NewFactory(locker).NewCommand("move", "aws/env-1 ../..", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("../../env-1 is not a valid pool name"))
+				Expect(locker.RenamePoolCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when checking the status fails", func() {
+			It("returns an error", func() {
+				locker.StatusReturns(nil, errors.New("some-error"))
+
+				command := NewFactory(locker).NewCommand("move", "some-pool some-group", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).To(MatchError("failed to get status of locks: some-error"))
+				Expect(slackResponse).To(BeEmpty())
+			})
+		})
+
+		Context("when the pool does not exist", func() {
+			It("returns a slack response", func() {
+				locker.StatusReturns(nil, nil)
+
+				command := NewFactory(locker).NewCommand("move", "some-pool some-group", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("some-pool does not exist"))
+				Expect(locker.RenamePoolCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the group already has a pool with the name", func() {
+			It("returns a slack response", func() {
+				locker.StatusReturns([]clocker.Lock{{Name: "some-pool"}, {Name: "some-group/some-pool"}}, nil)
+
+				command := NewFactory(locker).NewCommand("move", "some-pool some-group", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("some-group/some-pool already exists"))
+				Expect(locker.RenamePoolCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when moving the pool fails", func() {
+			It("returns an error", func() {
+				locker.StatusReturns([]clocker.Lock{{Name: "some-pool"}}, nil)
+				locker.RenamePoolReturns(errors.New("some-error"))
+
+				command := NewFactory(locker).NewCommand("move", "some-pool some-group", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).To(MatchError("failed to move pool: some-error"))
+				Expect(slackResponse).To(BeEmpty())
+			})
+		})
+	})
+})
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"strings"
+
+	. "github.com/mdelillo/claimer/translate"
+	"github.com/pkg/errors"
+)
+
+type noteCommand struct {
+	locker   locker
+	args     string
+	username string
+}
+
+func (n *noteCommand) Execute() (string, error) {
+	args := strings.SplitN(strings.TrimSpace(n.args), " ", 2)
+	if args[0] == "" {
+		return T("note.no_pool", nil), nil
+	}
+	pool := args[0]
+	if len(args) < 2 || strings.TrimSpace(args[1]) == "" {
+		return T("note.no_message", nil), nil
+	}
+	message := strings.TrimSpace(args[1])
+
+	locks, err := n.locker.Status()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get status of locks")
+	}
+	if !poolExists(pool, locks) {
+		return T("note.pool_does_not_exist", TArgs{"pool": pool}), nil
+	}
+	if !poolClaimed(pool, locks) {
+		return T("note.pool_is_not_claimed", TArgs{"pool": pool}), nil
+	}
+	if lock := getLock(pool, locks); lock.Owner != n.username {
+		return T("note.not_owner", TArgs{"pool": pool, "owner": lock.Owner}), nil
+	}
+
+	if err := n.locker.AnnotateLock(pool, n.username, message); err != nil {
+		return "", errors.Wrap(err, "failed to annotate lock")
+	}
+
+	return T("note.success", TArgs{"pool": pool}), nil
+}
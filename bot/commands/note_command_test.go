@@ -0,0 +1,136 @@
+package commands_test
+
+import (
+	. "github.com/mdelillo/claimer/bot/commands"
+
+	"errors"
+
+	"github.com/mdelillo/claimer/bot/commands/commandsfakes"
+	clocker "github.com/mdelillo/claimer/locker"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NoteCommand", func() {
+	Describe("Execute", func() {
+		var locker *commandsfakes.FakeLocker
+
+		BeforeEach(func() {
+			locker = new(commandsfakes.FakeLocker)
+		})
+
+		It("updates the message on the claim and returns a slack response", func() {
+			pool := "some-pool"
+			username := "some-username"
+			message := "some new message"
+
+			locker.StatusReturns(
+				[]clocker.Lock{{Name: pool, Claimed: true, Owner: username}},
+				nil,
+			)
+
+			command := NewFactory(locker).NewCommand("note", pool+" "+message, username)
+
+			slackResponse, err := command.Execute()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(slackResponse).To(Equal("Updated message on " + pool))
+
+			Expect(locker.AnnotateLockCallCount()).To(Equal(1))
+			actualPool, actualUsername, actualMessage := locker.AnnotateLockArgsForCall(0)
+			Expect(actualPool).To(Equal(pool))
+			Expect(actualUsername).To(Equal(username))
+			Expect(actualMessage).To(Equal(message))
+		})
+
+		Context("when no pool is specified", func() {
+			It("returns a slack response", func() {
+				command := NewFactory(locker).NewCommand("note", "", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("must specify pool to annotate"))
+			})
+		})
+
+		Context("when no message is specified", func() {
+			It("returns a slack response", func() {
+				command := NewFactory(locker).NewCommand("note", "some-pool", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("must specify message"))
+			})
+		})
+
+		Context("when the pool does not exist", func() {
+			It("returns a slack response", func() {
+				locker.StatusReturns(nil, nil)
+
+				command := NewFactory(locker).NewCommand("note", "some-pool some message", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("some-pool does not exist"))
+			})
+		})
+
+		Context("when the pool is not claimed", func() {
+			It("returns a slack response", func() {
+				locker.StatusReturns(
+					[]clocker.Lock{{Name: "some-pool", Claimed: false}},
+					nil,
+				)
+
+				command := NewFactory(locker).NewCommand("note", "some-pool some message", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("some-pool is not claimed"))
+			})
+		})
+
+		Context("when the pool is claimed by someone else", func() {
+			It("returns a slack response", func() {
+				locker.StatusReturns(
+					[]clocker.Lock{{Name: "some-pool", Claimed: true, Owner: "some-other-user"}},
+					nil,
+				)
+
+				command := NewFactory(locker).NewCommand("note", "some-pool some message", "some-username")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("some-pool is claimed by some-other-user"))
+				Expect(locker.AnnotateLockCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when checking the status fails", func() {
+			It("returns an error", func() {
+				locker.StatusReturns(nil, errors.New("some-error"))
+
+				command := NewFactory(locker).NewCommand("note", "some-pool some message", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).To(MatchError("failed to get status of locks: some-error"))
+				Expect(slackResponse).To(BeEmpty())
+			})
+		})
+
+		Context("when annotating the lock fails", func() {
+			It("returns an error", func() {
+				locker.StatusReturns(
+					[]clocker.Lock{{Name: "some-pool", Claimed: true, Owner: "some-username"}},
+					nil,
+				)
+				locker.AnnotateLockReturns(errors.New("some-error"))
+
+				command := NewFactory(locker).NewCommand("note", "some-pool some message", "some-username")
+
+				slackResponse, err := command.Execute()
+				Expect(err).To(MatchError("failed to annotate lock: some-error"))
+				Expect(slackResponse).To(BeEmpty())
+			})
+		})
+	})
+})
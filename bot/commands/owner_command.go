@@ -3,6 +3,7 @@ package commands
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	clocker "github.com/mdelillo/claimer/locker"
 	. "github.com/mdelillo/claimer/translate"
@@ -28,16 +29,56 @@ func (o *ownerCommand) Execute() (string, error) {
 	if !poolExists(pool, locks) {
 		return T("owner.pool_does_not_exist", TArgs{"pool": pool}), nil
 	}
-	if !poolClaimed(pool, locks) {
-		return T("owner.pool_is_not_claimed", TArgs{"pool": pool}), nil
+	lock := getLock(pool, locks)
+	if lock.Maintenance != nil {
+		response := T("owner.pool_is_disabled", TArgs{
+			"pool": pool,
+			"user": lock.Maintenance.By,
+			"date": lock.Maintenance.Since.Format(clocker.DateFormat),
+		})
+		if lock.Maintenance.Reason != "" {
+			response = fmt.Sprintf("%s (%s)", response, lock.Maintenance.Reason)
+		}
+		return response + poolDetails(lock), nil
+	}
+	if !lock.Claimed {
+		return T("owner.pool_is_not_claimed", TArgs{"pool": pool}) + poolDetails(lock), nil
 	}
 
-	lock := getLock(pool, locks)
 	response := T("owner.success", TArgs{"pool": pool, "owner": lock.Owner, "date": lock.Date})
+	if !lock.Expires.IsZero() {
+		response = fmt.Sprintf("%s, %s", response, T("owner.expires", TArgs{"expires": lock.Expires.Format(clocker.DateFormat)}))
+	}
 	if lock.Message != "" {
 		response = fmt.Sprintf("%s (%s)", response, lock.Message)
 	}
-	return response, nil
+	return response + poolDetails(lock), nil
+}
+
+// poolDetails lists the metadata and upcoming reservations of a pool on
+// separate lines
+func poolDetails(lock *clocker.Lock) string {
+	var details string
+	if lock.Description != "" {
+		details += "\n" + T("owner.description", TArgs{"description": lock.Description})
+	}
+	if lock.Team != "" {
+		details += "\n" + T("owner.team", TArgs{"team": lock.Team})
+	}
+	if len(lock.Links) > 0 {
+		details += "\n" + T("owner.links", TArgs{"links": strings.Join(lock.Links, ", ")})
+	}
+	now := time.Now()
+	for _, reservation := range lock.Reservations {
+		if reservation.End.After(now) {
+			details += "\n" + T("owner.reservation", TArgs{
+				"owner": reservation.Owner,
+				"start": reservation.Start.Format(clocker.DateFormat),
+				"end":   reservation.End.Format(clocker.DateFormat),
+			})
+		}
+	}
+	return details
 }
 
 func getLock(pool string, locks []clocker.Lock) *clocker.Lock {
@@ -5,6 +5,7 @@ import (
 
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/mdelillo/claimer/bot/commands/commandsfakes"
 	clocker "github.com/mdelillo/claimer/locker"
@@ -63,6 +64,94 @@ var _ = Describe("OwnerCommand", func() {
 			})
 		})
 
+		Context("when the claim has been extended", func() {
+			It("responds with the expiry of the claim", func() {
+				pool := "some-pool"
+				owner := "some-owner"
+				claimDate := "some-date"
+				message := "some message"
+				expires := time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC)
+
+				locker.StatusReturns(
+					[]clocker.Lock{
+						{Name: pool, Claimed: true, Owner: owner, Date: claimDate, Message: message, Expires: expires},
+					},
+					nil,
+				)
+
+				command := NewFactory(locker).NewCommand("owner", pool, "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal(fmt.Sprintf(
+					"%s was claimed by %s on %s, expires Wed Mar 1 12:00:00 2017 +0000 (%s)",
+					pool, owner, claimDate, message,
+				)))
+			})
+		})
+
+		Context("when the pool has metadata", func() {
+			It("responds with the description, team and links of the pool", func() {
+				locker.StatusReturns(
+					[]clocker.Lock{
+						{Name: "some-pool", Claimed: true, Owner: "some-owner", Date: "some-date", Metadata: clocker.Metadata{
+							Description: "some description",
+							Team:        "some-team",
+							Tags:        []string{"some-tag"},
+							Links:       []string{"some-link", "some-other-link"},
+						}},
+						{Name: "some-other-pool", Claimed: false, Metadata: clocker.Metadata{Description: "some other description"}},
+					},
+					nil,
+				)
+
+				slackResponse, err := NewFactory(locker).NewCommand("owner", "some-pool", "").Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("some-pool was claimed by some-owner on some-date\n" +
+					"*Description:* some description\n" +
+					"*Team:* some-team\n" +
+					"*Links:* some-link, some-other-link",
+				))
+
+				slackResponse, err = NewFactory(locker).NewCommand("owner", "some-other-pool", "").Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("some-other-pool is not claimed\n*Description:* some other description"))
+			})
+		})
+
+		Context("when the pool is disabled", func() {
+			It("responds with who disabled it and why", func() {
+				since := time.Date(2017, 3, 1, 9, 0, 0, 0, time.UTC)
+				locker.StatusReturns(
+					[]clocker.Lock{{Name: "some-pool", Maintenance: &clocker.Maintenance{By: "some-user", Reason: "repaving", Since: since}}},
+					nil,
+				)
+
+				slackResponse, err := NewFactory(locker).NewCommand("owner", "some-pool", "").Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("some-pool was disabled by some-user on Wed Mar 1 09:00:00 2017 +0000 (repaving)"))
+			})
+		})
+
+		Context("when the pool is reserved", func() {
+			It("responds with the reservations which have not ended", func() {
+				start := time.Date(2017, 3, 1, 9, 0, 0, 0, time.UTC)
+				locker.StatusReturns(
+					[]clocker.Lock{{Name: "some-pool", Reservations: []clocker.Reservation{
+						{Owner: "some-old-owner", Start: start.AddDate(-1, 0, 0), End: start.AddDate(-1, 0, 1)},
+						{Owner: "some-owner", Start: start.AddDate(100, 0, 0), End: start.AddDate(100, 0, 1)},
+					}}},
+					nil,
+				)
+
+				slackResponse, err := NewFactory(locker).NewCommand("owner", "some-pool", "").Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("some-pool is not claimed\n" +
+					"*Reserved* by some-owner from Mon Mar 1 09:00:00 2117 +0000 until Tue Mar 2 09:00:00 2117 +0000",
+				))
+			})
+		})
+
 		Context("when the pool does not exist", func() {
 			It("returns a slack response", func() {
 				pool := "some-pool"
@@ -2,9 +2,7 @@ package commands
 
 import (
 	"strings"
-
-	. "github.com/mdelillo/claimer/translate"
-	"github.com/pkg/errors"
+	"unicode"
 )
 
 type releaseCommand struct {
@@ -13,27 +11,12 @@ type releaseCommand struct {
 	username string
 }
 
+// Execute releases the pools, which are separated by commas as in claim.
+// Since release takes no message, spaces separate them too.
 func (r *releaseCommand) Execute() (string, error) {
-	args := strings.Fields(r.args)
-	if len(r.args) < 1 {
-		return T("release.no_pool", nil), nil
-	}
-	pool := args[0]
-
-	locks, err := r.locker.Status()
-	if err != nil {
-		return "", errors.Wrap(err, "failed to get status of locks")
-	}
-	if !poolExists(pool, locks) {
-		return T("release.pool_does_not_exist", TArgs{"pool": pool}), nil
-	}
-	if !poolClaimed(pool, locks) {
-		return T("release.pool_is_not_claimed", TArgs{"pool": pool}), nil
-	}
-
-	if err := r.locker.ReleaseLock(pool, r.username); err != nil {
-		return "", errors.Wrap(err, "failed to release lock")
-	}
-
-	return T("release.success", TArgs{"pool": pool}), nil
+	pools := strings.FieldsFunc(r.args, func(c rune) bool {
+		return c == ',' || unicode.IsSpace(c)
+	})
+	result, err := NewActions(r.locker).Release(pools, r.username)
+	return result.Response, err
 }
@@ -33,12 +33,66 @@ var _ = Describe("ReleaseCommand", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(slackResponse).To(Equal("Released " + pool))
 
-			Expect(locker.ReleaseLockCallCount()).To(Equal(1))
-			actualPool, actualUsername := locker.ReleaseLockArgsForCall(0)
-			Expect(actualPool).To(Equal(pool))
+			Expect(locker.ReleaseLocksCallCount()).To(Equal(1))
+			actualPools, actualUsername := locker.ReleaseLocksArgsForCall(0)
+			Expect(actualPools).To(Equal([]string{pool}))
 			Expect(actualUsername).To(Equal(username))
 		})
 
+		Context("when several pools are provided", func() {
+			BeforeEach(func() {
+				locker.StatusReturns(
+					[]clocker.Lock{
+						{Name: "pool-1", Claimed: true},
+						{Name: "pool-2", Claimed: true},
+						{Name: "pool-3", Claimed: false},
+					},
+					nil,
+				)
+			})
+
+			It("releases all of them at once", func() {
+				slackResponse, err := NewFactory(locker).NewCommand("release", "pool-1, pool-2", "some-username").Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("Released pool-1, pool-2"))
+
+				Expect(locker.ReleaseLocksCallCount()).To(Equal(1))
+				actualPools, _ := locker.ReleaseLocksArgsForCall(0)
+				Expect(actualPools).To(Equal([]string{"pool-1", "pool-2"}))
+			})
+
+			It("accepts the pools separated by spaces as well", func() {
+				for _, args := range []string{"pool-1 pool-2", "pool-1,pool-2", "pool-1 ,pool-2"} {
+					_, err := NewFactory(locker).NewCommand("release", args, "some-username").Execute()
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				Expect(locker.ReleaseLocksCallCount()).To(Equal(3))
+				for i := 0; i < 3; i++ {
+					actualPools, _ := locker.ReleaseLocksArgsForCall(i)
+					Expect(actualPools).To(Equal([]string{"pool-1", "pool-2"}))
+				}
+			})
+
+			Context("when one of them is not claimed", func() {
+				It("releases none of them", func() {
+					slackResponse, err := NewFactory(locker).NewCommand("release", "pool-1, pool-3", "").Execute()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(slackResponse).To(Equal("pool-3 is not claimed"))
+					Expect(locker.ReleaseLocksCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when one of them does not exist", func() {
+				It("releases none of them", func() {
+					slackResponse, err := NewFactory(locker).NewCommand("release", "pool-1 pool-4", "").Execute()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(slackResponse).To(Equal("pool-4 does not exist"))
+					Expect(locker.ReleaseLocksCallCount()).To(Equal(0))
+				})
+			})
+		})
+
 		Context("when no pool is specified", func() {
 			It("returns a slack response", func() {
 				command := NewFactory(locker).NewCommand("release", "", "")
@@ -79,6 +133,20 @@ var _ = Describe("ReleaseCommand", func() {
 			})
 		})
 
+		Context("when the pool is disabled", func() {
+			It("returns a slack response", func() {
+				locker.StatusReturns(
+					[]clocker.Lock{{Name: "some-pool", Maintenance: &clocker.Maintenance{By: "some-user"}}},
+					nil,
+				)
+
+				slackResponse, err := NewFactory(locker).NewCommand("release", "some-pool", "").Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("some-pool is disabled, enable it instead"))
+				Expect(locker.ReleaseLocksCallCount()).To(Equal(0))
+			})
+		})
+
 		Context("when checking the status fails", func() {
 			It("returns an error", func() {
 				locker.StatusReturns(nil, errors.New("some-error"))
@@ -99,7 +167,7 @@ var _ = Describe("ReleaseCommand", func() {
 					[]clocker.Lock{{Name: pool, Claimed: true}},
 					nil,
 				)
-				locker.ReleaseLockReturns(errors.New("some-error"))
+				locker.ReleaseLocksReturns(errors.New("some-error"))
 
 				command := NewFactory(locker).NewCommand("release", "some-pool", "")
 
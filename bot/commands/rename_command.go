@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"strings"
+
+	clocker "github.com/mdelillo/claimer/locker"
+	. "github.com/mdelillo/claimer/translate"
+	"github.com/pkg/errors"
+)
+
+type renameCommand struct {
+	locker   locker
+	args     string
+	username string
+}
+
+func (c *renameCommand) Execute() (string, error) {
+	args := strings.Fields(c.args)
+	if len(args) < 1 {
+		return T("rename.no_pool", nil), nil
+	}
+	if len(args) < 2 {
+		return T("rename.no_name", nil), nil
+	}
+	pool := args[0]
+	newName := strings.Trim(args[1], "/")
+
+	if !clocker.ValidPoolName(newName) {
+		return T("rename.invalid_name", TArgs{"pool": newName}), nil
+	}
+
+	locks, err := c.locker.Status()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get status of locks")
+	}
+	if !poolExists(pool, locks) {
+		return T("rename.pool_does_not_exist", TArgs{"pool": pool}), nil
+	}
+	if nameTaken(newName, locks) {
+		return T("rename.pool_already_exists", TArgs{"pool": newName}), nil
+	}
+
+	if err := c.locker.RenamePool(pool, newName, c.username); err != nil {
+		return "", errors.Wrap(err, "failed to rename pool")
+	}
+
+	return T("rename.success", TArgs{"pool": pool, "name": newName}), nil
+}
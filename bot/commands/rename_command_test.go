@@ -0,0 +1,135 @@
+package commands_test
+
+import (
+	. "github.com/mdelillo/claimer/bot/commands"
+
+	"errors"
+
+	"github.com/mdelillo/claimer/bot/commands/commandsfakes"
+	clocker "github.com/mdelillo/claimer/locker"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RenameCommand", func() {
+	Describe("Execute", func() {
+		var locker *commandsfakes.FakeLocker
+
+		BeforeEach(func() {
+			locker = new(commandsfakes.FakeLocker)
+		})
+
+		It("renames the pool and returns a slack response", func() {
+			username := "some-username"
+
+			locker.StatusReturns(
+				[]clocker.Lock{{Name: "some-pool", Claimed: true}},
+				nil,
+			)
+
+			command := NewFactory(locker).NewCommand("rename", "some-pool some-group/some-new-pool", username)
+
+			slackResponse, err := command.Execute()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(slackResponse).To(Equal("Renamed some-pool to some-group/some-new-pool"))
+
+			Expect(locker.RenamePoolCallCount()).To(Equal(1))
+			actualPool, actualNewName, actualUsername := locker.RenamePoolArgsForCall(0)
+			Expect(actualPool).To(Equal("some-pool"))
+			Expect(actualNewName).To(Equal("some-group/some-new-pool"))
+			Expect(actualUsername).To(Equal(username))
+		})
+
+		Context("when no pool is specified", func() {
+			It("returns a slack response", func() {
+				command := NewFactory(locker).NewCommand("rename", "", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("must specify pool to rename"))
+			})
+		})
+
+		Context("when no new name is specified", func() {
+			It("returns a slack response", func() {
+				command := NewFactory(locker).NewCommand("rename", "some-pool", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("must specify new name"))
+			})
+		})
+
+		Context("when the new name is not a valid pool name", func() {
+			It("returns a slack response", func() {
+				for _, name := range []string{"../pool-1", "aws/../../pool-1", ".git/pool-1"} {
+					command := NewFactory(locker).NewCommand("rename", "pool-1 "+name, "")
+
+					slackResponse, err := command.Execute()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(slackResponse).To(Equal(name + " is not a valid pool name"))
+				}
+				Expect(locker.RenamePoolCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when checking the status fails", func() {
+			It("returns an error", func() {
+				locker.StatusReturns(nil, errors.New("some-error"))
+
+				command := NewFactory(locker).NewCommand("rename", "some-pool some-new-pool", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).To(MatchError("failed to get status of locks: some-error"))
+				Expect(slackResponse).To(BeEmpty())
+			})
+		})
+
+		Context("when the pool does not exist", func() {
+			It("returns a slack response", func() {
+				locker.StatusReturns(nil, nil)
+
+				command := NewFactory(locker).NewCommand("rename", "some-pool some-new-pool", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("some-pool does not exist"))
+				Expect(locker.RenamePoolCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when a pool or group already has the new name", func() {
+			It("returns a slack response", func() {
+				locker.StatusReturns(
+					[]clocker.Lock{{Name: "some-pool"}, {Name: "some-other-pool"}, {Name: "some-group/some-pool"}},
+					nil,
+				)
+
+				command := NewFactory(locker).NewCommand("rename", "some-pool some-other-pool", "")
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("some-other-pool already exists"))
+
+				command = NewFactory(locker).NewCommand("rename", "some-pool some-group", "")
+				slackResponse, err = command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("some-group already exists"))
+
+				Expect(locker.RenamePoolCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when renaming the pool fails", func() {
+			It("returns an error", func() {
+				locker.StatusReturns([]clocker.Lock{{Name: "some-pool"}}, nil)
+				locker.RenamePoolReturns(errors.New("some-error"))
+
+				command := NewFactory(locker).NewCommand("rename", "some-pool some-new-pool", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).To(MatchError("failed to rename pool: some-error"))
+				Expect(slackResponse).To(BeEmpty())
+			})
+		})
+	})
+})
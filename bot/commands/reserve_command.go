@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"strings"
+	"time"
+
+	clocker "github.com/mdelillo/claimer/locker"
+	. "github.com/mdelillo/claimer/translate"
+	"github.com/pkg/errors"
+)
+
+// timeFormats are the formats accepted for the start and end of a
+// reservation. Times without a zone are in the local time of the bot.
+var timeFormats = []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02"}
+
+type reserveCommand struct {
+	locker   locker
+	args     string
+	username string
+}
+
+func (c *reserveCommand) Execute() (string, error) {
+	args := strings.Fields(c.args)
+	if len(args) < 1 {
+		return T("reserve.no_pool", nil), nil
+	}
+	if len(args) < 3 {
+		return T("reserve.no_window", nil), nil
+	}
+	pool := args[0]
+
+	start, ok := parseTime(args[1])
+	if !ok {
+		return T("reserve.invalid_time", TArgs{"time": args[1]}), nil
+	}
+	// the end can also be given as a duration after the start, e.g. 4h
+	end, ok := parseTime(args[2])
+	if !ok {
+		duration, err := parseDuration(args[2])
+		if err != nil {
+			return T("reserve.invalid_time", TArgs{"time": args[2]}), nil
+		}
+		end = start.Add(duration)
+	}
+	if !end.After(start) {
+		return T("reserve.ends_before_start", nil), nil
+	}
+	now := time.Now()
+	if !end.After(now) {
+		return T("reserve.in_the_past", nil), nil
+	}
+	reservation := clocker.Reservation{
+		Owner:   c.username,
+		Start:   start,
+		End:     end,
+		Message: strings.Join(args[3:], " "),
+	}
+
+	locks, err := c.locker.Status()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get status of locks")
+	}
+	if !poolExists(pool, locks) {
+		return T("reserve.pool_does_not_exist", TArgs{"pool": pool}), nil
+	}
+	for _, existing := range getLock(pool, locks).Reservations {
+		if existing.End.After(now) && existing.Overlaps(reservation) {
+			return T("reserve.conflict", TArgs{
+				"pool":  pool,
+				"owner": existing.Owner,
+				"start": existing.Start.Format(clocker.DateFormat),
+				"end":   existing.End.Format(clocker.DateFormat),
+			}), nil
+		}
+	}
+
+	if err := c.locker.Reserve(pool, reservation); err != nil {
+		return "", errors.Wrap(err, "failed to reserve pool")
+	}
+
+	return T("reserve.success", TArgs{
+		"pool":  pool,
+		"start": start.Format(clocker.DateFormat),
+		"end":   end.Format(clocker.DateFormat),
+	}), nil
+}
+
+func parseTime(value string) (time.Time, bool) {
+	for _, format := range timeFormats {
+		if t, err := time.ParseInLocation(format, value, time.Local); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,143 @@
+package commands_test
+
+import (
+	. "github.com/mdelillo/claimer/bot/commands"
+
+	"errors"
+	"time"
+
+	"github.com/mdelillo/claimer/bot/commands/commandsfakes"
+	clocker "github.com/mdelillo/claimer/locker"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ReserveCommand", func() {
+	Describe("Execute", func() {
+		var (
+			locker *commandsfakes.FakeLocker
+			start  time.Time
+		)
+
+		BeforeEach(func() {
+			locker = new(commandsfakes.FakeLocker)
+			locker.StatusReturns([]clocker.Lock{{Name: "some-pool"}}, nil)
+			start = time.Date(time.Now().Year()+1, 3, 1, 9, 0, 0, 0, time.Local)
+		})
+
+		It("reserves the pool and returns a slack response", func() {
+			command := NewFactory(locker).NewCommand("reserve", "some-pool "+start.Format("2006-01-02T15:04")+" "+start.Add(4*time.Hour).Format("2006-01-02T15:04")+" some message", "some-user")
+
+			slackResponse, err := command.Execute()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(slackResponse).To(Equal("Reserved some-pool from " + start.Format(clocker.DateFormat) + " until " + start.Add(4*time.Hour).Format(clocker.DateFormat)))
+
+			Expect(locker.ReserveCallCount()).To(Equal(1))
+			actualPool, actualReservation := locker.ReserveArgsForCall(0)
+			Expect(actualPool).To(Equal("some-pool"))
+			Expect(actualReservation.Owner).To(Equal("some-user"))
+			Expect(actualReservation.Start).To(BeTemporally("==", start))
+			Expect(actualReservation.End).To(BeTemporally("==", start.Add(4*time.Hour)))
+			Expect(actualReservation.Message).To(Equal("some message"))
+		})
+
+		It("accepts dates and durations", func() {
+			day := time.Date(start.Year(), 3, 1, 0, 0, 0, 0, time.Local)
+			command := NewFactory(locker).NewCommand("reserve", "some-pool "+day.Format("2006-01-02")+" 2d", "some-user")
+
+			_, err := command.Execute()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, actualReservation := locker.ReserveArgsForCall(0)
+			Expect(actualReservation.Start).To(BeTemporally("==", day))
+			Expect(actualReservation.End).To(BeTemporally("==", day.Add(48*time.Hour)))
+		})
+
+		Context("when no pool is specified", func() {
+			It("returns a slack response", func() {
+				slackResponse, err := NewFactory(locker).NewCommand("reserve", "", "some-user").Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("must specify pool to reserve"))
+			})
+		})
+
+		Context("when no window is specified", func() {
+			It("returns a slack response", func() {
+				slackResponse, err := NewFactory(locker).NewCommand("reserve", "some-pool 2017-03-01", "some-user").Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("must specify when the reservation starts and ends"))
+			})
+		})
+
+		Context("when a time is invalid", func() {
+			It("returns a slack response", func() {
+				slackResponse, err := NewFactory(locker).NewCommand("reserve", "some-pool tomorrow 4h", "some-user").Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("tomorrow is not a valid time, e.g. 2017-03-01T09:00 or 2017-03-01"))
+
+				slackResponse, err = NewFactory(locker).NewCommand("reserve", "some-pool 2017-03-01 later", "some-user").Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("later is not a valid time, e.g. 2017-03-01T09:00 or 2017-03-01"))
+				Expect(locker.ReserveCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the reservation ends before it starts", func() {
+			It("returns a slack response", func() {
+				slackResponse, err := NewFactory(locker).NewCommand("reserve", "some-pool 2017-03-02 2017-03-01", "some-user").Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("a reservation must end after it starts"))
+				Expect(locker.ReserveCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the reservation has already ended", func() {
+			It("returns a slack response", func() {
+				slackResponse, err := NewFactory(locker).NewCommand("reserve", "some-pool 2017-03-01 2017-03-02", "some-user").Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("a reservation must end in the future"))
+				Expect(locker.ReserveCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the pool does not exist", func() {
+			It("returns a slack response", func() {
+				slackResponse, err := NewFactory(locker).NewCommand("reserve", "some-other-pool "+start.Format("2006-01-02")+" 1d", "some-user").Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("some-other-pool does not exist"))
+				Expect(locker.ReserveCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the reservation overlaps another one", func() {
+			It("returns a slack response", func() {
+				existing := clocker.Reservation{Owner: "some-other-user", Start: start.Add(time.Hour), End: start.Add(2 * time.Hour)}
+				locker.StatusReturns([]clocker.Lock{{Name: "some-pool", Reservations: []clocker.Reservation{existing}}}, nil)
+
+				slackResponse, err := NewFactory(locker).NewCommand("reserve", "some-pool "+start.Format("2006-01-02T15:04")+" 4h", "some-user").Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("some-pool is already reserved by some-other-user from " +
+					existing.Start.Format(clocker.DateFormat) + " until " + existing.End.Format(clocker.DateFormat)))
+				Expect(locker.ReserveCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when checking the status fails", func() {
+			It("returns an error", func() {
+				locker.StatusReturns(nil, errors.New("some-error"))
+
+				_, err := NewFactory(locker).NewCommand("reserve", "some-pool "+start.Format("2006-01-02")+" 1d", "some-user").Execute()
+				Expect(err).To(MatchError("failed to get status of locks: some-error"))
+			})
+		})
+
+		Context("when reserving fails", func() {
+			It("returns an error", func() {
+				locker.ReserveReturns(errors.New("some-error"))
+
+				_, err := NewFactory(locker).NewCommand("reserve", "some-pool "+start.Format("2006-01-02")+" 1d", "some-user").Execute()
+				Expect(err).To(MatchError("failed to reserve pool: some-error"))
+			})
+		})
+	})
+})
@@ -0,0 +1,209 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	clocker "github.com/mdelillo/claimer/locker"
+	. "github.com/mdelillo/claimer/translate"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultStatsPeriod = 30 * 24 * time.Hour
+	statsListLength    = 5
+)
+
+type statsCommand struct {
+	locker locker
+	args   string
+}
+
+type poolStats struct {
+	pool        string
+	utilization float64
+	claims      int
+	mean        time.Duration
+}
+
+type claimerStats struct {
+	user    string
+	claimed time.Duration
+	claims  int
+}
+
+type currentClaim struct {
+	pool  string
+	claim clocker.Claim
+}
+
+func (s *statsCommand) Execute() (string, error) {
+	var pool, since string
+	args := strings.Fields(s.args)
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--since":
+			if i+1 < len(args) {
+				i++
+				since = args[i]
+			}
+		case strings.HasPrefix(args[i], "--since="):
+			since = strings.TrimPrefix(args[i], "--since=")
+		default:
+			pool = args[i]
+		}
+	}
+
+	period := defaultStatsPeriod
+	if since != "" {
+		var err error
+		period, err = parseDuration(since)
+		if err != nil || period <= 0 {
+			return T("stats.invalid_since", TArgs{"since": since}), nil
+		}
+	}
+
+	var histories []clocker.History
+	if pool != "" {
+		locks, err := s.locker.Status()
+		if err != nil {
+			return "", errors.Wrap(err, "failed to get status of locks")
+		}
+		if !poolExists(pool, locks) {
+			return T("stats.pool_does_not_exist", TArgs{"pool": pool}), nil
+		}
+		history, err := s.locker.History(pool)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to get history")
+		}
+		histories = []clocker.History{history}
+	} else {
+		var err error
+		histories, err = s.locker.Histories()
+		if err != nil {
+			return "", errors.Wrap(err, "failed to get history")
+		}
+	}
+
+	now := time.Now()
+	start := now.Add(-period)
+	pools, claimers, current := computeStats(histories, start, now)
+
+	lines := []string{T("stats.header", TArgs{"since": start.Format(clocker.DateFormat)})}
+
+	lines = append(lines, T("stats.utilization_header", nil))
+	for _, stats := range pools {
+		lines = append(lines, T("stats.pool", TArgs{
+			"pool":        stats.pool,
+			"utilization": fmt.Sprintf("%.0f", stats.utilization*100),
+			"claims":      fmt.Sprintf("%d", stats.claims),
+			"mean":        formatDuration(stats.mean),
+		}))
+	}
+
+	lines = append(lines, T("stats.top_claimers_header", nil))
+	for i := 0; i < len(claimers) && i < statsListLength; i++ {
+		lines = append(lines, T("stats.claimer", TArgs{
+			"user":     claimers[i].user,
+			"duration": formatDuration(claimers[i].claimed),
+			"claims":   fmt.Sprintf("%d", claimers[i].claims),
+		}))
+	}
+
+	lines = append(lines, T("stats.current_claims_header", nil))
+	for i := 0; i < len(current) && i < statsListLength; i++ {
+		lines = append(lines, T("stats.current_claim", TArgs{
+			"pool":     current[i].pool,
+			"owner":    current[i].claim.Owner,
+			"duration": formatDuration(current[i].claim.Duration(now)),
+		}))
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// computeStats summarizes the claims which overlap the period between since
+// and now. Pools are ordered by utilization, claimers by time spent holding
+// pools, and current claims by how long they have been held.
+func computeStats(histories []clocker.History, since, now time.Time) ([]poolStats, []claimerStats, []currentClaim) {
+	var pools []poolStats
+	claimersByUser := map[string]*claimerStats{}
+	var current []currentClaim
+
+	for _, history := range histories {
+		start := since
+		if history.Created.After(start) {
+			start = history.Created
+		}
+
+		stats := poolStats{pool: history.Pool}
+		var claimed, completed time.Duration
+		var completedClaims int
+		for _, claim := range history.Claims {
+			end := now
+			if !claim.Active() {
+				end = claim.Released
+			}
+			overlap := minTime(end, now).Sub(maxTime(claim.Claimed, start))
+			if overlap <= 0 {
+				continue
+			}
+
+			claimed += overlap
+			stats.claims++
+			if !claim.Active() {
+				completed += claim.Duration(now)
+				completedClaims++
+			}
+
+			if _, ok := claimersByUser[claim.Owner]; !ok {
+				claimersByUser[claim.Owner] = &claimerStats{user: claim.Owner}
+			}
+			claimersByUser[claim.Owner].claimed += overlap
+			claimersByUser[claim.Owner].claims++
+
+			if claim.Active() {
+				current = append(current, currentClaim{pool: history.Pool, claim: claim})
+			}
+		}
+		if window := now.Sub(start); window > 0 {
+			stats.utilization = float64(claimed) / float64(window)
+		}
+		if completedClaims > 0 {
+			stats.mean = completed / time.Duration(completedClaims)
+		}
+		pools = append(pools, stats)
+	}
+
+	var claimers []claimerStats
+	for _, stats := range claimersByUser {
+		claimers = append(claimers, *stats)
+	}
+
+	sort.SliceStable(pools, func(i, j int) bool { return pools[i].utilization > pools[j].utilization })
+	sort.Slice(claimers, func(i, j int) bool {
+		if claimers[i].claimed == claimers[j].claimed {
+			return claimers[i].user < claimers[j].user
+		}
+		return claimers[i].claimed > claimers[j].claimed
+	})
+	sort.SliceStable(current, func(i, j int) bool { return current[i].claim.Claimed.Before(current[j].claim.Claimed) })
+
+	return pools, claimers, current
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
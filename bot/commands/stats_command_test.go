@@ -0,0 +1,139 @@
+package commands_test
+
+import (
+	. "github.com/mdelillo/claimer/bot/commands"
+
+	"errors"
+	"time"
+
+	"github.com/mdelillo/claimer/bot/commands/commandsfakes"
+	clocker "github.com/mdelillo/claimer/locker"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StatsCommand", func() {
+	Describe("Execute", func() {
+		var (
+			locker *commandsfakes.FakeLocker
+			ago    func(hours int) time.Time
+		)
+
+		BeforeEach(func() {
+			locker = new(commandsfakes.FakeLocker)
+			now := time.Now()
+			ago = func(hours int) time.Time {
+				return now.Add(-time.Duration(hours) * time.Hour)
+			}
+		})
+
+		It("responds with utilization, top claimers and current claims of all pools", func() {
+			locker.HistoriesReturns([]clocker.History{
+				{
+					Pool:    "pool-1",
+					Created: ago(100 * 24),
+					Claims: []clocker.Claim{
+						{Owner: "some-user", Claimed: ago(60 * 24), Released: ago(50 * 24), ReleasedBy: "some-user"},
+						{Owner: "some-user", Claimed: ago(30*24 + 12), Released: ago(29 * 24), ReleasedBy: "some-user"},
+						{Owner: "some-other-user", Claimed: ago(5 * 24), Released: ago(4 * 24), ReleasedBy: "some-other-user"},
+						{Owner: "some-other-user", Claimed: ago(2 * 24)},
+					},
+				},
+				{
+					Pool:    "pool-2",
+					Created: ago(10 * 24),
+					Claims: []clocker.Claim{
+						{Owner: "some-user", Claimed: ago(6 * 24)},
+					},
+				},
+			}, nil)
+
+			command := NewFactory(locker).NewCommand("stats", "", "")
+
+			slackResponse, err := command.Execute()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(slackResponse).To(MatchRegexp(
+				`^Usage since .*:\n` +
+					`\*Utilization:\*\n` +
+					`pool-2: 60% claimed, 1 claims, mean claim 0m\n` +
+					`pool-1: 13% claimed, 3 claims, mean claim 1d 6h\n` +
+					`\*Top claimers:\*\n` +
+					`some-user: 7d across 2 claims\n` +
+					`some-other-user: 3d across 2 claims\n` +
+					`\*Longest current claims:\*\n` +
+					`pool-2: some-user for 6d\n` +
+					`pool-1: some-other-user for 2d$`,
+			))
+
+			Expect(locker.HistoriesCallCount()).To(Equal(1))
+			Expect(locker.HistoryCallCount()).To(Equal(0))
+		})
+
+		Context("when a pool and period are provided", func() {
+			It("responds with stats for that pool over that period", func() {
+				locker.StatusReturns([]clocker.Lock{{Name: "some-pool"}}, nil)
+				locker.HistoryReturns(clocker.History{
+					Pool: "some-pool",
+					Claims: []clocker.Claim{
+						{Owner: "some-user", Claimed: ago(30), Released: ago(18), ReleasedBy: "some-user"},
+					},
+				}, nil)
+
+				command := NewFactory(locker).NewCommand("stats", "some-pool --since 1d", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(ContainSubstring("\nsome-pool: 25% claimed, 1 claims, mean claim 12h\n"))
+				Expect(slackResponse).To(ContainSubstring("\nsome-user: 6h across 1 claims\n"))
+
+				Expect(locker.HistoryArgsForCall(0)).To(Equal("some-pool"))
+			})
+		})
+
+		Context("when the period is invalid", func() {
+			It("returns a slack response", func() {
+				command := NewFactory(locker).NewCommand("stats", "--since=forever", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("forever is not a valid duration"))
+			})
+		})
+
+		Context("when the pool does not exist", func() {
+			It("returns a slack response", func() {
+				locker.StatusReturns(nil, nil)
+
+				command := NewFactory(locker).NewCommand("stats", "some-pool", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("some-pool does not exist"))
+			})
+		})
+
+		Context("when checking the status fails", func() {
+			It("returns an error", func() {
+				locker.StatusReturns(nil, errors.New("some-error"))
+
+				command := NewFactory(locker).NewCommand("stats", "some-pool", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).To(MatchError("failed to get status of locks: some-error"))
+				Expect(slackResponse).To(BeEmpty())
+			})
+		})
+
+		Context("when getting the history fails", func() {
+			It("returns an error", func() {
+				locker.HistoriesReturns(nil, errors.New("some-error"))
+
+				command := NewFactory(locker).NewCommand("stats", "", "")
+
+				slackResponse, err := command.Execute()
+				Expect(err).To(MatchError("failed to get history: some-error"))
+				Expect(slackResponse).To(BeEmpty())
+			})
+		})
+	})
+})
@@ -10,6 +10,7 @@ import (
 
 type statusCommand struct {
 	locker   locker
+	args     string
 	username string
 }
 
@@ -19,6 +20,16 @@ func (s *statusCommand) Execute() (string, error) {
 		return "", errors.Wrap(err, "failed to get status of locks")
 	}
 
+	if args := strings.Fields(s.args); len(args) > 0 {
+		group := args[0]
+		locks = filterLocks(locks, func(lock clocker.Lock) bool {
+			return lock.Name == group || inGroup(lock.Name, group)
+		})
+		if len(locks) == 0 {
+			return T("status.group_does_not_exist", TArgs{"group": group}), nil
+		}
+	}
+
 	usersClaimedLocks := filterLocks(locks, func(lock clocker.Lock) bool {
 		return lock.Claimed && lock.Owner == s.username
 	})
@@ -27,8 +38,10 @@ func (s *statusCommand) Execute() (string, error) {
 		return lock.Claimed && lock.Owner != s.username
 	})
 
-	unclaimedLocks := filterLocks(locks, func(lock clocker.Lock) bool {
-		return !lock.Claimed
+	unclaimedLocks := filterLocks(locks, available)
+
+	disabledLocks := filterLocks(locks, func(lock clocker.Lock) bool {
+		return lock.Maintenance != nil
 	})
 
 	tArgs := TArgs{
@@ -36,7 +49,25 @@ func (s *statusCommand) Execute() (string, error) {
 		"otherClaimed": lockNames(otherClaimedLocks),
 		"unclaimed":    lockNames(unclaimedLocks),
 	}
-	return T("status.success", tArgs), nil
+	response := T("status.success", tArgs)
+	if len(disabledLocks) > 0 {
+		response += "\n" + T("status.disabled", TArgs{"disabled": disabledNames(disabledLocks)})
+	}
+	return response, nil
+}
+
+// disabledNames lists disabled pools along with the reasons they were
+// disabled
+func disabledNames(locks []clocker.Lock) string {
+	var names []string
+	for _, lock := range locks {
+		if lock.Maintenance.Reason != "" {
+			names = append(names, T("status.disabled_pool", TArgs{"pool": lock.Name, "reason": lock.Maintenance.Reason}))
+		} else {
+			names = append(names, lock.Name)
+		}
+	}
+	return strings.Join(names, ", ")
 }
 
 func filterLocks(locks []clocker.Lock, filterFunc func(clocker.Lock) bool) []clocker.Lock {
@@ -52,7 +83,11 @@ func filterLocks(locks []clocker.Lock, filterFunc func(clocker.Lock) bool) []clo
 func lockNames(locks []clocker.Lock) string {
 	var names []string
 	for _, lock := range locks {
-		names = append(names, lock.Name)
+		if lock.Description != "" {
+			names = append(names, T("status.described_pool", TArgs{"pool": lock.Name, "description": lock.Description}))
+		} else {
+			names = append(names, lock.Name)
+		}
 	}
 	return strings.Join(names, ", ")
 }
@@ -37,6 +37,72 @@ var _ = Describe("StatusCommand", func() {
 			Expect(slackResponse).To(Equal("*Claimed by you:* claimed-1\n*Claimed by others:* claimed-2\n*Unclaimed:* unclaimed-1, unclaimed-2"))
 		})
 
+		It("shows the descriptions of pools", func() {
+			locker.StatusReturns(
+				[]clocker.Lock{
+					{Name: "claimed-1", Owner: "some-user", Claimed: true, Metadata: clocker.Metadata{Description: "some description"}},
+					{Name: "unclaimed-1", Claimed: false, Metadata: clocker.Metadata{Description: "some other description"}},
+					{Name: "unclaimed-2", Claimed: false, Metadata: clocker.Metadata{Team: "some-team"}},
+				},
+				nil,
+			)
+
+			command := NewFactory(locker).NewCommand("status", "", "some-other-user")
+
+			slackResponse, err := command.Execute()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(slackResponse).To(Equal("*Claimed by you:* \n*Claimed by others:* claimed-1 (some description)\n*Unclaimed:* unclaimed-1 (some other description), unclaimed-2"))
+		})
+
+		It("shows disabled pools separately", func() {
+			locker.StatusReturns(
+				[]clocker.Lock{
+					{Name: "disabled-1", Maintenance: &clocker.Maintenance{By: "some-user", Reason: "repaving"}},
+					{Name: "disabled-2", Maintenance: &clocker.Maintenance{By: "some-user"}},
+					{Name: "unclaimed-1", Claimed: false},
+				},
+				nil,
+			)
+
+			command := NewFactory(locker).NewCommand("status", "", "some-user")
+
+			slackResponse, err := command.Execute()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(slackResponse).To(Equal("*Claimed by you:* \n*Claimed by others:* \n*Unclaimed:* unclaimed-1\n*Disabled:* disabled-1 (repaving), disabled-2"))
+		})
+
+		Context("when a group is specified", func() {
+			BeforeEach(func() {
+				locker.StatusReturns(
+					[]clocker.Lock{
+						{Name: "aws/us-east/env-1", Owner: "some-user", Claimed: true},
+						{Name: "aws/us-west/env-1", Claimed: false},
+						{Name: "aws-legacy", Claimed: false},
+						{Name: "gcp/env-1", Claimed: false},
+					},
+					nil,
+				)
+			})
+
+			It("only shows pools in the group", func() {
+				slackResponse, err := NewFactory(locker).NewCommand("status", "aws", "some-user").Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("*Claimed by you:* aws/us-east/env-1\n*Claimed by others:* \n*Unclaimed:* aws/us-west/env-1"))
+
+				slackResponse, err = NewFactory(locker).NewCommand("status", "aws/us-west/", "some-user").Execute()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slackResponse).To(Equal("*Claimed by you:* \n*Claimed by others:* \n*Unclaimed:* aws/us-west/env-1"))
+			})
+
+			Context("when the group does not exist", func() {
+				It("returns a slack response", func() {
+					slackResponse, err := NewFactory(locker).NewCommand("status", "azure", "").Execute()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(slackResponse).To(Equal("azure does not exist"))
+				})
+			})
+		})
+
 		Context("when getting the status fails", func() {
 			It("returns an error", func() {
 				locker.StatusReturns(nil, errors.New("some-error"))
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/mdelillo/claimer/api"
+	"github.com/mdelillo/claimer/bot/commands"
+	"github.com/mdelillo/claimer/locker"
+)
+
+// runCli runs a command given after the flags, e.g. `claimer -repoUrl <url>
+// claim pool-1 -m <message>`, instead of starting the bot. Commands behave
+// as they do in chat, and a command which is refused returns the response as
+// an error so that scripts can tell.
+func runCli(args []string, locks locker.Locker, actions commands.Actions, commandFactory commands.Factory, username string, out io.Writer) error {
+	switch args[0] {
+	case "claim":
+		flags := flag.NewFlagSet("claim", flag.ContinueOnError)
+		flags.SetOutput(ioutil.Discard)
+		message := flags.String("m", "", "Message to claim with")
+		pools, err := parseInterspersed(flags, args[1:])
+		if err != nil {
+			return err
+		}
+		if len(pools) == 0 {
+			return errors.New("usage: claim <env>... [-m <message>]")
+		}
+		result, err := actions.Claim(pools, username, *message)
+		return printResult(result, err, out)
+	case "release":
+		if len(args) < 2 {
+			return errors.New("usage: release <env>...")
+		}
+		result, err := actions.Release(args[1:], username)
+		return printResult(result, err, out)
+	case "status":
+		flags := flag.NewFlagSet("status", flag.ContinueOnError)
+		flags.SetOutput(ioutil.Discard)
+		asJson := flags.Bool("json", false, "Print the status as JSON")
+		rest, err := parseInterspersed(flags, args[1:])
+		if err != nil {
+			return err
+		}
+		if *asJson {
+			return printJson(locks, out)
+		}
+		return runCommand(commandFactory.NewCommand("status", strings.Join(rest, " "), username), out)
+	case "doctor":
+		return runCommand(commandFactory.NewCommand("doctor", strings.Join(args[1:], " "), username), out)
+	default:
+		return fmt.Errorf("unknown command %s", args[0])
+	}
+}
+
+func runCommand(command commands.Command, out io.Writer) error {
+	response, err := command.Execute()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, response)
+	return nil
+}
+
+// printResult prints the response to an action which changed the pools. An
+// action which was refused, e.g. because the pool is already claimed, returns
+// its response as an error.
+func printResult(result commands.Result, err error, out io.Writer) error {
+	if err != nil {
+		return err
+	}
+	if result.Refused() {
+		return errors.New(result.Response)
+	}
+	fmt.Fprintln(out, result.Response)
+	return nil
+}
+
+func printJson(locks locker.Locker, out io.Writer) error {
+	status, err := locks.Status()
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(api.Pools(status))
+}
+
+// parseInterspersed parses flags which may come before, between or after the
+// positional arguments, e.g. `claim pool-1 -m message`, and returns the
+// positional arguments
+func parseInterspersed(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		if flags.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+}
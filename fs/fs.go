@@ -4,6 +4,7 @@ import (
 	"github.com/pkg/errors"
 	"io/ioutil"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 )
@@ -14,6 +15,16 @@ func NewFs() *filesystem {
 	return &filesystem{}
 }
 
+func (*filesystem) Exists(path string) (bool, error) {
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "failed to stat path")
+	}
+	return true, nil
+}
+
 func (*filesystem) Ls(dir string) ([]string, error) {
 	var files []string
 
@@ -48,13 +59,68 @@ func (*filesystem) LsDirs(dir string) ([]string, error) {
 	return dirs, nil
 }
 
+// LsPools returns every pool below dir as a slash-separated path relative to
+// dir, e.g. "aws/us-east/env-1". A pool is a directory containing a claimed
+// or unclaimed directory; any other directory is a group of pools and is
+// searched in turn.
+func (*filesystem) LsPools(dir string) ([]string, error) {
+	return lsPools(dir, "")
+}
+
+func lsPools(root, group string) ([]string, error) {
+	var pools []string
+
+	children, err := ioutil.ReadDir(filepath.Join(root, filepath.FromSlash(group)))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list directory")
+	}
+
+	for _, child := range children {
+		if !child.IsDir() || isHidden(child) {
+			continue
+		}
+		name := path.Join(group, child.Name())
+		if isPool(filepath.Join(root, filepath.FromSlash(name))) {
+			pools = append(pools, name)
+			continue
+		}
+		nested, err := lsPools(root, name)
+		if err != nil {
+			return nil, err
+		}
+		pools = append(pools, nested...)
+	}
+
+	return pools, nil
+}
+
+func isPool(dir string) bool {
+	for _, lockDir := range []string{"claimed", "unclaimed"} {
+		if info, err := os.Stat(filepath.Join(dir, lockDir)); err == nil && info.IsDir() {
+			return true
+		}
+	}
+	return false
+}
+
 func (*filesystem) Mv(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return errors.Wrap(err, "failed to create directory")
+	}
 	if err := os.Rename(src, dst); err != nil {
 		return errors.Wrap(err, "failed to move file")
 	}
 	return nil
 }
 
+func (*filesystem) ReadFile(file string) ([]byte, error) {
+	contents, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read file")
+	}
+	return contents, nil
+}
+
 func (*filesystem) Rm(path string) error {
 	if err := os.RemoveAll(path); err != nil {
 		return errors.Wrap(err, "failed to remove path")
@@ -70,6 +136,17 @@ func (*filesystem) Touch(file string) error {
 	return ioutil.WriteFile(file, nil, 0644)
 }
 
+func (*filesystem) WriteFile(file string, contents []byte) error {
+	dir := filepath.Dir(file)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Wrap(err, "failed to create directory")
+	}
+	if err := ioutil.WriteFile(file, contents, 0644); err != nil {
+		return errors.Wrap(err, "failed to write file")
+	}
+	return nil
+}
+
 func isHidden(fileInfo os.FileInfo) bool {
 	return strings.HasPrefix(fileInfo.Name(), ".")
 }
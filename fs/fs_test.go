@@ -23,6 +23,16 @@ var _ = Describe("Fs", func() {
 		os.RemoveAll(tempDir)
 	})
 
+	Describe("Exists", func() {
+		It("returns whether the path exists", func() {
+			file := filepath.Join(tempDir, "some-file")
+			writeFile(file, nil)
+
+			Expect(NewFs().Exists(file)).To(BeTrue())
+			Expect(NewFs().Exists(filepath.Join(tempDir, "some-missing-file"))).To(BeFalse())
+		})
+	})
+
 	Describe("Ls", func() {
 		It("lists non-hidden files in a directory", func() {
 			firstFile := "some-file"
@@ -65,6 +75,33 @@ var _ = Describe("Fs", func() {
 		})
 	})
 
+	Describe("LsPools", func() {
+		It("lists pools, searching nested groups", func() {
+			Expect(os.MkdirAll(filepath.Join(tempDir, "pool-1", "claimed"), 0755)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(tempDir, "pool-1", "unclaimed", "not-a-pool"), 0755)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(tempDir, "aws", "us-east", "env-1", "unclaimed"), 0755)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(tempDir, "aws", "us-east", "env-2", "claimed"), 0755)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(tempDir, "aws", "us-west", "env-1", "unclaimed"), 0755)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(tempDir, "aws", "empty-group"), 0755)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(tempDir, ".git", "claimed"), 0755)).To(Succeed())
+			writeFile(filepath.Join(tempDir, "aws", "claimed"), nil)
+
+			Expect(NewFs().LsPools(tempDir)).To(Equal([]string{
+				"aws/us-east/env-1",
+				"aws/us-east/env-2",
+				"aws/us-west/env-1",
+				"pool-1",
+			}))
+		})
+
+		Context("when listing the directory fails", func() {
+			It("returns an error", func() {
+				_, err := NewFs().LsPools("some-bad-dir")
+				Expect(err).To(MatchError(ContainSubstring("failed to list directory: ")))
+			})
+		})
+	})
+
 	Describe("Mv", func() {
 		Context("when src exists and dst does not exist", func() {
 			It("moves src to dst", func() {
@@ -81,6 +118,19 @@ var _ = Describe("Fs", func() {
 			})
 		})
 
+		Context("when the parent of dst does not exist", func() {
+			It("creates it", func() {
+				src := filepath.Join(tempDir, "some-src-dir")
+				dst := filepath.Join(tempDir, "some", "nested", "dst-dir")
+				mkdir(src)
+				writeFile(filepath.Join(src, "some-file"), nil)
+
+				Expect(NewFs().Mv(src, dst)).To(Succeed())
+				Expect(src).NotTo(BeADirectory())
+				Expect(filepath.Join(dst, "some-file")).To(BeAnExistingFile())
+			})
+		})
+
 		Context("when src and dst exist", func() {
 			It("replaces dst with src", func() {
 				src := filepath.Join(tempDir, "some-src-path")
@@ -107,6 +157,23 @@ var _ = Describe("Fs", func() {
 		})
 	})
 
+	Describe("ReadFile", func() {
+		It("returns the contents of the file", func() {
+			file := filepath.Join(tempDir, "some-file")
+			contents := []byte("some-contents")
+			writeFile(file, contents)
+
+			Expect(NewFs().ReadFile(file)).To(Equal(contents))
+		})
+
+		Context("when reading the file fails", func() {
+			It("returns an error", func() {
+				_, err := NewFs().ReadFile(filepath.Join(tempDir, "some-missing-file"))
+				Expect(err).To(MatchError(ContainSubstring("failed to read file:")))
+			})
+		})
+	})
+
 	Describe("Rm", func() {
 		It("recursively removes the path", func() {
 			mkdir(filepath.Join(tempDir, "some-dir"))
@@ -134,6 +201,26 @@ var _ = Describe("Fs", func() {
 			})
 		})
 	})
+
+	Describe("WriteFile", func() {
+		It("writes the file and creates any required directories", func() {
+			file := filepath.Join(tempDir, "some", "nested", "file")
+			contents := []byte("some-contents")
+
+			Expect(NewFs().WriteFile(file, contents)).To(Succeed())
+			Expect(ioutil.ReadFile(file)).To(Equal(contents))
+		})
+
+		Context("when creating the directory fails", func() {
+			It("returns an error", func() {
+				notADirectory := filepath.Join(tempDir, "not-a-directory")
+				writeFile(notADirectory, nil)
+
+				path := filepath.Join(notADirectory, "some-file")
+				Expect(NewFs().WriteFile(path, nil)).To(MatchError(ContainSubstring("failed to create directory:")))
+			})
+		})
+	})
 })
 
 func writeFile(path string, contents []byte) {
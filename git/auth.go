@@ -0,0 +1,132 @@
+package git
+
+import (
+	"encoding/pem"
+	"net"
+
+	"github.com/pkg/errors"
+	cryptossh "golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport/http"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport/ssh"
+)
+
+// tokenUsername is sent with an HTTPS token when no username is given. GitHub
+// accepts it for both personal access tokens and GitHub App tokens.
+const tokenUsername = "x-access-token"
+
+// Auth holds the credentials for the remote. Which of them are used depends
+// on the scheme of the repo URL: HTTPS URLs use Username and Password (or a
+// token), SSH URLs use DeployKey (decrypted with Passphrase if needed) or the
+// keys held by ssh-agent.
+//
+// SSH servers must present a host key listed in the KnownHosts file or
+// matching one of HostKeyFingerprints (e.g. "SHA256:nThbg6kX..."). If neither
+// is set the default known_hosts files are used, and connecting fails if
+// there are none.
+type Auth struct {
+	DeployKey  string
+	Passphrase string
+	SSHAgent   bool
+	Username   string
+	Password   string
+
+	KnownHosts          string
+	HostKeyFingerprints []string
+}
+
+func (a Auth) method(url string) (transport.AuthMethod, error) {
+	endpoint, err := transport.NewEndpoint(url)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse repo url")
+	}
+
+	switch endpoint.Protocol {
+	case "http", "https":
+		if a.DeployKey != "" || a.SSHAgent {
+			return nil, errors.Errorf("cannot use ssh credentials with %s", url)
+		}
+		if a.Password == "" {
+			return nil, nil
+		}
+		username := a.Username
+		if username == "" {
+			username = tokenUsername
+		}
+		return &http.BasicAuth{Username: username, Password: a.Password}, nil
+	case "ssh":
+		if a.Password != "" {
+			return nil, errors.Errorf("cannot use https credentials with %s", url)
+		}
+		user := endpoint.User
+		if user == "" {
+			user = "git"
+		}
+		var auth *ssh.PublicKeys
+		var agent *ssh.PublicKeysCallback
+		switch {
+		case a.DeployKey != "":
+			if block, _ := pem.Decode([]byte(a.DeployKey)); block == nil {
+				return nil, errors.New("failed to parse public key: invalid PEM")
+			}
+			if auth, err = ssh.NewPublicKeys(user, []byte(a.DeployKey), a.Passphrase); err != nil {
+				return nil, errors.Wrap(err, "failed to parse public key")
+			}
+		case a.SSHAgent:
+			if agent, err = ssh.NewSSHAgentAuth(user); err != nil {
+				return nil, errors.Wrap(err, "failed to connect to ssh-agent")
+			}
+		default:
+			return nil, errors.Errorf("no credentials for %s: pass a deploy key or use ssh-agent", url)
+		}
+
+		hostKeyCallback, err := a.hostKeyCallback()
+		if err != nil {
+			return nil, err
+		}
+		if agent != nil {
+			agent.HostKeyCallback = hostKeyCallback
+			return agent, nil
+		}
+		auth.HostKeyCallback = hostKeyCallback
+		return auth, nil
+	default:
+		return nil, nil
+	}
+}
+
+// hostKeyCallback rejects SSH servers whose host key is not trusted
+func (a Auth) hostKeyCallback() (cryptossh.HostKeyCallback, error) {
+	if len(a.HostKeyFingerprints) > 0 {
+		return func(host string, _ net.Addr, key cryptossh.PublicKey) error {
+			fingerprint := cryptossh.FingerprintSHA256(key)
+			for _, trusted := range a.HostKeyFingerprints {
+				if fingerprint == trusted {
+					return nil
+				}
+			}
+			return errors.Errorf("unknown host key for %s: %s is not one of the trusted fingerprints", host, fingerprint)
+		}, nil
+	}
+
+	var files []string
+	if a.KnownHosts != "" {
+		files = append(files, a.KnownHosts)
+	}
+	callback, err := ssh.NewKnownHostsCallback(files...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load known hosts")
+	}
+	return func(host string, remote net.Addr, key cryptossh.PublicKey) error {
+		err := callback(host, remote, key)
+		if keyErr, ok := err.(*knownhosts.KeyError); ok {
+			fingerprint := cryptossh.FingerprintSHA256(key)
+			if len(keyErr.Want) == 0 {
+				return errors.Errorf("unknown host key for %s: %s is not in known_hosts", host, fingerprint)
+			}
+			return errors.Errorf("host key for %s has changed to %s: refusing to connect", host, fingerprint)
+		}
+		return err
+	}, nil
+}
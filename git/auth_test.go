@@ -0,0 +1,336 @@
+package git_test
+
+import (
+	. "github.com/mdelillo/claimer/git"
+
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/cgi"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+var _ = Describe("Auth", func() {
+	var gitDir string
+
+	BeforeEach(func() {
+		var err error
+		gitDir, err = ioutil.TempDir("", "claimer-test-git-dir")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(gitDir)
+	})
+
+	Context("when the repo is served over HTTPS", func() {
+		var (
+			serverDir string
+			server    *httptest.Server
+			repoUrl   string
+		)
+
+		BeforeEach(func() {
+			var err error
+			serverDir, err = ioutil.TempDir("", "claimer-test-git-server")
+			Expect(err).NotTo(HaveOccurred())
+
+			workDir := filepath.Join(serverDir, "work")
+			Expect(os.Mkdir(workDir, 0755)).To(Succeed())
+			runGitCommand(workDir, "init", ".")
+			runGitCommand(workDir, "symbolic-ref", "HEAD", "refs/heads/master")
+			runGitCommand(workDir, "commit", "--allow-empty", "-m", "Initial commit")
+			runGitCommand(serverDir, "clone", "--bare", workDir, "repo.git")
+			runGitCommand(filepath.Join(serverDir, "repo.git"), "config", "http.receivepack", "true")
+
+			backend := &cgi.Handler{
+				Path: filepath.Join(runGitCommand(serverDir, "--exec-path"), "git-http-backend"),
+				Env:  []string{"GIT_PROJECT_ROOT=" + serverDir, "GIT_HTTP_EXPORT_ALL=1"},
+			}
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				username, password, ok := r.BasicAuth()
+				if !ok || username != "some-user" || password != "some-token" {
+					w.Header().Set("WWW-Authenticate", `Basic realm="git"`)
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				r.Header.Set("REMOTE_USER", username)
+				backend.ServeHTTP(w, r)
+			}))
+			repoUrl = server.URL + "/repo.git"
+		})
+
+		AfterEach(func() {
+			server.Close()
+			os.RemoveAll(serverDir)
+		})
+
+		It("fetches and pushes with the username and token", func() {
+			repo := NewRepo(repoUrl, Auth{Username: "some-user", Password: "some-token"}, gitDir, nil, DefaultCommitter)
+			Expect(repo.CloneOrPull()).To(Succeed())
+
+			touchFile(filepath.Join(gitDir, "some-file"))
+			Expect(repo.CommitAndPush("some-message", Identity{Name: "some-author"})).To(Succeed())
+
+			Expect(runGitCommand(filepath.Join(serverDir, "repo.git"), "log", "-1", "--format=%s")).To(Equal("some-message"))
+			Expect(repo.CloneOrPull()).To(Succeed())
+		})
+
+		Context("when the credentials are wrong", func() {
+			It("returns an error", func() {
+				repo := NewRepo(repoUrl, Auth{Username: "some-user", Password: "some-other-token"}, gitDir, nil, DefaultCommitter)
+				Expect(repo.CloneOrPull()).To(MatchError(ContainSubstring("failed to clone repo: ")))
+			})
+		})
+
+		Context("when ssh credentials are given", func() {
+			It("returns an error", func() {
+				repo := NewRepo(repoUrl, Auth{SSHAgent: true}, gitDir, nil, DefaultCommitter)
+				Expect(repo.CloneOrPull()).To(MatchError("cannot use ssh credentials with " + repoUrl))
+			})
+		})
+	})
+
+	Context("when the repo is served over SSH", func() {
+		var (
+			server      *sshServer
+			repoUrl     string
+			fingerprint string
+			deployKey   string
+		)
+
+		BeforeEach(func() {
+			server = startSSHServer()
+			repoUrl = "ssh://git@" + server.addr + "/some-repo.git"
+			fingerprint = ssh.FingerprintSHA256(server.hostKey)
+			deployKey = generateDeployKey()
+		})
+
+		AfterEach(func() {
+			server.close()
+		})
+
+		Context("when the host key has a trusted fingerprint", func() {
+			It("connects to the server", func() {
+				repo := NewRepo(repoUrl, Auth{DeployKey: deployKey, HostKeyFingerprints: []string{"SHA256:some-other-fingerprint", fingerprint}}, gitDir, nil, DefaultCommitter)
+				err := repo.CloneOrPull()
+				Expect(err).To(MatchError(ContainSubstring("failed to clone repo: ")))
+				Expect(err).NotTo(MatchError(ContainSubstring("host key")))
+				Expect(server.connections()).To(Equal(1))
+			})
+		})
+
+		Context("when the host key does not have a trusted fingerprint", func() {
+			It("refuses to connect", func() {
+				repo := NewRepo(repoUrl, Auth{DeployKey: deployKey, HostKeyFingerprints: []string{"SHA256:some-other-fingerprint"}}, gitDir, nil, DefaultCommitter)
+				err := repo.CloneOrPull()
+				Expect(err).To(MatchError(ContainSubstring(
+					fmt.Sprintf("unknown host key for %s: %s is not one of the trusted fingerprints", server.addr, fingerprint),
+				)))
+				Expect(server.connections()).To(Equal(0))
+			})
+		})
+
+		Context("when a known_hosts file is given", func() {
+			var knownHosts string
+
+			BeforeEach(func() {
+				knownHosts = filepath.Join(gitDir, "known_hosts")
+			})
+
+			It("connects to servers listed in it", func() {
+				line := knownhosts.Line([]string{knownhosts.Normalize(server.addr)}, server.hostKey)
+				Expect(ioutil.WriteFile(knownHosts, []byte(line+"\n"), 0644)).To(Succeed())
+
+				repo := NewRepo(repoUrl, Auth{DeployKey: deployKey, KnownHosts: knownHosts}, filepath.Join(gitDir, "clone"), nil, DefaultCommitter)
+				err := repo.CloneOrPull()
+				Expect(err).NotTo(MatchError(ContainSubstring("host key")))
+				Expect(server.connections()).To(Equal(1))
+			})
+
+			It("refuses to connect to servers not listed in it", func() {
+				Expect(ioutil.WriteFile(knownHosts, nil, 0644)).To(Succeed())
+
+				repo := NewRepo(repoUrl, Auth{DeployKey: deployKey, KnownHosts: knownHosts}, filepath.Join(gitDir, "clone"), nil, DefaultCommitter)
+				Expect(repo.CloneOrPull()).To(MatchError(ContainSubstring(
+					fmt.Sprintf("unknown host key for %s: %s is not in known_hosts", server.addr, fingerprint),
+				)))
+				Expect(server.connections()).To(Equal(0))
+			})
+
+			It("refuses to connect to servers whose key has changed", func() {
+				otherKey, err := ssh.NewSignerFromKey(generateKey())
+				Expect(err).NotTo(HaveOccurred())
+				line := knownhosts.Line([]string{knownhosts.Normalize(server.addr)}, otherKey.PublicKey())
+				Expect(ioutil.WriteFile(knownHosts, []byte(line+"\n"), 0644)).To(Succeed())
+
+				repo := NewRepo(repoUrl, Auth{DeployKey: deployKey, KnownHosts: knownHosts}, filepath.Join(gitDir, "clone"), nil, DefaultCommitter)
+				Expect(repo.CloneOrPull()).To(MatchError(ContainSubstring(
+					fmt.Sprintf("host key for %s has changed to %s: refusing to connect", server.addr, fingerprint),
+				)))
+			})
+
+			Context("when the file does not exist", func() {
+				It("returns an error", func() {
+					repo := NewRepo(repoUrl, Auth{DeployKey: deployKey, KnownHosts: knownHosts}, filepath.Join(gitDir, "clone"), nil, DefaultCommitter)
+					Expect(repo.CloneOrPull()).To(MatchError(ContainSubstring("failed to load known hosts: ")))
+				})
+			})
+		})
+
+		Context("when no credentials are given", func() {
+			It("returns an error", func() {
+				repo := NewRepo(repoUrl, Auth{}, gitDir, nil, DefaultCommitter)
+				Expect(repo.CloneOrPull()).To(MatchError(fmt.Sprintf("no credentials for %s: pass a deploy key or use ssh-agent", repoUrl)))
+			})
+		})
+
+		Context("when the deploy key is encrypted", func() {
+			BeforeEach(func() {
+				keyPath := filepath.Join(gitDir, "some-key")
+				runCommand(gitDir, "ssh-keygen", "-q", "-t", "rsa", "-b", "2048", "-m", "PEM", "-N", "some-passphrase", "-f", keyPath)
+				contents, err := ioutil.ReadFile(keyPath)
+				Expect(err).NotTo(HaveOccurred())
+				deployKey = string(contents)
+				Expect(os.Remove(keyPath)).To(Succeed())
+				Expect(os.Remove(keyPath + ".pub")).To(Succeed())
+			})
+
+			It("decrypts it with the passphrase", func() {
+				repo := NewRepo(repoUrl, Auth{DeployKey: deployKey, Passphrase: "some-passphrase", HostKeyFingerprints: []string{fingerprint}}, gitDir, nil, DefaultCommitter)
+				err := repo.CloneOrPull()
+				Expect(err).To(MatchError(ContainSubstring("failed to clone repo: ")))
+				Expect(server.connections()).To(Equal(1))
+			})
+
+			Context("when the passphrase is wrong", func() {
+				It("returns an error", func() {
+					repo := NewRepo(repoUrl, Auth{DeployKey: deployKey, Passphrase: "some-other-passphrase"}, gitDir, nil, DefaultCommitter)
+					Expect(repo.CloneOrPull()).To(MatchError(ContainSubstring("failed to parse public key: ")))
+				})
+			})
+		})
+
+		Context("when ssh-agent is not running", func() {
+			var authSock string
+
+			BeforeEach(func() {
+				authSock = os.Getenv("SSH_AUTH_SOCK")
+				os.Unsetenv("SSH_AUTH_SOCK")
+			})
+
+			AfterEach(func() {
+				os.Setenv("SSH_AUTH_SOCK", authSock)
+			})
+
+			It("returns an error", func() {
+				repo := NewRepo(repoUrl, Auth{SSHAgent: true}, gitDir, nil, DefaultCommitter)
+				Expect(repo.CloneOrPull()).To(MatchError(ContainSubstring("failed to connect to ssh-agent: ")))
+			})
+		})
+
+		Context("when a token is given", func() {
+			It("returns an error", func() {
+				repo := NewRepo(repoUrl, Auth{Password: "some-token"}, gitDir, nil, DefaultCommitter)
+				Expect(repo.CloneOrPull()).To(MatchError("cannot use https credentials with " + repoUrl))
+			})
+		})
+	})
+})
+
+// sshServer accepts any client key and then rejects every channel, so that
+// clients get as far as authenticating but can't fetch anything
+type sshServer struct {
+	addr     string
+	hostKey  ssh.PublicKey
+	listener net.Listener
+
+	mutex sync.Mutex
+	count int
+}
+
+func startSSHServer() *sshServer {
+	hostKey, err := ssh.NewSignerFromKey(generateKey())
+	Expect(err).NotTo(HaveOccurred())
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(ssh.ConnMetadata, ssh.PublicKey) (*ssh.Permissions, error) {
+			return nil, nil
+		},
+	}
+	config.AddHostKey(hostKey)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).NotTo(HaveOccurred())
+	server := &sshServer{addr: listener.Addr().String(), hostKey: hostKey.PublicKey(), listener: listener}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, channels, requests, err := ssh.NewServerConn(conn, config)
+				if err != nil {
+					return
+				}
+				server.mutex.Lock()
+				server.count++
+				server.mutex.Unlock()
+				go ssh.DiscardRequests(requests)
+				for channel := range channels {
+					channel.Reject(ssh.Prohibited, "no repos here")
+				}
+			}()
+		}
+	}()
+	return server
+}
+
+// connections returns the number of clients which completed the handshake
+func (s *sshServer) connections() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.count
+}
+
+func (s *sshServer) close() {
+	s.listener.Close()
+}
+
+func generateKey() *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).NotTo(HaveOccurred())
+	return key
+}
+
+func generateDeployKey() string {
+	return string(pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(generateKey()),
+	}))
+}
+
+func runCommand(dir, name string, args ...string) string {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	ExpectWithOffset(1, err).NotTo(HaveOccurred(), string(output))
+	return strings.TrimSpace(string(output))
+}
@@ -0,0 +1,273 @@
+package git
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	git "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/plumbing/storer"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+)
+
+// dateFormat matches the default date format used by git log
+const dateFormat = "Mon Jan 2 15:04:05 2006 -0700"
+
+type Commit struct {
+	Hash    string
+	Author  string
+	Date    time.Time
+	Subject string
+	Body    string
+	Changes []Change
+}
+
+// Change is a file added (A), deleted (D), modified (M) or renamed (R) by a
+// commit. OldPath is only set for renames.
+type Change struct {
+	Status  string
+	Path    string
+	OldPath string
+}
+
+func (r *repo) LatestCommit(path string) (string, string, string, error) {
+	commits, err := r.log([]string{path}, 1)
+	if err != nil {
+		return "", "", "", err
+	}
+	if len(commits) == 0 {
+		return "", "", "", nil
+	}
+	return commits[0].Author, commits[0].Date.Format(dateFormat), commits[0].Body, nil
+}
+
+// Head returns the SHA of the commit checked out in the clone
+func (r *repo) Head() (string, error) {
+	repo, err := git.PlainOpen(r.dir)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get head")
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get head")
+	}
+	return head.Hash().String(), nil
+}
+
+// LatestCommits returns the newest commit touching each of the given paths,
+// using a single pass over the log. Paths which have never been touched are
+// left out of the result.
+func (r *repo) LatestCommits(paths []string) (map[string]Commit, error) {
+	latest := map[string]Commit{}
+	if len(paths) == 0 {
+		return latest, nil
+	}
+
+	commits, err := r.log(paths, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, commit := range commits {
+		for _, path := range paths {
+			if _, ok := latest[path]; ok {
+				continue
+			}
+			for _, change := range commit.Changes {
+				if within(change.Path, path) || within(change.OldPath, path) {
+					latest[path] = commit
+					break
+				}
+			}
+		}
+		if len(latest) == len(paths) {
+			break
+		}
+	}
+	return latest, nil
+}
+
+// Log returns the commits touching the given path, newest first, with only
+// the changes inside that path. An empty path returns every commit. Like git
+// log, merge commits are left out.
+func (r *repo) Log(path string) ([]Commit, error) {
+	return r.log([]string{path}, 0)
+}
+
+// log returns up to limit commits touching any of the paths, or every such
+// commit if limit is zero
+func (r *repo) log(paths []string, limit int) ([]Commit, error) {
+	repo, err := git.PlainOpen(r.dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get log")
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get log")
+	}
+	iter, err := repo.Log(&git.LogOptions{From: head.Hash(), Order: git.LogOrderCommitterTime})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get log")
+	}
+	defer iter.Close()
+
+	var commits []Commit
+	err = iter.ForEach(func(c *object.Commit) error {
+		if c.NumParents() > 1 {
+			return nil
+		}
+		changes, err := changesIn(c, paths)
+		if err != nil {
+			return err
+		}
+		if len(changes) == 0 {
+			return nil
+		}
+
+		subject, body := splitMessage(c.Message)
+		commits = append(commits, Commit{
+			Hash:    c.Hash.String(),
+			Author:  c.Author.Name,
+			Date:    c.Author.When,
+			Subject: subject,
+			Body:    body,
+			Changes: changes,
+		})
+		if limit > 0 && len(commits) == limit {
+			return storer.ErrStop
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get log")
+	}
+	return commits, nil
+}
+
+// changesIn returns the changes a commit makes to files inside the paths.
+// A file deleted and added with the same contents is reported as a rename,
+// even if only one side of the rename is inside the paths.
+func changesIn(c *object.Commit, paths []string) ([]Change, error) {
+	tree, err := c.Tree()
+	if err != nil {
+		return nil, err
+	}
+	var parentTree *object.Tree
+	if c.NumParents() == 1 {
+		parent, err := c.Parent(0)
+		if err != nil {
+			return nil, err
+		}
+		if parentTree, err = parent.Tree(); err != nil {
+			return nil, err
+		}
+	}
+	diff, err := object.DiffTree(parentTree, tree)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []Change
+	deleted := map[int]string{}
+	for _, d := range diff {
+		action, err := d.Action()
+		if err != nil {
+			return nil, err
+		}
+		switch action {
+		case merkletrie.Insert:
+			changes = append(changes, Change{Status: "A", Path: d.To.Name})
+		case merkletrie.Delete:
+			deleted[len(changes)] = d.From.TreeEntry.Hash.String()
+			changes = append(changes, Change{Status: "D", Path: d.From.Name})
+		case merkletrie.Modify:
+			changes = append(changes, Change{Status: "M", Path: d.To.Name})
+		}
+	}
+
+	var result []Change
+	for _, change := range detectRenames(changes, deleted, diff) {
+		if inAny(change.Path, paths) || inAny(change.OldPath, paths) {
+			result = append(result, change)
+		}
+	}
+	return result, nil
+}
+
+// detectRenames pairs each deletion with an addition of the same contents,
+// preferring one with the same file name
+func detectRenames(changes []Change, deleted map[int]string, diff object.Changes) []Change {
+	if len(deleted) == 0 {
+		return changes
+	}
+	added := map[string]string{}
+	for _, d := range diff {
+		if d.From.Name == "" {
+			added[d.To.Name] = d.To.TreeEntry.Hash.String()
+		}
+	}
+
+	var deletions []int
+	for i := range deleted {
+		deletions = append(deletions, i)
+	}
+	sort.Ints(deletions)
+
+	renamed := map[int]bool{}
+	for _, sameName := range []bool{true, false} {
+		for _, i := range deletions {
+			hash := deleted[i]
+			if renamed[i] {
+				continue
+			}
+			for j := range changes {
+				change := changes[j]
+				if change.Status != "A" || added[change.Path] != hash {
+					continue
+				}
+				if sameName && path.Base(change.Path) != path.Base(changes[i].Path) {
+					continue
+				}
+				changes[j] = Change{Status: "R", Path: change.Path, OldPath: changes[i].Path}
+				renamed[i] = true
+				break
+			}
+		}
+	}
+
+	var result []Change
+	for i, change := range changes {
+		if !renamed[i] {
+			result = append(result, change)
+		}
+	}
+	return result
+}
+
+// splitMessage splits a commit message into its subject, which is the first
+// paragraph, and its body
+func splitMessage(message string) (string, string) {
+	message = strings.TrimSpace(message)
+	parts := strings.SplitN(message, "\n\n", 2)
+	subject := strings.Replace(parts[0], "\n", " ", -1)
+	if len(parts) == 1 {
+		return subject, ""
+	}
+	return subject, strings.TrimSpace(parts[1])
+}
+
+func inAny(file string, paths []string) bool {
+	for _, path := range paths {
+		if path == "" || within(file, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// within reports whether file is dir or is inside it
+func within(file, dir string) bool {
+	return file != "" && (file == dir || strings.HasPrefix(file, dir+"/"))
+}
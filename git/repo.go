@@ -1,128 +1,247 @@
 package git
 
 import (
-	"encoding/pem"
 	"fmt"
 	"io/ioutil"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	git "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/config"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
 	"gopkg.in/src-d/go-git.v4/plumbing/transport"
-	"gopkg.in/src-d/go-git.v4/plumbing/transport/ssh"
 )
 
+const branch = "master"
+
+// DefaultCommitter is the committer of every commit unless another is
+// configured
+var DefaultCommitter = Identity{Name: "Claimer"}
+
+// Identity is the name and email recorded as the author or committer of a
+// commit
+type Identity struct {
+	Name  string
+	Email string
+}
+
+var errCorrupt = errors.New("corrupt repo")
+
 type repo struct {
 	url       string
-	deployKey string
+	auth      Auth
 	dir       string
+	signer    Signer
+	committer Identity
 }
 
-func NewRepo(url, deployKey string, dir string) *repo {
+// NewRepo returns a repo which clones url into dir. Commits are signed by
+// signer, or left unsigned if it is nil.
+func NewRepo(url string, auth Auth, dir string, signer Signer, committer Identity) *repo {
 	return &repo{
 		url:       url,
-		deployKey: deployKey,
+		auth:      auth,
 		dir:       dir,
+		signer:    signer,
+		committer: committer,
 	}
 }
 
 func (r *repo) CloneOrPull() error {
-	var auth transport.AuthMethod
-	if r.deployKey != "" {
-		if block, _ := pem.Decode([]byte(r.deployKey)); block == nil {
-			return errors.New("failed to parse public key: invalid PEM")
-		}
-		var err error
-		auth, err = ssh.NewPublicKeys("git", []byte(r.deployKey), "")
-		if err != nil {
-			return errors.Wrap(err, "failed to parse public key")
-		}
+	auth, err := r.auth.method(r.url)
+	if err != nil {
+		return err
 	}
 
 	if r.cloned() {
 		repo, err := git.PlainOpen(r.dir)
 		if err != nil {
-			return errors.Wrap(err, "failed to open repo")
+			return r.reclone(auth)
+		}
+		if err := r.validate(repo); err != nil {
+			if err == errCorrupt {
+				return r.reclone(auth)
+			}
+			return err
 		}
 		if err := repo.Fetch(&git.FetchOptions{Auth: auth}); err != nil && err != git.NoErrAlreadyUpToDate {
 			return errors.Wrap(err, "failed to fetch repo")
 		}
-		if output, err := r.run("reset", "--hard", "origin/master"); err != nil {
-			return errors.Errorf("failed to reset repo: %s: %s", err, string(output))
+		if err := reset(repo); err != nil {
+			return r.reclone(auth)
 		}
 	} else {
-		_, err := git.PlainClone(r.dir, false, &git.CloneOptions{URL: r.url, Auth: auth})
-		if err != nil {
-			return errors.Wrap(err, "failed to clone repo")
+		if err := r.checkNotInUse(); err != nil {
+			return err
+		}
+		if err := r.clone(auth); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
-func (r *repo) CommitAndPush(message, committer string) error {
-	if output, err := r.run("add", "-A"); err != nil {
-		return errors.Errorf("failed to stage files: %s: %s", err, string(output))
+// validate makes sure that an existing clone can be reused. It returns
+// errCorrupt if the clone is broken, e.g. its origin remote is missing, and
+// should be replaced.
+func (r *repo) validate(repo *git.Repository) error {
+	remote, err := repo.Remote("origin")
+	if err != nil || len(remote.Config().URLs) == 0 {
+		return errCorrupt
 	}
-	if output, err := r.run("-c", "user.name=Claimer", "-c", "user.email=<>", "commit", "--author", committer+" <>", "-m", message); err != nil {
-		return errors.Errorf("failed to commit: %s: %s", err, string(output))
+	if remote.Config().URLs[0] != r.url {
+		return errors.Errorf("%s is a clone of %s, not %s", r.dir, remote.Config().URLs[0], r.url)
 	}
-	if output, err := r.run("push", "origin", "master"); err != nil {
-		return errors.Errorf("failed to push: %s: %s", err, string(output))
+
+	head, err := repo.Head()
+	if err != nil {
+		return errCorrupt
+	}
+	if head.Name() != plumbing.NewBranchReferenceName(branch) {
+		return errors.Errorf("%s has %s checked out, not %s", r.dir, head.Name().Short(), branch)
 	}
 	return nil
 }
 
-func (r *repo) Dir() string {
-	return r.dir
+// checkNotInUse refuses a work dir which is not a clone but has files in it,
+// in case it points somewhere it should not. A failed clone removes what is
+// in the directory it was cloning into.
+func (r *repo) checkNotInUse() error {
+	files, err := ioutil.ReadDir(r.dir)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "failed to read work dir")
+	}
+	if len(files) > 0 {
+		return errors.Errorf("refusing to clone into %s, which is not empty and is not a git repo", r.dir)
+	}
+	return nil
+}
+
+// reclone replaces a corrupt clone
+func (r *repo) reclone(auth transport.AuthMethod) error {
+	if err := os.RemoveAll(r.dir); err != nil {
+		return errors.Wrap(err, "failed to remove corrupt repo")
+	}
+	return r.clone(auth)
+}
+
+func (r *repo) clone(auth transport.AuthMethod) error {
+	if _, err := git.PlainClone(r.dir, false, &git.CloneOptions{URL: r.url, Auth: auth}); err != nil {
+		return errors.Wrap(err, "failed to clone repo")
+	}
+	return nil
+}
+
+// reset points the branch, index and working tree at the fetched commit
+func reset(repo *git.Repository) error {
+	remoteRef, err := repo.Reference(plumbing.NewRemoteReferenceName("origin", branch), true)
+	if err != nil {
+		return err
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return err
+	}
+	return worktree.Reset(&git.ResetOptions{Commit: remoteRef.Hash(), Mode: git.HardReset})
 }
 
-func (r *repo) LatestCommit(path string) (string, string, string, error) {
-	author, err := r.run("log", "-1", "--format=%an", path)
+func (r *repo) CommitAndPush(message string, author Identity) error {
+	repo, err := git.PlainOpen(r.dir)
 	if err != nil {
-		return "", "", "", errors.Errorf("failed to get commit author: %s: %s", err, string(author))
+		return errors.Wrap(err, "failed to open repo")
 	}
-	date, err := r.run("log", "-1", "--format=%ad", path)
+	worktree, err := repo.Worktree()
 	if err != nil {
-		return "", "", "", errors.Errorf("failed to get commit date: %s: %s", err, string(date))
+		return errors.Wrap(err, "failed to open worktree")
 	}
-	body, err := r.run("log", "-1", "--format=%b", path)
+
+	status, err := worktree.Status()
 	if err != nil {
-		return "", "", "", errors.Errorf("failed to get commit body: %s: %s", err, string(date))
+		return errors.Wrap(err, "failed to stage files")
+	}
+	if status.IsClean() {
+		return errors.New("failed to commit: nothing to commit")
+	}
+	for path, fileStatus := range status {
+		if fileStatus.Worktree == git.Unmodified {
+			continue
+		}
+		if _, err := worktree.Add(path); err != nil {
+			return errors.Wrap(err, "failed to stage files")
+		}
 	}
 
-	return strings.TrimSpace(string(author)), strings.TrimSpace(string(date)), strings.TrimSpace(string(body)), nil
-}
+	now := time.Now()
+	hash, err := worktree.Commit(message, &git.CommitOptions{
+		Author:    &object.Signature{Name: author.Name, Email: author.Email, When: now},
+		Committer: &object.Signature{Name: r.committer.Name, Email: r.committer.Email, When: now},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to commit")
+	}
+	if r.signer != nil {
+		if err := r.sign(repo, hash); err != nil {
+			return errors.Wrap(err, "failed to sign commit")
+		}
+	}
 
-func (r *repo) cloned() bool {
-	output, err := r.run("rev-parse", "--is-inside-work-tree")
-	return err == nil && strings.TrimSpace(string(output)) == "true"
+	auth, err := r.auth.method(r.url)
+	if err != nil {
+		return err
+	}
+	refSpec := config.RefSpec(fmt.Sprintf("refs/heads/%[1]s:refs/heads/%[1]s", branch))
+	if err := repo.Push(&git.PushOptions{Auth: auth, RefSpecs: []config.RefSpec{refSpec}}); err != nil {
+		return errors.Wrap(err, "failed to push")
+	}
+	return nil
 }
 
-func (r *repo) run(args ...string) ([]byte, error) {
-	tempDir, err := ioutil.TempDir("", "claimer-git")
+// sign replaces the commit at the tip of the branch with a signed copy
+func (r *repo) sign(repo *git.Repository, hash plumbing.Hash) error {
+	commit, err := repo.CommitObject(hash)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	defer os.RemoveAll(tempDir)
 
-	deployKeyPath := filepath.Join(tempDir, "deployKey")
-	if err := ioutil.WriteFile(deployKeyPath, []byte(r.deployKey), 0600); err != nil {
-		return nil, err
+	encoded := &plumbing.MemoryObject{}
+	if err := commit.Encode(encoded); err != nil {
+		return err
+	}
+	reader, err := encoded.Reader()
+	if err != nil {
+		return err
+	}
+	payload, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	commit.PGPSignature, err = r.signer.Sign(payload)
+	if err != nil {
+		return err
 	}
 
-	sshPath := filepath.Join(tempDir, "ssh")
-	sshScript := fmt.Sprintf("#!/bin/sh\n"+
-		`exec /usr/bin/ssh -o StrictHostKeyChecking=no -i %s "$@"`, deployKeyPath)
-	if err := ioutil.WriteFile(sshPath, []byte(sshScript), 0755); err != nil {
-		return nil, err
+	signed := repo.Storer.NewEncodedObject()
+	if err := commit.Encode(signed); err != nil {
+		return err
 	}
+	signedHash, err := repo.Storer.SetEncodedObject(signed)
+	if err != nil {
+		return err
+	}
+	return repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName(branch), signedHash))
+}
 
-	cmd := exec.Command("git", args...)
-	cmd.Dir = r.dir
-	cmd.Env = append(os.Environ(), fmt.Sprintf("GIT_SSH=%s", sshPath))
-	return cmd.CombinedOutput()
+func (r *repo) Dir() string {
+	return r.dir
+}
+
+// cloned reports whether the directory itself contains a repo, ignoring any
+// repo it might be nested in
+func (r *repo) cloned() bool {
+	_, err := os.Stat(filepath.Join(r.dir, ".git"))
+	return err == nil
 }
@@ -10,6 +10,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -32,7 +33,7 @@ var _ = Describe("Repo", func() {
 		Context("when the directory already contains a repo", func() {
 			Context("when the repo is public", func() {
 				It("updates the repo", func() {
-					repo := NewRepo("https://github.com/octocat/Hello-World", "", gitDir)
+					repo := NewRepo("https://github.com/octocat/Hello-World", Auth{}, gitDir, nil, DefaultCommitter)
 					Expect(repo.CloneOrPull()).To(Succeed())
 
 					master := runGitCommand(gitDir, "rev-parse", "HEAD")
@@ -48,7 +49,7 @@ var _ = Describe("Repo", func() {
 					repoUrl := getEnv("CLAIMER_TEST_REPO_URL")
 					deployKey := getEnv("CLAIMER_TEST_DEPLOY_KEY")
 
-					repo := NewRepo(repoUrl, deployKey, gitDir)
+					repo := NewRepo(repoUrl, Auth{DeployKey: deployKey}, gitDir, nil, DefaultCommitter)
 					Expect(repo.CloneOrPull()).To(Succeed())
 
 					master := runGitCommand(gitDir, "rev-parse", "HEAD")
@@ -63,7 +64,7 @@ var _ = Describe("Repo", func() {
 		Context("when the directory does not contain a repo", func() {
 			Context("when the repo is public", func() {
 				It("clones the repo", func() {
-					repo := NewRepo("https://github.com/octocat/Hello-World", "", gitDir)
+					repo := NewRepo("https://github.com/octocat/Hello-World", Auth{}, gitDir, nil, DefaultCommitter)
 					Expect(repo.CloneOrPull()).To(Succeed())
 					Expect(runGitCommand(gitDir, "status")).To(ContainSubstring("working tree clean"))
 				})
@@ -74,36 +75,138 @@ var _ = Describe("Repo", func() {
 					repoUrl := getEnv("CLAIMER_TEST_REPO_URL")
 					deployKey := getEnv("CLAIMER_TEST_DEPLOY_KEY")
 
-					repo := NewRepo(repoUrl, deployKey, gitDir)
+					repo := NewRepo(repoUrl, Auth{DeployKey: deployKey}, gitDir, nil, DefaultCommitter)
 					Expect(repo.CloneOrPull()).To(Succeed())
 					Expect(runGitCommand(gitDir, "status")).To(ContainSubstring("working tree clean"))
 				})
 			})
 		})
 
-		Context("when the SSH key is invalid", func() {
-			It("returns an error", func() {
-				repoUrl := getEnv("CLAIMER_TEST_REPO_URL")
+		Context("when the directory contains a clone of a local repo", func() {
+			var gitRemoteDir string
+			var gitRemoteUrl string
 
-				repo := NewRepo(repoUrl, "some-invalid-deploy-key", gitDir)
-				Expect(repo.CloneOrPull()).To(MatchError(ContainSubstring("failed to parse public key: ")))
+			BeforeEach(func() {
+				var err error
+
+				gitRemoteDir, err = ioutil.TempDir("", "claimer-test-git-remote")
+				Expect(err).NotTo(HaveOccurred())
+				gitRemoteUrl = "file://" + gitRemoteDir
+
+				runGitCommand(gitRemoteDir, "init", ".")
+				runGitCommand(gitRemoteDir, "symbolic-ref", "HEAD", "refs/heads/master")
+				runGitCommand(gitRemoteDir, "commit", "--allow-empty", "-m", "Initial commit")
+
+				Expect(NewRepo(gitRemoteUrl, Auth{}, gitDir, nil, DefaultCommitter).CloneOrPull()).To(Succeed())
+				runGitCommand(gitRemoteDir, "commit", "--allow-empty", "-m", "Second commit")
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(gitRemoteDir)
+			})
+
+			It("reuses the clone", func() {
+				touchFile(filepath.Join(gitDir, ".git", "some-marker"))
+
+				Expect(NewRepo(gitRemoteUrl, Auth{}, gitDir, nil, DefaultCommitter).CloneOrPull()).To(Succeed())
+				Expect(runGitCommand(gitDir, "log", "-1", "--format=%s")).To(Equal("Second commit"))
+				Expect(filepath.Join(gitDir, ".git", "some-marker")).To(BeAnExistingFile())
+			})
+
+			Context("when the clone is corrupt", func() {
+				It("clones the repo again", func() {
+					Expect(os.Remove(filepath.Join(gitDir, ".git", "HEAD"))).To(Succeed())
+
+					Expect(NewRepo(gitRemoteUrl, Auth{}, gitDir, nil, DefaultCommitter).CloneOrPull()).To(Succeed())
+					Expect(runGitCommand(gitDir, "log", "-1", "--format=%s")).To(Equal("Second commit"))
+				})
+			})
+
+			Context("when the clone has no origin remote", func() {
+				It("clones the repo again", func() {
+					runGitCommand(gitDir, "remote", "rename", "origin", "some-remote")
+
+					Expect(NewRepo(gitRemoteUrl, Auth{}, gitDir, nil, DefaultCommitter).CloneOrPull()).To(Succeed())
+					Expect(runGitCommand(gitDir, "remote")).To(Equal("origin"))
+					Expect(runGitCommand(gitDir, "log", "-1", "--format=%s")).To(Equal("Second commit"))
+				})
+			})
+
+			Context("when the origin remote has no URL", func() {
+				It("clones the repo again", func() {
+					runGitCommand(gitDir, "config", "--unset", "remote.origin.url")
+
+					Expect(NewRepo(gitRemoteUrl, Auth{}, gitDir, nil, DefaultCommitter).CloneOrPull()).To(Succeed())
+					Expect(runGitCommand(gitDir, "remote", "get-url", "origin")).To(Equal(gitRemoteUrl))
+				})
+			})
+
+			Context("when the remote cannot be reached", func() {
+				It("returns an error", func() {
+					Expect(os.RemoveAll(gitRemoteDir)).To(Succeed())
+
+					err := NewRepo(gitRemoteUrl, Auth{}, gitDir, nil, DefaultCommitter).CloneOrPull()
+					Expect(err).To(MatchError(ContainSubstring("failed to fetch repo: ")))
+					Expect(filepath.Join(gitDir, ".git")).To(BeADirectory())
+				})
+			})
+
+			Context("when the clone is of a different repo", func() {
+				It("returns an error", func() {
+					err := NewRepo("file:///some-other-repo", Auth{}, gitDir, nil, DefaultCommitter).CloneOrPull()
+					Expect(err).To(MatchError(fmt.Sprintf("%s is a clone of %s, not file:///some-other-repo", gitDir, gitRemoteUrl)))
+				})
+			})
+
+			Context("when a different branch is checked out", func() {
+				It("returns an error", func() {
+					runGitCommand(gitDir, "checkout", "-b", "some-branch")
+
+					err := NewRepo(gitRemoteUrl, Auth{}, gitDir, nil, DefaultCommitter).CloneOrPull()
+					Expect(err).To(MatchError(fmt.Sprintf("%s has some-branch checked out, not master", gitDir)))
+				})
 			})
 		})
 
-		Context("when pulling the repo fails", func() {
-			It("returns an error", func() {
-				repo := NewRepo("https://github.com/octocat/Hello-World", "", gitDir)
-				Expect(repo.CloneOrPull()).To(Succeed())
+		Context("when the directory is not empty and is not a repo", func() {
+			It("returns an error without touching the directory", func() {
+				touchFile(filepath.Join(gitDir, "some-file"))
 
-				runGitCommand(gitDir, "remote", "remove", "origin")
+				err := NewRepo("file:///some-repo", Auth{}, gitDir, nil, DefaultCommitter).CloneOrPull()
+				Expect(err).To(MatchError(fmt.Sprintf("refusing to clone into %s, which is not empty and is not a git repo", gitDir)))
+				Expect(filepath.Join(gitDir, "some-file")).To(BeAnExistingFile())
+			})
+		})
+
+		Context("when the directory is inside another repo", func() {
+			It("clones the repo into the directory", func() {
+				gitRemoteDir, err := ioutil.TempDir("", "claimer-test-git-remote")
+				Expect(err).NotTo(HaveOccurred())
+				defer os.RemoveAll(gitRemoteDir)
+				runGitCommand(gitRemoteDir, "init", ".")
+				runGitCommand(gitRemoteDir, "symbolic-ref", "HEAD", "refs/heads/master")
+				runGitCommand(gitRemoteDir, "commit", "--allow-empty", "-m", "Initial commit")
 
-				Expect(repo.CloneOrPull()).To(MatchError(ContainSubstring("failed to fetch repo: ")))
+				runGitCommand(gitDir, "init", ".")
+				nestedDir := filepath.Join(gitDir, "nested")
+
+				Expect(NewRepo("file://"+gitRemoteDir, Auth{}, nestedDir, nil, DefaultCommitter).CloneOrPull()).To(Succeed())
+				Expect(filepath.Join(nestedDir, ".git")).To(BeADirectory())
+			})
+		})
+
+		Context("when the SSH key is invalid", func() {
+			It("returns an error", func() {
+				repoUrl := getEnv("CLAIMER_TEST_REPO_URL")
+
+				repo := NewRepo(repoUrl, Auth{DeployKey: "some-invalid-deploy-key"}, gitDir, nil, DefaultCommitter)
+				Expect(repo.CloneOrPull()).To(MatchError(ContainSubstring("failed to parse public key: ")))
 			})
 		})
 
 		Context("when cloning the repo fails", func() {
 			It("returns an error", func() {
-				repo := NewRepo("some-invalid-url", "", gitDir)
+				repo := NewRepo("some-invalid-url", Auth{}, gitDir, nil, DefaultCommitter)
 				Expect(repo.CloneOrPull()).To(MatchError(ContainSubstring("failed to clone repo: ")))
 			})
 		})
@@ -135,27 +238,40 @@ var _ = Describe("Repo", func() {
 		It("commits and pushes all changes to the repo", func() {
 			commitMessage := "some-commit-message"
 			newFileName := "some-new-file"
-			author := "some-author"
+			author := Identity{Name: "some-author", Email: "some-author@example.com"}
 
 			touchFile(filepath.Join(gitDir, newFileName))
 
-			repo := NewRepo(gitRemoteUrl, "", gitDir)
+			repo := NewRepo(gitRemoteUrl, Auth{}, gitDir, nil, DefaultCommitter)
 			Expect(repo.CommitAndPush(commitMessage, author)).To(Succeed())
 
 			committedFiles := runGitCommand(gitDir, "log", "origin/master", "-1", "--name-only", "--format=")
 			Expect(committedFiles).To(Equal(newFileName))
 			commit := runGitCommand(gitDir, "log", "origin/master", "-1", "--format=%s")
 			Expect(commit).To(Equal(commitMessage))
-			actualAuthor := runGitCommand(gitDir, "log", "origin/master", "-1", "--format=%an")
-			Expect(actualAuthor).To(Equal(author))
+			actualAuthor := runGitCommand(gitDir, "log", "origin/master", "-1", "--format=%an <%ae>")
+			Expect(actualAuthor).To(Equal("some-author <some-author@example.com>"))
 			committer := runGitCommand(gitDir, "log", "origin/master", "-1", "--format=%cn")
 			Expect(committer).To(Equal("Claimer"))
 		})
 
+		Context("when a committer is configured", func() {
+			It("commits as that committer", func() {
+				touchFile(filepath.Join(gitDir, "some-new-file"))
+
+				committer := Identity{Name: "some-bot", Email: "some-bot@example.com"}
+				repo := NewRepo(gitRemoteUrl, Auth{}, gitDir, nil, committer)
+				Expect(repo.CommitAndPush("some-commit-message", Identity{Name: "some-author"})).To(Succeed())
+
+				actualCommitter := runGitCommand(gitDir, "log", "origin/master", "-1", "--format=%cn <%ce>")
+				Expect(actualCommitter).To(Equal("some-bot <some-bot@example.com>"))
+			})
+		})
+
 		Context("when committing fails", func() {
 			It("returns an error", func() {
-				repo := NewRepo(gitRemoteUrl, "", gitDir)
-				err := repo.CommitAndPush("some-commit-message", "some-author")
+				repo := NewRepo(gitRemoteUrl, Auth{}, gitDir, nil, DefaultCommitter)
+				err := repo.CommitAndPush("some-commit-message", Identity{Name: "some-author"})
 				Expect(err).To(MatchError(MatchRegexp("(?s:failed to commit: .*nothing to commit)")))
 			})
 		})
@@ -165,16 +281,16 @@ var _ = Describe("Repo", func() {
 				runGitCommand(gitDir, "remote", "remove", "origin")
 				touchFile(filepath.Join(gitDir, "some-new-file"))
 
-				repo := NewRepo(gitRemoteUrl, "", gitDir)
-				err := repo.CommitAndPush("some-commit-message", "some-author")
-				Expect(err).To(MatchError(MatchRegexp("(?s:failed to push: .*'origin' does not appear to be a git repository)")))
+				repo := NewRepo(gitRemoteUrl, Auth{}, gitDir, nil, DefaultCommitter)
+				err := repo.CommitAndPush("some-commit-message", Identity{Name: "some-author"})
+				Expect(err).To(MatchError("failed to push: remote not found"))
 			})
 		})
 	})
 
 	Describe("Dir", func() {
 		It("returns the git directory", func() {
-			repo := NewRepo("", "", "some-dir")
+			repo := NewRepo("", Auth{}, "some-dir", nil, DefaultCommitter)
 			Expect(repo.Dir()).To(Equal("some-dir"))
 		})
 	})
@@ -219,7 +335,7 @@ var _ = Describe("Repo", func() {
 				"-m", "some-commit-message\n\n"+body,
 			)
 
-			repo := NewRepo(gitRemoteUrl, "", gitDir)
+			repo := NewRepo(gitRemoteUrl, Auth{}, gitDir, nil, DefaultCommitter)
 			actualAuthor, actualDate, actualBody, err := repo.LatestCommit(newFileName)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(actualAuthor).To(Equal(author))
@@ -229,9 +345,190 @@ var _ = Describe("Repo", func() {
 
 		Context("when there is an error getting the log", func() {
 			It("returns an error", func() {
-				repo := NewRepo("", "", gitDir)
-				_, _, _, err := repo.LatestCommit("")
-				Expect(err).To(MatchError(ContainSubstring("failed to get commit author: ")))
+				repo := NewRepo("", Auth{}, filepath.Join(gitDir, "does-not-exist"), nil, DefaultCommitter)
+				_, _, _, err := repo.LatestCommit("some-path")
+				Expect(err).To(MatchError(ContainSubstring("failed to get log: ")))
+			})
+		})
+	})
+
+	Describe("Log", func() {
+		BeforeEach(func() {
+			runGitCommand(gitDir, "init", ".")
+		})
+
+		It("returns the commits touching the given path, newest first", func() {
+			Expect(os.MkdirAll(filepath.Join(gitDir, "some-pool", "claimed"), 0755)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(gitDir, "some-pool", "unclaimed"), 0755)).To(Succeed())
+			touchFile(filepath.Join(gitDir, "some-pool", "claimed", ".gitkeep"))
+			touchFile(filepath.Join(gitDir, "some-pool", "unclaimed", "some-lock"))
+			runGitCommand(gitDir, "add", "-A")
+			runGitCommand(
+				gitDir,
+				"commit",
+				"--author", "some-creator <>",
+				"--date", "Tue Nov 10 23:00:00 2009 +0000",
+				"-m", "Claimer creating some-pool",
+			)
+
+			touchFile(filepath.Join(gitDir, "some-other-file"))
+			runGitCommand(gitDir, "add", "-A")
+			runGitCommand(gitDir, "commit", "-m", "some unrelated commit")
+
+			runGitCommand(gitDir, "mv", filepath.Join("some-pool", "unclaimed", "some-lock"), filepath.Join("some-pool", "claimed", "some-lock"))
+			runGitCommand(
+				gitDir,
+				"commit",
+				"--author", "some-claimer <>",
+				"--date", "Wed Nov 11 23:00:00 2009 +0000",
+				"-m", "Claimer claiming some-pool\n\nsome message",
+			)
+
+			repo := NewRepo("", Auth{}, gitDir, nil, DefaultCommitter)
+			commits, err := repo.Log("some-pool")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(commits).To(HaveLen(2))
+
+			Expect(commits[0].Author).To(Equal("some-claimer"))
+			Expect(commits[0].Date).To(BeTemporally("==", time.Date(2009, 11, 11, 23, 0, 0, 0, time.UTC)))
+			Expect(commits[0].Subject).To(Equal("Claimer claiming some-pool"))
+			Expect(commits[0].Body).To(Equal("some message"))
+			Expect(commits[0].Changes).To(Equal([]Change{{
+				Status:  "R",
+				OldPath: "some-pool/unclaimed/some-lock",
+				Path:    "some-pool/claimed/some-lock",
+			}}))
+
+			Expect(commits[1].Author).To(Equal("some-creator"))
+			Expect(commits[1].Subject).To(Equal("Claimer creating some-pool"))
+			Expect(commits[1].Body).To(BeEmpty())
+			Expect(commits[1].Changes).To(Equal([]Change{
+				{Status: "A", Path: "some-pool/claimed/.gitkeep"},
+				{Status: "A", Path: "some-pool/unclaimed/some-lock"},
+			}))
+		})
+
+		Context("when the path is empty", func() {
+			It("returns every commit with all of its changes", func() {
+				touchFile(filepath.Join(gitDir, "some-file"))
+				runGitCommand(gitDir, "add", "-A")
+				runGitCommand(gitDir, "commit", "-m", "some commit")
+
+				Expect(os.MkdirAll(filepath.Join(gitDir, "some-pool", "unclaimed"), 0755)).To(Succeed())
+				touchFile(filepath.Join(gitDir, "some-pool", "unclaimed", "some-lock"))
+				runGitCommand(gitDir, "add", "-A")
+				runGitCommand(gitDir, "commit", "-m", "some other commit")
+
+				repo := NewRepo("", Auth{}, gitDir, nil, DefaultCommitter)
+				commits, err := repo.Log("")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(commits).To(HaveLen(2))
+				Expect(commits[0].Changes).To(Equal([]Change{{Status: "A", Path: "some-pool/unclaimed/some-lock"}}))
+				Expect(commits[1].Changes).To(Equal([]Change{{Status: "A", Path: "some-file"}}))
+			})
+		})
+
+		It("reports files moved into or out of the path as renames", func() {
+			Expect(os.MkdirAll(filepath.Join(gitDir, "some-pool", "claimed"), 0755)).To(Succeed())
+			touchFile(filepath.Join(gitDir, "some-pool", "claimed", "some-lock"))
+			runGitCommand(gitDir, "add", "-A")
+			runGitCommand(gitDir, "commit", "-m", "Claimer creating some-pool")
+
+			Expect(os.MkdirAll(filepath.Join(gitDir, "some-group"), 0755)).To(Succeed())
+			runGitCommand(gitDir, "mv", "some-pool", filepath.Join("some-group", "some-new-pool"))
+			runGitCommand(gitDir, "commit", "-m", "Claimer renaming some-pool to some-group/some-new-pool")
+
+			repo := NewRepo("", Auth{}, gitDir, nil, DefaultCommitter)
+			expectedChanges := []Change{{
+				Status:  "R",
+				OldPath: "some-pool/claimed/some-lock",
+				Path:    "some-group/some-new-pool/claimed/some-lock",
+			}}
+
+			commits, err := repo.Log("some-pool")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(commits).To(HaveLen(2))
+			Expect(commits[0].Changes).To(Equal(expectedChanges))
+
+			commits, err = repo.Log("some-group/some-new-pool")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(commits).To(HaveLen(1))
+			Expect(commits[0].Changes).To(Equal(expectedChanges))
+			Expect(commits[0].Hash).To(Equal(strings.TrimSpace(runGitCommand(gitDir, "rev-parse", "HEAD"))))
+		})
+
+		Context("when there is an error getting the log", func() {
+			It("returns an error", func() {
+				repo := NewRepo("", Auth{}, gitDir, nil, DefaultCommitter)
+				_, err := repo.Log("")
+				Expect(err).To(MatchError(ContainSubstring("failed to get log: ")))
+			})
+		})
+	})
+
+	Describe("LatestCommits", func() {
+		BeforeEach(func() {
+			runGitCommand(gitDir, "init", ".")
+
+			Expect(os.MkdirAll(filepath.Join(gitDir, "pool-1", "claimed"), 0755)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(gitDir, "pool-2", "claimed"), 0755)).To(Succeed())
+			touchFile(filepath.Join(gitDir, "pool-1", "claimed", "lock-1"))
+			touchFile(filepath.Join(gitDir, "pool-2", "claimed", "lock-2"))
+			runGitCommand(gitDir, "add", "-A")
+			runGitCommand(
+				gitDir,
+				"commit",
+				"--author", "some-author <>",
+				"--date", "Tue Nov 10 23:00:00 2009 -0500",
+				"-m", "Claimer claiming pool-1\n\nsome message",
+			)
+
+			touchFile(filepath.Join(gitDir, "pool-2", "claimed", "lock-3"))
+			runGitCommand(gitDir, "add", "-A")
+			runGitCommand(gitDir, "commit", "--author", "some-other-author <>", "-m", "Claimer claiming pool-2")
+
+			touchFile(filepath.Join(gitDir, "some-other-file"))
+			runGitCommand(gitDir, "add", "-A")
+			runGitCommand(gitDir, "commit", "-m", "some unrelated commit")
+		})
+
+		It("returns the newest commit touching each path", func() {
+			repo := NewRepo("", Auth{}, gitDir, nil, DefaultCommitter)
+			commits, err := repo.LatestCommits([]string{"pool-1/claimed", "pool-2/claimed", "pool-3/claimed"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(commits).To(HaveLen(2))
+
+			Expect(commits["pool-1/claimed"].Author).To(Equal("some-author"))
+			Expect(commits["pool-1/claimed"].Date.Format("Mon Jan 2 15:04:05 2006 -0700")).To(Equal("Tue Nov 10 23:00:00 2009 -0500"))
+			Expect(commits["pool-1/claimed"].Body).To(Equal("some message"))
+			Expect(commits["pool-2/claimed"].Author).To(Equal("some-other-author"))
+		})
+
+		Context("when there is an error getting the log", func() {
+			It("returns an error", func() {
+				repo := NewRepo("", Auth{}, filepath.Join(gitDir, "does-not-exist"), nil, DefaultCommitter)
+				_, err := repo.LatestCommits([]string{"pool-1/claimed"})
+				Expect(err).To(MatchError(ContainSubstring("failed to get log: ")))
+			})
+		})
+	})
+
+	Describe("Head", func() {
+		It("returns the SHA of the checked out commit", func() {
+			runGitCommand(gitDir, "init", ".")
+			runGitCommand(gitDir, "commit", "--allow-empty", "-m", "some commit")
+
+			repo := NewRepo("", Auth{}, gitDir, nil, DefaultCommitter)
+			Expect(repo.Head()).To(Equal(strings.TrimSpace(runGitCommand(gitDir, "rev-parse", "HEAD"))))
+		})
+
+		Context("when there are no commits", func() {
+			It("returns an error", func() {
+				runGitCommand(gitDir, "init", ".")
+
+				repo := NewRepo("", Auth{}, gitDir, nil, DefaultCommitter)
+				_, err := repo.Head()
+				Expect(err).To(MatchError(ContainSubstring("failed to get head: ")))
 			})
 		})
 	})
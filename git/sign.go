@@ -0,0 +1,133 @@
+package git
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/ssh"
+)
+
+// Signer signs the commits created by CommitAndPush. The signature is stored
+// in the gpgsig header of the commit, where git looks for both GPG and SSH
+// signatures.
+type Signer interface {
+	Sign(payload []byte) (string, error)
+}
+
+type gpgSigner struct {
+	entity *openpgp.Entity
+}
+
+// NewGPGSigner returns a signer for an ASCII-armored GPG private key. The
+// passphrase is only needed if the key is encrypted.
+func NewGPGSigner(armoredKey, passphrase string) (Signer, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(armoredKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse signing key")
+	}
+	var entity *openpgp.Entity
+	for _, e := range entities {
+		if e.PrivateKey != nil {
+			entity = e
+			break
+		}
+	}
+	if entity == nil {
+		return nil, errors.New("failed to parse signing key: no private key found")
+	}
+
+	if entity.PrivateKey.Encrypted {
+		if err := entity.PrivateKey.Decrypt([]byte(passphrase)); err != nil {
+			return nil, errors.Wrap(err, "failed to decrypt signing key")
+		}
+	}
+	for _, subkey := range entity.Subkeys {
+		if subkey.PrivateKey != nil && subkey.PrivateKey.Encrypted {
+			if err := subkey.PrivateKey.Decrypt([]byte(passphrase)); err != nil {
+				return nil, errors.Wrap(err, "failed to decrypt signing key")
+			}
+		}
+	}
+	return &gpgSigner{entity: entity}, nil
+}
+
+func (s *gpgSigner) Sign(payload []byte) (string, error) {
+	var signature bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&signature, s.entity, bytes.NewReader(payload), nil); err != nil {
+		return "", err
+	}
+	return signature.String(), nil
+}
+
+const (
+	sshSigMagic     = "SSHSIG"
+	sshSigNamespace = "git"
+	sshSigHash      = "sha512"
+)
+
+type sshSigner struct {
+	signer ssh.Signer
+}
+
+// NewSSHSigner returns a signer for an SSH private key, as used by git's
+// gpg.format=ssh. The passphrase is only needed if the key is encrypted.
+func NewSSHSigner(privateKey, passphrase string) (Signer, error) {
+	var signer ssh.Signer
+	var err error
+	if passphrase == "" {
+		signer, err = ssh.ParsePrivateKey([]byte(privateKey))
+	} else {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase([]byte(privateKey), []byte(passphrase))
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse signing key")
+	}
+	return &sshSigner{signer: signer}, nil
+}
+
+// Sign creates an armored signature in the SSHSIG format described in
+// https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig
+func (s *sshSigner) Sign(payload []byte) (string, error) {
+	hash := sha512.Sum512(payload)
+	signedData := append([]byte(sshSigMagic), ssh.Marshal(struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          string
+	}{sshSigNamespace, "", sshSigHash, string(hash[:])})...)
+
+	var signature *ssh.Signature
+	var err error
+	if algorithmSigner, ok := s.signer.(ssh.AlgorithmSigner); ok && s.signer.PublicKey().Type() == ssh.KeyAlgoRSA {
+		signature, err = algorithmSigner.SignWithAlgorithm(rand.Reader, signedData, ssh.SigAlgoRSASHA2512)
+	} else {
+		signature, err = s.signer.Sign(rand.Reader, signedData)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	blob := append([]byte(sshSigMagic), ssh.Marshal(struct {
+		Version       uint32
+		PublicKey     string
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     string
+	}{1, string(s.signer.PublicKey().Marshal()), sshSigNamespace, "", sshSigHash, string(ssh.Marshal(signature))})...)
+
+	encoded := base64.StdEncoding.EncodeToString(blob)
+	var armored strings.Builder
+	armored.WriteString("-----BEGIN SSH SIGNATURE-----\n")
+	for len(encoded) > 70 {
+		armored.WriteString(encoded[:70] + "\n")
+		encoded = encoded[70:]
+	}
+	armored.WriteString(encoded + "\n-----END SSH SIGNATURE-----\n")
+	return armored.String(), nil
+}
@@ -0,0 +1,173 @@
+package git_test
+
+import (
+	. "github.com/mdelillo/claimer/git"
+
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	git "gopkg.in/src-d/go-git.v4"
+)
+
+var _ = Describe("Signing", func() {
+	var (
+		keyDir       string
+		gitDir       string
+		gitRemoteDir string
+	)
+
+	BeforeEach(func() {
+		var err error
+		keyDir, err = ioutil.TempDir("", "claimer-test-keys")
+		Expect(err).NotTo(HaveOccurred())
+		gitDir, err = ioutil.TempDir("", "claimer-test-git-dir")
+		Expect(err).NotTo(HaveOccurred())
+		gitRemoteDir, err = ioutil.TempDir("", "claimer-test-git-remote")
+		Expect(err).NotTo(HaveOccurred())
+
+		runGitCommand(gitRemoteDir, "init", ".")
+		runGitCommand(gitRemoteDir, "config", "receive.denyCurrentBranch", "updateInstead")
+		runGitCommand(gitRemoteDir, "symbolic-ref", "HEAD", "refs/heads/master")
+		runGitCommand(gitRemoteDir, "commit", "--allow-empty", "-m", "Initial commit")
+
+		_, err = git.PlainClone(gitDir, false, &git.CloneOptions{URL: "file://" + gitRemoteDir})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(keyDir)
+		os.RemoveAll(gitDir)
+		os.RemoveAll(gitRemoteDir)
+	})
+
+	commit := func(signer Signer) {
+		touchFile(filepath.Join(gitDir, "some-file"))
+		repo := NewRepo("file://"+gitRemoteDir, Auth{}, gitDir, signer, DefaultCommitter)
+		ExpectWithOffset(1, repo.CommitAndPush("some-message", Identity{Name: "some-author"})).To(Succeed())
+	}
+
+	Describe("NewSSHSigner", func() {
+		for _, keyType := range []string{"ed25519", "rsa"} {
+			keyType := keyType
+
+			It("signs commits with a "+keyType+" key which git can verify", func() {
+				keyPath := filepath.Join(keyDir, "some-key")
+				runCommand(keyDir, "ssh-keygen", "-q", "-t", keyType, "-N", "some-passphrase", "-C", "", "-f", keyPath)
+				privateKey, err := ioutil.ReadFile(keyPath)
+				Expect(err).NotTo(HaveOccurred())
+				publicKey, err := ioutil.ReadFile(keyPath + ".pub")
+				Expect(err).NotTo(HaveOccurred())
+
+				signer, err := NewSSHSigner(string(privateKey), "some-passphrase")
+				Expect(err).NotTo(HaveOccurred())
+				commit(signer)
+
+				allowedSigners := filepath.Join(keyDir, "allowed_signers")
+				Expect(ioutil.WriteFile(allowedSigners, []byte(`* namespaces="git" `+string(publicKey)), 0644)).To(Succeed())
+				runGitCommand(
+					gitRemoteDir,
+					"-c", "gpg.format=ssh",
+					"-c", "gpg.ssh.allowedSignersFile="+allowedSigners,
+					"verify-commit", "HEAD",
+				)
+			})
+		}
+
+		Context("when the key is invalid", func() {
+			It("returns an error", func() {
+				_, err := NewSSHSigner("some-invalid-key", "")
+				Expect(err).To(MatchError(ContainSubstring("failed to parse signing key: ")))
+			})
+		})
+
+		Context("when the passphrase is wrong", func() {
+			It("returns an error", func() {
+				keyPath := filepath.Join(keyDir, "some-key")
+				runCommand(keyDir, "ssh-keygen", "-q", "-t", "ed25519", "-N", "some-passphrase", "-f", keyPath)
+				privateKey, err := ioutil.ReadFile(keyPath)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = NewSSHSigner(string(privateKey), "some-other-passphrase")
+				Expect(err).To(MatchError(ContainSubstring("failed to parse signing key: ")))
+			})
+		})
+	})
+
+	Describe("NewGPGSigner", func() {
+		var armoredKey string
+
+		gpg := func(args ...string) string {
+			cmd := exec.Command("gpg", append([]string{"--batch", "--pinentry-mode", "loopback", "--passphrase", "some-passphrase"}, args...)...)
+			cmd.Env = append(os.Environ(), "GNUPGHOME="+keyDir)
+			output, err := cmd.Output()
+			ExpectWithOffset(1, err).NotTo(HaveOccurred())
+			return string(output)
+		}
+
+		BeforeEach(func() {
+			Expect(os.Chmod(keyDir, 0700)).To(Succeed())
+			gpg("--quick-gen-key", "Claimer <claimer@example.com>", "rsa2048", "sign", "never")
+			armoredKey = gpg("--armor", "--export-secret-keys")
+		})
+
+		AfterEach(func() {
+			exec.Command("gpgconf", "--homedir", keyDir, "--kill", "all").Run()
+		})
+
+		It("signs commits which git can verify", func() {
+			signer, err := NewGPGSigner(armoredKey, "some-passphrase")
+			Expect(err).NotTo(HaveOccurred())
+			commit(signer)
+
+			cmd := exec.Command("git", "verify-commit", "HEAD")
+			cmd.Dir = gitRemoteDir
+			cmd.Env = append(os.Environ(), "GNUPGHOME="+keyDir)
+			output, err := cmd.CombinedOutput()
+			Expect(err).NotTo(HaveOccurred(), string(output))
+			Expect(string(output)).To(ContainSubstring(`Good signature from "Claimer <claimer@example.com>"`))
+		})
+
+		Context("when the key is invalid", func() {
+			It("returns an error", func() {
+				_, err := NewGPGSigner("some-invalid-key", "")
+				Expect(err).To(MatchError(ContainSubstring("failed to parse signing key: ")))
+			})
+		})
+
+		Context("when the key has no private key", func() {
+			It("returns an error", func() {
+				publicKey := gpg("--armor", "--export")
+				_, err := NewGPGSigner(publicKey, "")
+				Expect(err).To(MatchError("failed to parse signing key: no private key found"))
+			})
+		})
+
+		Context("when the passphrase is wrong", func() {
+			It("returns an error", func() {
+				_, err := NewGPGSigner(armoredKey, "some-other-passphrase")
+				Expect(err).To(MatchError(ContainSubstring("failed to decrypt signing key: ")))
+			})
+		})
+	})
+
+	Context("when signing fails", func() {
+		It("returns an error", func() {
+			touchFile(filepath.Join(gitDir, "some-file"))
+			repo := NewRepo("file://"+gitRemoteDir, Auth{}, gitDir, failingSigner{}, DefaultCommitter)
+			Expect(repo.CommitAndPush("some-message", Identity{Name: "some-author"})).To(MatchError("failed to sign commit: some-error"))
+			Expect(strings.TrimSpace(runGitCommand(gitRemoteDir, "log", "-1", "--format=%s"))).To(Equal("Initial commit"))
+		})
+	})
+})
+
+type failingSigner struct{}
+
+func (failingSigner) Sign([]byte) (string, error) {
+	return "", errors.New("some-error")
+}
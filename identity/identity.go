@@ -0,0 +1,93 @@
+package identity
+
+import (
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+//go:generate counterfeiter . slackClient
+type slackClient interface {
+	UserEmail(username string) (string, error)
+}
+
+// RetryInterval is how long a failed lookup of an email is remembered before
+// Slack is asked again, so that users without an email, such as bots, do not
+// cost a lookup on every commit
+var RetryInterval = 10 * time.Minute
+
+type identity struct {
+	mapping     map[string]string
+	slackClient slackClient
+	logger      *logrus.Logger
+
+	// mutex guards emails, which caches the emails found in Slack profiles,
+	// and failures, which holds when lookups last failed
+	mutex    sync.Mutex
+	emails   map[string]string
+	failures map[string]time.Time
+}
+
+// LoadMapping reads a yaml file mapping chat usernames to the email
+// addresses which should be used as their git author identity.
+func LoadMapping(path string) (map[string]string, error) {
+	var mapping map[string]string
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read authors file")
+	}
+	if err := yaml.Unmarshal(contents, &mapping); err != nil {
+		return nil, errors.Wrap(err, "failed to parse authors file")
+	}
+	return mapping, nil
+}
+
+// New returns a resolver of author emails which prefers the mapping and falls
+// back to the email in the Slack profile of the user. With a nil slackClient
+// only the mapping is used.
+func New(mapping map[string]string, slackClient slackClient, logger *logrus.Logger) *identity {
+	return &identity{
+		mapping:     mapping,
+		slackClient: slackClient,
+		logger:      logger,
+		emails:      map[string]string{},
+		failures:    map[string]time.Time{},
+	}
+}
+
+// Email returns the email address of a user, or an empty string if it is not
+// known. Failed lookups are logged rather than returned so that a missing
+// email never prevents a lock from being claimed, and are not retried until
+// RetryInterval has passed.
+func (i *identity) Email(username string) string {
+	if email, ok := i.mapping[username]; ok {
+		return email
+	}
+	if i.slackClient == nil {
+		return ""
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	if email, ok := i.emails[username]; ok {
+		return email
+	}
+	if failed, ok := i.failures[username]; ok && time.Since(failed) < RetryInterval {
+		return ""
+	}
+
+	email, err := i.slackClient.UserEmail(username)
+	if err != nil {
+		i.logger.WithField("username", username).Warnf("failed to look up email: %s", err)
+		i.failures[username] = time.Now()
+		return ""
+	}
+	delete(i.failures, username)
+	i.emails[username] = email
+	return email
+}
@@ -0,0 +1,128 @@
+package identity_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/mdelillo/claimer/identity"
+	"github.com/mdelillo/claimer/identity/identityfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("Identity", func() {
+	var (
+		slackClient *identityfakes.FakeSlackClient
+		logger      *logrus.Logger
+		logHook     *test.Hook
+	)
+
+	BeforeEach(func() {
+		slackClient = new(identityfakes.FakeSlackClient)
+		logger, logHook = test.NewNullLogger()
+	})
+
+	Describe("Email", func() {
+		It("returns the email from the mapping", func() {
+			identity := New(map[string]string{"some-user": "some-email"}, slackClient, logger)
+
+			Expect(identity.Email("some-user")).To(Equal("some-email"))
+			Expect(slackClient.UserEmailCallCount()).To(Equal(0))
+		})
+
+		It("falls back to the email from slack and caches it", func() {
+			slackClient.UserEmailReturns("some-slack-email", nil)
+			identity := New(map[string]string{"some-other-user": "some-email"}, slackClient, logger)
+
+			Expect(identity.Email("some-user")).To(Equal("some-slack-email"))
+			Expect(identity.Email("some-user")).To(Equal("some-slack-email"))
+
+			Expect(slackClient.UserEmailCallCount()).To(Equal(1))
+			Expect(slackClient.UserEmailArgsForCall(0)).To(Equal("some-user"))
+		})
+
+		Context("when there is no slack client", func() {
+			It("only uses the mapping", func() {
+				identity := New(map[string]string{"some-user": "some-email"}, nil, logger)
+
+				Expect(identity.Email("some-user")).To(Equal("some-email"))
+				Expect(identity.Email("some-other-user")).To(BeEmpty())
+				Expect(logHook.Entries).To(BeEmpty())
+			})
+		})
+
+		Context("when the email cannot be found in slack", func() {
+			It("logs a warning and returns an empty email", func() {
+				slackClient.UserEmailReturns("", errors.New("some-error"))
+				identity := New(nil, slackClient, logger)
+
+				Expect(identity.Email("some-user")).To(BeEmpty())
+				Expect(logHook.LastEntry().Level).To(Equal(logrus.WarnLevel))
+				Expect(logHook.LastEntry().Message).To(Equal("failed to look up email: some-error"))
+			})
+
+			It("does not ask slack again until the retry interval has passed", func() {
+				slackClient.UserEmailReturns("", errors.New("some-error"))
+				identity := New(nil, slackClient, logger)
+
+				Expect(identity.Email("some-user")).To(BeEmpty())
+				Expect(identity.Email("some-user")).To(BeEmpty())
+				Expect(slackClient.UserEmailCallCount()).To(Equal(1))
+
+				retryInterval := RetryInterval
+				RetryInterval = 0
+				defer func() { RetryInterval = retryInterval }()
+
+				slackClient.UserEmailReturns("some-slack-email", nil)
+				Expect(identity.Email("some-user")).To(Equal("some-slack-email"))
+				Expect(identity.Email("some-user")).To(Equal("some-slack-email"))
+				Expect(slackClient.UserEmailCallCount()).To(Equal(2))
+			})
+		})
+	})
+
+	Describe("LoadMapping", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "claimer-identity")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("reads usernames and emails from a yaml file", func() {
+			path := filepath.Join(dir, "authors.yml")
+			Expect(ioutil.WriteFile(path, []byte("some-user: some-email\nsome-other-user: some-other-email\n"), 0644)).To(Succeed())
+
+			Expect(LoadMapping(path)).To(Equal(map[string]string{
+				"some-user":       "some-email",
+				"some-other-user": "some-other-email",
+			}))
+		})
+
+		Context("when the file does not exist", func() {
+			It("returns an error", func() {
+				_, err := LoadMapping(filepath.Join(dir, "missing.yml"))
+				Expect(err).To(MatchError(ContainSubstring("failed to read authors file: ")))
+			})
+		})
+
+		Context("when the file is not valid yaml", func() {
+			It("returns an error", func() {
+				path := filepath.Join(dir, "authors.yml")
+				Expect(ioutil.WriteFile(path, []byte("some-invalid-yaml"), 0644)).To(Succeed())
+
+				_, err := LoadMapping(path)
+				Expect(err).To(MatchError(ContainSubstring("failed to parse authors file: ")))
+			})
+		})
+	})
+})
@@ -0,0 +1,95 @@
+// This file was generated by counterfeiter
+package identityfakes
+
+import (
+	"sync"
+)
+
+type FakeSlackClient struct {
+	UserEmailStub        func(username string) (string, error)
+	userEmailMutex       sync.RWMutex
+	userEmailArgsForCall []struct {
+		username string
+	}
+	userEmailReturns struct {
+		result1 string
+		result2 error
+	}
+	userEmailReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSlackClient) UserEmail(username string) (string, error) {
+	fake.userEmailMutex.Lock()
+	ret, specificReturn := fake.userEmailReturnsOnCall[len(fake.userEmailArgsForCall)]
+	fake.userEmailArgsForCall = append(fake.userEmailArgsForCall, struct {
+		username string
+	}{username})
+	fake.recordInvocation("UserEmail", []interface{}{username})
+	fake.userEmailMutex.Unlock()
+	if fake.UserEmailStub != nil {
+		return fake.UserEmailStub(username)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.userEmailReturns.result1, fake.userEmailReturns.result2
+}
+
+func (fake *FakeSlackClient) UserEmailCallCount() int {
+	fake.userEmailMutex.RLock()
+	defer fake.userEmailMutex.RUnlock()
+	return len(fake.userEmailArgsForCall)
+}
+
+func (fake *FakeSlackClient) UserEmailArgsForCall(i int) string {
+	fake.userEmailMutex.RLock()
+	defer fake.userEmailMutex.RUnlock()
+	return fake.userEmailArgsForCall[i].username
+}
+
+func (fake *FakeSlackClient) UserEmailReturns(result1 string, result2 error) {
+	fake.UserEmailStub = nil
+	fake.userEmailReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSlackClient) UserEmailReturnsOnCall(i int, result1 string, result2 error) {
+	fake.UserEmailStub = nil
+	if fake.userEmailReturnsOnCall == nil {
+		fake.userEmailReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.userEmailReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSlackClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.userEmailMutex.RLock()
+	defer fake.userEmailMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeSlackClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
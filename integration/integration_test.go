@@ -4,101 +4,101 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
-	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/mdelillo/claimer/slack/slacktest"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
 	"github.com/onsi/gomega/gexec"
 	"gopkg.in/src-d/go-git.v4"
-	"gopkg.in/src-d/go-git.v4/plumbing/transport/ssh"
 )
 
-const (
-	slackRateLimitDelay  = 200 * time.Millisecond
-	slackRequestAttempts = 5
+var (
+	bot          = slacktest.User{Id: "UCLAIMER", Name: "claimer", Token: "some-bot-token"}
+	user         = slacktest.User{Id: "UTESTER", Name: "some-user", Email: "some-user@example.com", Token: "some-user-token"}
+	otherUser    = slacktest.User{Id: "UOTHER", Name: "some-other-user", Token: "some-other-user-token"}
+	channelId    = "CTESTCLAIMER"
+	otherChannel = "COTHERCHANNEL"
 )
 
 var _ = Describe("Claimer", func() {
 	var (
-		apiToken       string
-		channelId      string
-		repoUrl        string
-		deployKey      string
-		botId          string
-		userApiToken   string
-		username       string
-		userId         string
-		otherChannelId string
-		runCommand     func(string) string
-		startClaimer   func(string)
-		gitDir         string
+		claimer      string
+		slackServer  *slacktest.Server
+		repoDir      string
+		repoUrl      string
+		gitDir       string
+		runCommand   func(string) string
+		runCli       func(int, ...string) string
+		startClaimer func(...string)
 	)
 
 	BeforeSuite(func() {
-		claimer, err := gexec.Build(filepath.Join("github.com", "mdelillo", "claimer"))
+		var err error
+		claimer, err = gexec.Build(filepath.Join("github.com", "mdelillo", "claimer"))
 		Expect(err).NotTo(HaveOccurred())
 
-		apiToken = getEnv("CLAIMER_TEST_API_TOKEN")
-		channelId = getEnv("CLAIMER_TEST_CHANNEL_ID")
-		repoUrl = getEnv("CLAIMER_TEST_REPO_URL")
-		deployKey = getEnv("CLAIMER_TEST_DEPLOY_KEY")
-		botId = getEnv("CLAIMER_TEST_BOT_ID")
-		userApiToken = getEnv("CLAIMER_TEST_USER_API_TOKEN")
-		username = getEnv("CLAIMER_TEST_USERNAME")
-		userId = getEnv("CLAIMER_TEST_USER_ID")
-		otherChannelId = getEnv("CLAIMER_TEST_OTHER_CHANNEL_ID")
-
 		runCommand = func(command string) string {
-			message := fmt.Sprintf("<@%s> %s", botId, command)
-			postSlackMessage(message, channelId, userApiToken)
-			EventuallyWithOffset(1, func() string { return latestSlackMessage(channelId, apiToken) }, "20s").
+			message := fmt.Sprintf("<@%s> %s", bot.Id, command)
+			slackServer.PostMessage(user.Id, channelId, message)
+			EventuallyWithOffset(1, func() string { return slackServer.LatestMessage(channelId) }, "20s").
 				ShouldNot(Equal(message), fmt.Sprintf(`Did not get response from command "%s"`, command))
-			return latestSlackMessage(channelId, apiToken)
+			return slackServer.LatestMessage(channelId)
+		}
+		runCli = func(exitCode int, args ...string) string {
+			// the Slack URL is only passed so that any call to Slack, which
+			// commands run from the command line must not make, is seen
+			cmd := exec.Command(claimer, append([]string{"-slackUrl", slackServer.URL, "-repoUrl", repoUrl, "-user", user.Name}, args...)...)
+			session, err := gexec.Start(cmd, GinkgoWriter, GinkgoWriter)
+			ExpectWithOffset(1, err).NotTo(HaveOccurred())
+			EventuallyWithOffset(1, session, "20s").Should(gexec.Exit(exitCode))
+			return strings.TrimSpace(string(session.Out.Contents()))
 		}
-		startClaimer = func(translationFile string) {
+		startClaimer = func(extraArgs ...string) {
 			args := []string{
-				"-apiToken", apiToken,
+				"-slackUrl", slackServer.URL,
+				"-apiToken", bot.Token,
 				"-channelId", channelId,
 				"-repoUrl", repoUrl,
-				"-deployKey", deployKey,
 			}
-			if translationFile != "" {
-				args = append(args, "-translationFile", translationFile)
-			}
-			cmd := exec.Command(claimer, args...)
+			cmd := exec.Command(claimer, append(args, extraArgs...)...)
 			session, err := gexec.Start(cmd, GinkgoWriter, GinkgoWriter)
 			ExpectWithOffset(1, err).NotTo(HaveOccurred())
 			Eventually(session, "20s").Should(gbytes.Say("Listening for messages"))
+			Eventually(slackServer.Connections).Should(Equal(1))
 		}
 	})
 
 	BeforeEach(func() {
+		slackServer = slacktest.NewServer()
+		slackServer.AddUser(bot)
+		slackServer.AddUser(user)
+		slackServer.AddUser(otherUser)
+
 		var err error
-		gitDir, err = ioutil.TempDir("", "claimer-integration-tests")
+		repoDir, err = ioutil.TempDir("", "claimer-integration-tests-repo")
 		Expect(err).NotTo(HaveOccurred())
+		createPoolRepo(repoDir)
+		repoUrl = "file://" + repoDir
 
-		key, err := ssh.NewPublicKeys("git", []byte(deployKey), "")
+		gitDir, err = ioutil.TempDir("", "claimer-integration-tests")
 		Expect(err).NotTo(HaveOccurred())
-		_, err = git.PlainClone(gitDir, false, &git.CloneOptions{
-			URL:  repoUrl,
-			Auth: key,
-		})
+		_, err = git.PlainClone(gitDir, false, &git.CloneOptions{URL: repoUrl})
 		Expect(err).NotTo(HaveOccurred())
-
-		resetClaimerTestPool(gitDir, deployKey)
 	})
 
 	AfterEach(func() {
 		gexec.KillAndWait()
-		resetClaimerTestPool(gitDir, deployKey)
+		slackServer.Close()
 		Expect(os.RemoveAll(gitDir)).To(Succeed())
+		Expect(os.RemoveAll(repoDir)).To(Succeed())
 	})
 
 	AfterSuite(func() {
@@ -106,15 +106,17 @@ var _ = Describe("Claimer", func() {
 	})
 
 	It("claims, releases, and shows status of locks", func() {
-		startClaimer("")
+		startClaimer()
 
 		Expect(runCommand("help")).To(ContainSubstring("Available commands:"))
 
 		Expect(runCommand("status")).To(Equal("*Claimed by you:* \n*Claimed by others:* pool-3\n*Unclaimed:* pool-1"))
 
 		Expect(runCommand("claim pool-1")).To(Equal("Claimed pool-1"))
-		updateGitRepo(gitDir, deployKey)
+		updateGitRepo(gitDir)
 		Expect(filepath.Join(gitDir, "pool-1", "claimed", "lock-a")).To(BeAnExistingFile())
+		Expect(runGitCommand(gitDir, "log", "-1", "--format=%an <%ae>")).To(Equal("some-user <some-user@example.com>\n"))
+		Expect(runGitCommand(gitDir, "log", "-1", "--format=%cn")).To(Equal("Claimer\n"))
 		Expect(filepath.Join(gitDir, "pool-1", "unclaimed", "lock-a")).NotTo(BeAnExistingFile())
 
 		status := runCommand("status")
@@ -124,7 +126,7 @@ var _ = Describe("Claimer", func() {
 		Expect(runCommand("claim pool-1")).To(Equal("pool-1 is already claimed"))
 
 		Expect(runCommand("release pool-1")).To(Equal("Released pool-1"))
-		updateGitRepo(gitDir, deployKey)
+		updateGitRepo(gitDir)
 		Expect(filepath.Join(gitDir, "pool-1", "unclaimed", "lock-a")).To(BeAnExistingFile())
 		Expect(filepath.Join(gitDir, "pool-1", "claimed", "lock-a")).NotTo(BeAnExistingFile())
 
@@ -146,7 +148,7 @@ var _ = Describe("Claimer", func() {
 	})
 
 	It("shows the owner of a lock", func() {
-		startClaimer("")
+		startClaimer()
 
 		Expect(runCommand("owner pool-1")).To(Equal("pool-1 is not claimed"))
 
@@ -154,7 +156,7 @@ var _ = Describe("Claimer", func() {
 		Expect(runCommand("claim pool-1")).To(Equal("Claimed pool-1"))
 
 		owner := runCommand("owner pool-1")
-		ownerPrefix := fmt.Sprintf("pool-1 was claimed by %s on ", username)
+		ownerPrefix := fmt.Sprintf("pool-1 was claimed by %s on ", user.Name)
 		Expect(owner).To(HavePrefix(ownerPrefix))
 
 		date := strings.TrimPrefix(owner, ownerPrefix)
@@ -171,22 +173,54 @@ var _ = Describe("Claimer", func() {
 		Expect(runCommand("owner")).To(Equal("must specify pool"))
 	})
 
+	It("extends and annotates claims", func() {
+		startClaimer()
+
+		Expect(runCommand("claim pool-1 some message")).To(Equal("Claimed pool-1"))
+
+		Expect(runCommand("extend pool-1 2h")).To(HavePrefix("Extended pool-1 until "))
+		Expect(runCommand("owner pool-1")).To(MatchRegexp(`, expires .* \(some message\)$`))
+
+		Expect(runCommand("note pool-1 some other message")).To(Equal("Updated message on pool-1"))
+		Expect(runCommand("owner pool-1")).To(HaveSuffix(" (some other message)"))
+
+		Expect(runCommand("release pool-1")).To(Equal("Released pool-1"))
+		Expect(runCommand("extend pool-1 2h")).To(Equal("pool-1 is not claimed"))
+		Expect(runCommand("note pool-1 some message")).To(Equal("pool-1 is not claimed"))
+	})
+
+	It("shows the history of a pool", func() {
+		startClaimer()
+
+		Expect(runCommand("claim pool-1 some message")).To(Equal("Claimed pool-1"))
+		Expect(runCommand("release pool-1")).To(Equal("Released pool-1"))
+		Expect(runCommand("claim pool-1")).To(Equal("Claimed pool-1"))
+
+		history := runCommand("history pool-1")
+		Expect(history).To(HavePrefix("Recent claims of pool-1:\n"))
+		Expect(history).To(MatchRegexp(fmt.Sprintf(`\n%s has claimed it since .*\n%s claimed it on .* for .* \(some message\)`, user.Name, user.Name)))
+
+		Expect(runCommand("history pool-1 1")).NotTo(ContainSubstring("some message"))
+
+		Expect(runCommand("history non-existent-pool")).To(Equal("non-existent-pool does not exist"))
+	})
+
 	It("notifies users who have claimed locks", func() {
-		startClaimer("")
+		startClaimer()
 
 		Expect(runCommand("claim pool-1")).To(Equal("Claimed pool-1"))
 
 		notification := runCommand("notify")
 		Expect(notification).To(ContainSubstring("Currently claimed locks, please release if not in use:\n"))
-		Expect(notification).To(ContainSubstring(fmt.Sprintf("<@%s>: pool-1", userId)))
+		Expect(notification).To(ContainSubstring(fmt.Sprintf("<@%s>: pool-1", user.Name)))
 	})
 
 	It("creates and destroys locks", func() {
-		startClaimer("")
+		startClaimer()
 
 		Expect(runCommand("create new-pool")).To(Equal("Created new-pool"))
 
-		updateGitRepo(gitDir, deployKey)
+		updateGitRepo(gitDir)
 		Expect(filepath.Join(gitDir, "new-pool", "unclaimed", "new-pool")).To(BeAnExistingFile())
 
 		Expect(runCommand("status")).To(MatchRegexp(`\*Unclaimed:\*.*new-pool`))
@@ -195,7 +229,7 @@ var _ = Describe("Claimer", func() {
 
 		Expect(runCommand("destroy new-pool")).To(Equal("Destroyed new-pool"))
 
-		updateGitRepo(gitDir, deployKey)
+		updateGitRepo(gitDir)
 		Expect(filepath.Join(gitDir, "new-pool")).NotTo(BeADirectory())
 
 		Expect(runCommand("destroy new-pool")).To(Equal("new-pool does not exist"))
@@ -207,13 +241,250 @@ var _ = Describe("Claimer", func() {
 		Expect(runCommand("destroy")).To(Equal("must specify pool to destroy"))
 	})
 
+	It("describes pools", func() {
+		startClaimer()
+
+		Expect(runCommand("create new-pool --description The staging env --tag gcp")).To(Equal("Created new-pool"))
+
+		updateGitRepo(gitDir)
+		Expect(ioutil.ReadFile(filepath.Join(gitDir, "new-pool", "pool.yml"))).To(MatchYAML("description: The staging env\ntags: [gcp]"))
+
+		Expect(runCommand("status")).To(MatchRegexp(`\*Unclaimed:\*.*new-pool \(The staging env\)`))
+		Expect(runCommand("owner new-pool")).To(Equal("new-pool is not claimed\n*Description:* The staging env"))
+	})
+
+	It("claims and releases several pools at once", func() {
+		startClaimer()
+
+		Expect(runCommand("create new-pool")).To(Equal("Created new-pool"))
+
+		Expect(runCommand("claim pool-1, pool-3, new-pool")).To(Equal("pool-3 is already claimed"))
+		Expect(runCommand("claim pool-1, new-pool some message")).To(Equal("Claimed pool-1, new-pool"))
+
+		updateGitRepo(gitDir)
+		Expect(filepath.Join(gitDir, "pool-1", "claimed", "lock-a")).To(BeAnExistingFile())
+		Expect(filepath.Join(gitDir, "new-pool", "claimed", "new-pool")).To(BeAnExistingFile())
+		Expect(runGitCommand(gitDir, "log", "-1", "--format=%s")).To(Equal("Claimer claiming pool-1, new-pool\n"))
+
+		Expect(runCommand("release pool-1 new-pool")).To(Equal("Released pool-1, new-pool"))
+		Expect(runCommand("status")).To(Equal("*Claimed by you:* \n*Claimed by others:* pool-3\n*Unclaimed:* new-pool, pool-1"))
+	})
+
+	It("groups pools in nested directories", func() {
+		startClaimer()
+
+		Expect(runCommand("create aws/us-east/env-1")).To(Equal("Created aws/us-east/env-1"))
+		Expect(runCommand("create aws/us-west/env-1")).To(Equal("Created aws/us-west/env-1"))
+
+		Expect(runCommand("claim aws/us-east")).To(Equal("Claimed aws/us-east/env-1"))
+		Expect(runCommand("claim aws/us-east")).To(Equal("every pool in aws/us-east is claimed"))
+
+		updateGitRepo(gitDir)
+		Expect(filepath.Join(gitDir, "aws", "us-east", "env-1", "claimed", "env-1")).To(BeAnExistingFile())
+
+		Expect(runCommand("status aws")).To(Equal("*Claimed by you:* aws/us-east/env-1\n*Claimed by others:* \n*Unclaimed:* aws/us-west/env-1"))
+		Expect(runCommand("release aws/us-east/env-1")).To(Equal("Released aws/us-east/env-1"))
+	})
+
+	It("renames and moves pools", func() {
+		startClaimer()
+
+		Expect(runCommand("claim pool-1 some message")).To(Equal("Claimed pool-1"))
+		Expect(runCommand("rename pool-1 pool-3")).To(Equal("pool-3 already exists"))
+		Expect(runCommand("rename pool-1 staging")).To(Equal("Renamed pool-1 to staging"))
+		Expect(runCommand("move staging aws/us-east")).To(Equal("Moved staging to aws/us-east/staging"))
+
+		updateGitRepo(gitDir)
+		Expect(filepath.Join(gitDir, "pool-1")).NotTo(BeADirectory())
+		Expect(filepath.Join(gitDir, "aws", "us-east", "staging", "claimed", "lock-a")).To(BeAnExistingFile())
+
+		Expect(runCommand("status")).To(Equal("*Claimed by you:* aws/us-east/staging\n*Claimed by others:* pool-3\n*Unclaimed:* "))
+		Expect(runCommand("owner aws/us-east/staging")).To(MatchRegexp(fmt.Sprintf(`^aws/us-east/staging was claimed by %s on .* \(some message\)$`, user.Name)))
+		Expect(runCommand("history aws/us-east/staging")).To(ContainSubstring(user.Name + " has claimed it since"))
+	})
+
+	It("reserves pools", func() {
+		startClaimer()
+
+		start := time.Now().AddDate(0, 0, 2).Format("2006-01-02")
+		Expect(runCommand("reserve pool-1 " + start + " 1d release testing")).To(HavePrefix("Reserved pool-1 from "))
+		Expect(runCommand("reserve pool-1 " + start + "T12:00 4h")).To(HavePrefix(fmt.Sprintf("pool-1 is already reserved by %s from ", user.Name)))
+
+		updateGitRepo(gitDir)
+		Expect(filepath.Join(gitDir, "pool-1", "reservations.yml")).To(BeAnExistingFile())
+		Expect(runCommand("owner pool-1")).To(ContainSubstring(fmt.Sprintf("*Reserved* by %s from ", user.Name)))
+	})
+
+	It("disables and enables pools", func() {
+		startClaimer()
+
+		Expect(runCommand("disable pool-3 repaving")).To(Equal("pool-3 is claimed by " + otherUser.Name + ", it must be released first"))
+		Expect(runCommand("disable pool-1 repaving")).To(Equal("Disabled pool-1"))
+		Expect(runCommand("claim pool-1")).To(Equal(fmt.Sprintf("pool-1 is disabled by %s (repaving)", user.Name)))
+		Expect(runCommand("status")).To(Equal("*Claimed by you:* \n*Claimed by others:* pool-3\n*Unclaimed:* \n*Disabled:* pool-1 (repaving)"))
+
+		updateGitRepo(gitDir)
+		Expect(filepath.Join(gitDir, "pool-1", "claimed", "lock-a")).To(BeAnExistingFile())
+		Expect(filepath.Join(gitDir, "pool-1", "disabled.yml")).To(BeAnExistingFile())
+
+		Expect(runCommand("enable pool-1")).To(Equal("Enabled pool-1"))
+		Expect(runCommand("history pool-1")).To(HavePrefix("pool-1 has never been claimed"))
+		Expect(runCommand("stats pool-1")).To(ContainSubstring("pool-1: 0% claimed, 0 claims"))
+		Expect(runCommand("claim pool-1")).To(Equal("Claimed pool-1"))
+	})
+
+	It("claims pools by tag", func() {
+		startClaimer()
+
+		Expect(runCommand("create gcp-1 --tag gcp --tag us-east")).To(Equal("Created gcp-1"))
+		Expect(runCommand("create gcp-2 --tag gcp")).To(Equal("Created gcp-2"))
+
+		Expect(runCommand("claim any:gcp AND NOT us-east some message")).To(Equal("Claimed gcp-2"))
+		Expect(runCommand("claim --tag gcp")).To(Equal("Claimed gcp-1"))
+		Expect(runCommand("claim any:gcp")).To(Equal("no unclaimed pool matches gcp"))
+
+		updateGitRepo(gitDir)
+		Expect(filepath.Join(gitDir, "gcp-2", "claimed", "gcp-2")).To(BeAnExistingFile())
+		Expect(runCommand("owner gcp-2")).To(HaveSuffix(" (some message)"))
+	})
+
+	It("finds and repairs malformed pools", func() {
+		Expect(runCli(0, "doctor")).To(Equal("Found problems with these pools:\n" +
+			"pool-1: lock pool-1/unclaimed/lock-a is not named after the pool\n" +
+			"pool-2: has more than one lock (pool-2/unclaimed/lock-a, pool-2/unclaimed/lock-b)\n" +
+			"pool-3: lock pool-3/claimed/lock-c is not named after the pool\n" +
+			"Run `doctor --fix` to repair what can be repaired",
+		))
+		Expect(runCli(0, "doctor", "--fix")).To(ContainSubstring("pool-1: lock pool-1/unclaimed/lock-a is not named after the pool (fixed)"))
+
+		updateGitRepo(gitDir)
+		Expect(filepath.Join(gitDir, "pool-1", "unclaimed", "pool-1")).To(BeAnExistingFile())
+		Expect(runGitCommand(gitDir, "log", "-1", "--format=%an: %s")).To(Equal(user.Name + ": Claimer repairing pool-1\n"))
+
+		startClaimer()
+		Expect(runCommand("doctor")).To(HavePrefix("Found problems with these pools:\npool-2: "))
+	})
+
+	It("claims and releases pools from the command line", func() {
+		Expect(runCli(0, "claim", "pool-1", "-m", "some message")).To(Equal("Claimed pool-1"))
+		Expect(runCli(1, "claim", "pool-1")).To(Equal("Error: pool-1 is already claimed"))
+		Expect(runCli(0, "status")).To(Equal("*Claimed by you:* pool-1\n*Claimed by others:* pool-3\n*Unclaimed:*"))
+
+		var locks []map[string]interface{}
+		Expect(json.Unmarshal([]byte(runCli(0, "status", "--json")), &locks)).To(Succeed())
+		Expect(locks).To(HaveLen(2))
+		Expect(locks[0]).To(HaveKeyWithValue("name", "pool-1"))
+		Expect(locks[0]).To(HaveKeyWithValue("claimed", true))
+		Expect(locks[0]).To(HaveKeyWithValue("owner", user.Name))
+		Expect(locks[0]).To(HaveKeyWithValue("message", "some message"))
+		Expect(locks[0]).To(HaveKey("since"))
+		Expect(locks[1]).To(HaveKeyWithValue("tags", BeEmpty()))
+
+		updateGitRepo(gitDir)
+		Expect(runGitCommand(gitDir, "log", "-1", "--format=%an: %s%n%n%b")).To(Equal(user.Name + ": Claimer claiming pool-1\n\nsome message\n"))
+
+		Expect(runCli(0, "release", "pool-1")).To(Equal("Released pool-1"))
+		Expect(runCli(1, "release", "pool-1")).To(Equal("Error: pool-1 is not claimed"))
+		Expect(runCli(1, "reserve", "pool-1")).To(Equal("Error: unknown command reserve"))
+
+		Expect(runCli(0, "claim", "pool-1", "-m", "pool-3 needs repaving first")).To(Equal("Claimed pool-1"))
+		updateGitRepo(gitDir)
+		Expect(runGitCommand(gitDir, "log", "-1", "--format=%s%n%n%b")).To(Equal("Claimer claiming pool-1\n\npool-3 needs repaving first\n"))
+		Expect(runCli(1, "claim", "pool-3", "-m", "pool-1")).To(Equal("Error: pool-3 is already claimed"))
+
+		Expect(slackServer.Requests()).To(BeEmpty())
+	})
+
+	It("claims and releases pools through the API", func() {
+		tokensFile := filepath.Join(repoDir, "..", filepath.Base(repoDir)+"-tokens.yml")
+		Expect(ioutil.WriteFile(tokensFile, []byte("some-api-token: "+user.Name+"\n"), 0600)).To(Succeed())
+		defer os.Remove(tokensFile)
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		addr := listener.Addr().String()
+		Expect(listener.Close()).To(Succeed())
+
+		startClaimer("-httpAddr", addr, "-apiTokensFile", tokensFile)
+
+		request := func(method, path, token, body string) (int, string) {
+			req, err := http.NewRequest(method, "http://"+addr+path, strings.NewReader(body))
+			ExpectWithOffset(1, err).NotTo(HaveOccurred())
+			req.Header.Set("Authorization", "Bearer "+token)
+			var response *http.Response
+			EventuallyWithOffset(1, func() error {
+				response, err = http.DefaultClient.Do(req)
+				return err
+			}, "5s").Should(Succeed())
+			defer response.Body.Close()
+			contents, err := ioutil.ReadAll(response.Body)
+			ExpectWithOffset(1, err).NotTo(HaveOccurred())
+			return response.StatusCode, string(contents)
+		}
+
+		code, _ := request("POST", "/pools/pool-1/claim", "some-other-token", "")
+		Expect(code).To(Equal(http.StatusUnauthorized))
+
+		code, body := request("POST", "/pools/pool-1/claim", "some-api-token", `{"message": "some message"}`)
+		Expect(code).To(Equal(http.StatusOK))
+		Expect(body).To(MatchJSON(`{"message": "Claimed pool-1"}`))
+
+		code, body = request("POST", "/pools/pool-1/claim", "some-api-token", "")
+		Expect(code).To(Equal(http.StatusConflict))
+		Expect(body).To(MatchJSON(`{"error": "pool-1 is already claimed"}`))
+
+		updateGitRepo(gitDir)
+		Expect(runGitCommand(gitDir, "log", "-1", "--format=%an <%ae>: %s")).To(Equal(user.Name + " <" + user.Email + ">: Claimer claiming pool-1\n"))
+
+		code, body = request("GET", "/pools", "some-api-token", "")
+		Expect(code).To(Equal(http.StatusOK))
+		Expect(body).To(ContainSubstring(`"owner":"` + user.Name + `"`))
+
+		code, _ = request("POST", "/pools/pool-1/release", "some-api-token", "")
+		Expect(code).To(Equal(http.StatusOK))
+		code, body = request("POST", "/pools/pool-1/claim", "some-api-token", `{"message": "pool-3 is broken"}`)
+		Expect(code).To(Equal(http.StatusOK))
+		Expect(body).To(MatchJSON(`{"message": "Claimed pool-1"}`))
+		code, _ = request("DELETE", "/pools/pool-4", "some-api-token", "")
+		Expect(code).To(Equal(http.StatusNotFound))
+	})
+
+	It("reuses the clone in the work dir across restarts", func() {
+		workDir, err := ioutil.TempDir("", "claimer-integration-tests-work-dir")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(workDir)
+
+		startClaimer("-workDir", workDir)
+		Expect(runCommand("claim pool-1")).To(Equal("Claimed pool-1"))
+		gexec.KillAndWait()
+		Eventually(slackServer.Connections).Should(Equal(0))
+		Expect(filepath.Join(workDir, ".git")).To(BeADirectory())
+
+		startClaimer("-workDir", workDir)
+		Expect(runCommand("status")).To(Equal("*Claimed by you:* pool-1\n*Claimed by others:* pool-3\n*Unclaimed:* "))
+	})
+
+	It("keeps locks in memory when using the memory store", func() {
+		startClaimer("-store", "memory")
+
+		Expect(runCommand("status")).To(Equal("*Claimed by you:* \n*Claimed by others:* \n*Unclaimed:* "))
+
+		Expect(runCommand("create new-pool")).To(Equal("Created new-pool"))
+		Expect(runCommand("claim new-pool")).To(Equal("Claimed new-pool"))
+		Expect(runCommand("status")).To(Equal("*Claimed by you:* new-pool\n*Claimed by others:* \n*Unclaimed:* "))
+		Expect(runCommand("release new-pool")).To(Equal("Released new-pool"))
+
+		updateGitRepo(gitDir)
+		Expect(filepath.Join(gitDir, "new-pool")).NotTo(BeADirectory())
+	})
+
 	It("does not respond in other channels", func() {
-		startClaimer("")
+		startClaimer()
 
-		postSlackMessage(fmt.Sprintf("<@%s> help", botId), otherChannelId, userApiToken)
+		slackServer.PostMessage(user.Id, otherChannel, fmt.Sprintf("<@%s> help", bot.Id))
 
-		Consistently(func() string { return latestSlackMessage(otherChannelId, apiToken) }, "10s").
-			Should(Equal(fmt.Sprintf("<@%s> help", botId)))
+		Consistently(func() string { return slackServer.LatestMessage(otherChannel) }, "2s").
+			Should(Equal(fmt.Sprintf("<@%s> help", bot.Id)))
 	})
 
 	Context("when a translation file is provided", func() {
@@ -233,7 +504,7 @@ var _ = Describe("Claimer", func() {
 			translations := `help: {header: "foo\n"}`
 			Expect(ioutil.WriteFile(translationFilePath, []byte(translations), 0644)).To(Succeed())
 
-			startClaimer(translationFilePath)
+			startClaimer("-translationFile", translationFilePath)
 
 			Expect(runCommand("help")).To(HavePrefix("foo"))
 
@@ -242,102 +513,55 @@ var _ = Describe("Claimer", func() {
 	})
 })
 
-func getEnv(name string) string {
-	value, ok := os.LookupEnv(name)
-	if !ok {
-		Fail(fmt.Sprintf("%s must be set", name))
-	}
-	return value
-}
-
-func postSlackMessage(text, channelId, apiToken string) {
-	_, err := slackPostForm("https://slack.com/api/chat.postMessage", url.Values{
-		"token":   {apiToken},
-		"channel": {channelId},
-		"text":    {text},
-		"as_user": {"true"},
-	})
-	Expect(err).NotTo(HaveOccurred())
-}
-
-func latestSlackMessage(channelId, apiToken string) string {
-	body, err := slackPostForm("https://slack.com/api/channels.history", url.Values{
-		"token":   {apiToken},
-		"channel": {channelId},
-		"count":   {"1"},
-	})
+// createPoolRepo creates a bare repo containing an unclaimed pool-1, a pool-2
+// with too many locks, and a pool-3 claimed by another user
+func createPoolRepo(repoDir string) {
+	workDir, err := ioutil.TempDir("", "claimer-integration-tests-fixture")
 	Expect(err).NotTo(HaveOccurred())
-	var slackResponse struct {
-		Messages []struct {
-			Text string
-		}
-	}
-	Expect(json.Unmarshal(body, &slackResponse)).To(Succeed())
-
-	return slackResponse.Messages[0].Text
-}
-
-func slackPostForm(url string, values url.Values) ([]byte, error) {
-	delay := slackRateLimitDelay
-	for i := 0; i < slackRequestAttempts; i++ {
-		time.Sleep(delay)
-
-		body, err := postForm(url, values)
-		if err != nil {
-			return nil, err
-		}
-
-		var slackResponse struct {
-			Ok    bool
-			Error string
-		}
-		if err := json.Unmarshal(body, &slackResponse); err != nil {
-			return nil, err
-		}
-
-		if slackResponse.Ok {
-			return body, nil
-		} else if slackResponse.Error != "ratelimited" {
-			return nil, fmt.Errorf("Slack request failed: %s", slackResponse.Error)
-		}
-
-		delay *= 2
+	defer os.RemoveAll(workDir)
+
+	runGitCommand(workDir, "init", ".")
+	for _, file := range []string{
+		filepath.Join("pool-1", "claimed", ".gitkeep"),
+		filepath.Join("pool-1", "unclaimed", ".gitkeep"),
+		filepath.Join("pool-1", "unclaimed", "lock-a"),
+		filepath.Join("pool-2", "claimed", ".gitkeep"),
+		filepath.Join("pool-2", "unclaimed", "lock-a"),
+		filepath.Join("pool-2", "unclaimed", "lock-b"),
+		filepath.Join("pool-3", "claimed", ".gitkeep"),
+		filepath.Join("pool-3", "unclaimed", ".gitkeep"),
+		filepath.Join("pool-3", "unclaimed", "lock-c"),
+	} {
+		Expect(os.MkdirAll(filepath.Join(workDir, filepath.Dir(file)), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(workDir, file), nil, 0644)).To(Succeed())
 	}
-	return nil, fmt.Errorf("Slack request failed %d times", slackRequestAttempts)
-}
-
-func postForm(url string, values url.Values) ([]byte, error) {
-	response, err := http.PostForm(url, values)
-	if err != nil {
-		return nil, err
-	}
-	defer response.Body.Close()
+	runGitCommand(workDir, "add", "-A")
+	runGitCommand(workDir, "commit", "-m", "Initial state")
 
-	return ioutil.ReadAll(response.Body)
-}
+	runGitCommand(workDir, "mv", filepath.Join("pool-3", "unclaimed", "lock-c"), filepath.Join("pool-3", "claimed", "lock-c"))
+	runGitCommand(workDir, "commit", "--author", otherUser.Name+" <>", "-m", "Claimer claiming pool-3")
 
-func updateGitRepo(gitDir, deployKey string) {
-	runGitCommand(gitDir, deployKey, "fetch")
-	runGitCommand(gitDir, deployKey, "reset", "--hard", "origin/master")
+	runGitCommand(repoDir, "init", "--bare", ".")
+	runGitCommand(repoDir, "symbolic-ref", "HEAD", "refs/heads/master")
+	runGitCommand(workDir, "push", repoDir, "HEAD:refs/heads/master")
 }
 
-func resetClaimerTestPool(gitDir, deployKey string) {
-	runGitCommand(gitDir, deployKey, "checkout", "master")
-	runGitCommand(gitDir, deployKey, "reset", "--hard", "initial-state")
-	runGitCommand(gitDir, deployKey, "push", "--force", "origin", "master")
+func updateGitRepo(gitDir string) {
+	runGitCommand(gitDir, "fetch")
+	runGitCommand(gitDir, "reset", "--hard", "origin/master")
 }
 
-func runGitCommand(dir, deployKey string, args ...string) {
-	deployKeyDir, err := ioutil.TempDir("", "claimer-integration-test-deploy-key")
-	Expect(err).NotTo(HaveOccurred())
-	defer os.RemoveAll(deployKeyDir)
-
-	deployKeyPath := filepath.Join(deployKeyDir, "key.pem")
-	Expect(ioutil.WriteFile(deployKeyPath, []byte(deployKey), 0600)).To(Succeed())
-
+func runGitCommand(dir string, args ...string) string {
 	cmd := exec.Command("git", args...)
 	cmd.Dir = dir
-	cmd.Env = append(os.Environ(), fmt.Sprintf(`GIT_SSH_COMMAND=/usr/bin/ssh -i %s`, deployKeyPath))
+	cmd.Env = append(
+		os.Environ(),
+		"GIT_AUTHOR_NAME=claimer-integration-tests",
+		"GIT_AUTHOR_EMAIL=<>",
+		"GIT_COMMITTER_NAME=claimer-integration-tests",
+		"GIT_COMMITTER_EMAIL=<>",
+	)
 	output, err := cmd.CombinedOutput()
 	ExpectWithOffset(1, err).NotTo(HaveOccurred(), fmt.Sprintf("Error running git command: %s", string(output)))
+	return string(output)
 }
@@ -0,0 +1,81 @@
+package locker_test
+
+import (
+	. "github.com/mdelillo/claimer/locker"
+
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mdelillo/claimer/fs"
+	"github.com/mdelillo/claimer/git"
+	"github.com/mdelillo/claimer/locker/lockerfakes"
+	"github.com/mdelillo/claimer/locker/lockertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Locker backed by a real git repo", func() {
+	var remoteDir, gitDir string
+
+	BeforeEach(func() {
+		var err error
+		remoteDir, err = ioutil.TempDir("", "claimer-locker-remote")
+		Expect(err).NotTo(HaveOccurred())
+		gitDir, err = ioutil.TempDir("", "claimer-locker-clone")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.Remove(gitDir)).To(Succeed())
+
+		runGitCommand(remoteDir, "init", ".")
+		runGitCommand(remoteDir, "symbolic-ref", "HEAD", "refs/heads/master")
+		runGitCommand(remoteDir, "config", "receive.denyCurrentBranch", "updateInstead")
+		runGitCommand(remoteDir, "commit", "--allow-empty", "-m", "Initial commit")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(remoteDir)
+		os.RemoveAll(gitDir)
+	})
+
+	newLocker := func() Locker {
+		return NewLocker(fs.NewFs(), git.NewRepo("file://"+remoteDir, git.Auth{}, gitDir, nil, git.DefaultCommitter), new(lockerfakes.FakeAuthors), 0)
+	}
+
+	lockertest.DescribeLocker(newLocker)
+
+	It("keeps locks named after their pools when renaming them", func() {
+		l := newLocker()
+		Expect(l.CreatePool("pool-a", "some-creator", Metadata{})).To(Succeed())
+		Expect(l.CreatePool("pool-b", "some-creator", Metadata{})).To(Succeed())
+		Expect(l.ClaimLock("pool-b", "some-owner", "")).To(Succeed())
+
+		Expect(l.RenamePool("pool-a", "pool-x", "some-user")).To(Succeed())
+		Expect(l.RenamePool("pool-b", "some-group/pool-y", "some-user")).To(Succeed())
+		Expect(filepath.Join(gitDir, "pool-x", "unclaimed", "pool-x")).To(BeAnExistingFile())
+		Expect(filepath.Join(gitDir, "some-group", "pool-y", "claimed", "pool-y")).To(BeAnExistingFile())
+
+		Expect(l.Doctor(false, "some-user")).To(BeEmpty())
+
+		history, err := l.History("some-group/pool-y")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(history.Creator).To(Equal("some-creator"))
+		Expect(history.Claims).To(HaveLen(1))
+		Expect(history.Claims[0].Owner).To(Equal("some-owner"))
+		Expect(history.Claims[0].Active()).To(BeTrue())
+	})
+})
+
+func runGitCommand(dir string, args ...string) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(
+		os.Environ(),
+		"GIT_AUTHOR_NAME=some-author",
+		"GIT_AUTHOR_EMAIL=<>",
+		"GIT_COMMITTER_NAME=some-committer",
+		"GIT_COMMITTER_EMAIL=<>",
+	)
+	output, err := cmd.CombinedOutput()
+	ExpectWithOffset(1, err).NotTo(HaveOccurred(), string(output))
+}
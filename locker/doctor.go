@@ -0,0 +1,243 @@
+package locker
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/mdelillo/claimer/git"
+	"github.com/pkg/errors"
+)
+
+// ProblemKind names a way in which a pool can be malformed. The names are
+// also the keys of the messages describing them.
+type ProblemKind string
+
+const (
+	// ProblemMissingDir is a pool without a claimed or unclaimed directory
+	ProblemMissingDir ProblemKind = "missing_dir"
+	// ProblemNoLock is a pool without any lock
+	ProblemNoLock ProblemKind = "no_lock"
+	// ProblemTooManyLocks is a pool with more than one lock, which claimer
+	// cannot claim or release
+	ProblemTooManyLocks ProblemKind = "too_many_locks"
+	// ProblemOrphanedLock is a file directly inside the pool directory
+	// rather than in claimed or unclaimed
+	ProblemOrphanedLock ProblemKind = "orphaned_lock"
+	// ProblemStrayFile is a directory where only files are expected
+	ProblemStrayFile ProblemKind = "stray_file"
+	// ProblemLockNameMismatch is a lock not named after its pool
+	ProblemLockNameMismatch ProblemKind = "lock_name_mismatch"
+	// ProblemStaleClaimFile is a claim file left in an unclaimed pool
+	ProblemStaleClaimFile ProblemKind = "stale_claim_file"
+)
+
+// Problem is something wrong with the layout of a pool in the repo. Status
+// leaves out pools with some of these problems, so they are easy to miss.
+// File is relative to the root of the repo.
+type Problem struct {
+	Kind    ProblemKind
+	Pool    string
+	File    string
+	Fixable bool
+	Fixed   bool
+}
+
+// Doctor checks every pool for problems. With fix set, the problems which
+// can be repaired safely are repaired in a single commit.
+func (l *locker) Doctor(fix bool, user string) ([]Problem, error) {
+	var author git.Identity
+	if fix {
+		author = l.author(user)
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if fix {
+		if err := l.pull(); err != nil {
+			return nil, errors.Wrap(err, "failed to clone or pull")
+		}
+	} else if err := l.fetch(); err != nil {
+		return nil, errors.Wrap(err, "failed to clone or pull")
+	}
+
+	pools, strays, err := l.walk("")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list pools")
+	}
+
+	var problems []Problem
+	for _, pool := range pools {
+		poolProblems, err := l.diagnose(pool)
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, poolProblems...)
+	}
+	problems = append(problems, strays...)
+	if !fix {
+		return problems, nil
+	}
+
+	var repaired []string
+	for i, problem := range problems {
+		if !problem.Fixable {
+			continue
+		}
+		if err := l.repair(problem); err != nil {
+			return nil, err
+		}
+		problems[i].Fixed = true
+		if !contains(repaired, problem.Pool) {
+			repaired = append(repaired, problem.Pool)
+		}
+	}
+	if len(repaired) == 0 {
+		return problems, nil
+	}
+
+	if err := l.gitRepo.CommitAndPush("Claimer repairing "+strings.Join(repaired, ", "), author); err != nil {
+		return nil, errors.Wrap(err, "failed to commit and push")
+	}
+	return problems, nil
+}
+
+// walk returns every pool below a group, and the files sitting in groups
+// rather than pools. Unlike Status, it counts a directory without
+// subdirectories as a pool even if it has neither lock directory, so that
+// doctor can report it.
+func (l *locker) walk(group string) ([]string, []Problem, error) {
+	var pools []string
+	var strays []Problem
+
+	dir := filepath.Join(l.gitRepo.Dir(), filepath.FromSlash(group))
+	children, err := l.fs.LsDirs(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if group != "" {
+		files, err := l.fs.Ls(dir)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, file := range files {
+			strays = append(strays, Problem{Kind: ProblemStrayFile, Pool: group, File: path.Join(group, file)})
+		}
+	}
+
+	for _, child := range children {
+		name := path.Join(group, child)
+		grandchildren, err := l.fs.LsDirs(filepath.Join(dir, child))
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(grandchildren) == 0 || contains(grandchildren, "claimed") || contains(grandchildren, "unclaimed") {
+			pools = append(pools, name)
+			continue
+		}
+		groupPools, groupStrays, err := l.walk(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		pools = append(pools, groupPools...)
+		strays = append(strays, groupStrays...)
+	}
+	return pools, strays, nil
+}
+
+func (l *locker) diagnose(pool string) ([]Problem, error) {
+	var problems []Problem
+	var locks []string
+	claimed := false
+
+	for _, dir := range []string{"claimed", "unclaimed"} {
+		exists, err := l.fs.Exists(filepath.Join(l.gitRepo.Dir(), pool, dir))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to check for %s directory", dir)
+		}
+		if !exists {
+			problems = append(problems, Problem{Kind: ProblemMissingDir, Pool: pool, File: path.Join(pool, dir), Fixable: true})
+			continue
+		}
+
+		files, err := l.fs.Ls(filepath.Join(l.gitRepo.Dir(), pool, dir))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list %s locks", dir)
+		}
+		for _, file := range files {
+			locks = append(locks, path.Join(pool, dir, file))
+		}
+		claimed = claimed || dir == "claimed" && len(files) > 0
+
+		dirs, err := l.fs.LsDirs(filepath.Join(l.gitRepo.Dir(), pool, dir))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list %s directory", dir)
+		}
+		for _, d := range dirs {
+			problems = append(problems, Problem{Kind: ProblemStrayFile, Pool: pool, File: path.Join(pool, dir, d)})
+		}
+	}
+
+	dirs, err := l.fs.LsDirs(filepath.Join(l.gitRepo.Dir(), pool))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list pool directory")
+	}
+	for _, d := range dirs {
+		if d != "claimed" && d != "unclaimed" {
+			problems = append(problems, Problem{Kind: ProblemStrayFile, Pool: pool, File: path.Join(pool, d)})
+		}
+	}
+
+	files, err := l.fs.Ls(filepath.Join(l.gitRepo.Dir(), pool))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list pool directory")
+	}
+	var orphans []string
+	for _, file := range files {
+		if file != claimFile && file != metadataFile && file != reservationsFile && file != maintenanceFile {
+			orphans = append(orphans, path.Join(pool, file))
+		}
+	}
+	// A single orphaned lock in a pool without locks can be put back in the
+	// unclaimed directory
+	for _, orphan := range orphans {
+		fixable := len(locks) == 0 && len(orphans) == 1
+		problems = append(problems, Problem{Kind: ProblemOrphanedLock, Pool: pool, File: orphan, Fixable: fixable})
+	}
+
+	switch {
+	case len(locks) == 0 && len(orphans) == 0:
+		problems = append(problems, Problem{Kind: ProblemNoLock, Pool: pool, File: path.Join(pool, "unclaimed", path.Base(pool)), Fixable: true})
+	case len(locks) == 1 && path.Base(locks[0]) != path.Base(pool):
+		// Renaming a claimed lock would look like a new claim in the log
+		problems = append(problems, Problem{Kind: ProblemLockNameMismatch, Pool: pool, File: locks[0], Fixable: !claimed})
+	case len(locks) > 1:
+		problems = append(problems, Problem{Kind: ProblemTooManyLocks, Pool: pool, File: strings.Join(locks, ", ")})
+	}
+
+	if !claimed && contains(files, claimFile) {
+		problems = append(problems, Problem{Kind: ProblemStaleClaimFile, Pool: pool, File: path.Join(pool, claimFile), Fixable: true})
+	}
+	return problems, nil
+}
+
+func (l *locker) repair(problem Problem) error {
+	file := filepath.Join(l.gitRepo.Dir(), problem.File)
+	var err error
+	switch problem.Kind {
+	case ProblemMissingDir:
+		err = l.fs.Touch(filepath.Join(file, ".gitkeep"))
+	case ProblemNoLock:
+		err = l.fs.Touch(file)
+	case ProblemOrphanedLock:
+		err = l.fs.Mv(file, filepath.Join(l.gitRepo.Dir(), problem.Pool, "unclaimed", path.Base(problem.File)))
+	case ProblemLockNameMismatch:
+		err = l.fs.Mv(file, filepath.Join(filepath.Dir(file), path.Base(problem.Pool)))
+	case ProblemStaleClaimFile:
+		err = l.fs.Rm(file)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to repair %s", problem.Pool)
+	}
+	return nil
+}
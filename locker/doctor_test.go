@@ -0,0 +1,165 @@
+package locker_test
+
+import (
+	. "github.com/mdelillo/claimer/locker"
+
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/mdelillo/claimer/fs"
+	"github.com/mdelillo/claimer/git"
+	"github.com/mdelillo/claimer/locker/lockerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Doctor", func() {
+	var (
+		gitDir  string
+		gitRepo *lockerfakes.FakeGitRepo
+		locker  Locker
+	)
+
+	touch := func(file string) {
+		ExpectWithOffset(1, fs.NewFs().Touch(filepath.Join(gitDir, file))).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		var err error
+		gitDir, err = ioutil.TempDir("", "claimer-locker-doctor")
+		Expect(err).NotTo(HaveOccurred())
+
+		gitRepo = new(lockerfakes.FakeGitRepo)
+		gitRepo.DirReturns(gitDir)
+		locker = NewLocker(fs.NewFs(), gitRepo, new(lockerfakes.FakeAuthors), 0)
+
+		touch("healthy/claimed/.gitkeep")
+		touch("healthy/unclaimed/healthy")
+		touch("healthy/pool.yml")
+		touch("aws/missing-claimed/unclaimed/missing-claimed")
+		touch("no-lock/claimed/.gitkeep")
+		touch("no-lock/unclaimed/.gitkeep")
+		touch("too-many-locks/claimed/lock-a")
+		touch("too-many-locks/unclaimed/lock-b")
+		touch("orphaned/claimed/.gitkeep")
+		touch("orphaned/unclaimed/.gitkeep")
+		touch("orphaned/orphaned")
+		touch("stray/claimed/stray")
+		touch("stray/unclaimed/some-dir/some-file")
+		touch("stray/some-dir/some-file")
+		touch("mismatch/claimed/.gitkeep")
+		touch("mismatch/unclaimed/some-lock")
+		touch("claimed-mismatch/claimed/some-lock")
+		touch("claimed-mismatch/unclaimed/.gitkeep")
+		touch("claimed-mismatch/claim.yml")
+		touch("stale/claimed/.gitkeep")
+		touch("stale/unclaimed/stale")
+		touch("stale/claim.yml")
+		touch("gcp/no-lock-dirs/no-lock-dirs")
+		touch("gcp/some-file")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(gitDir)
+	})
+
+	It("lists the problems with each pool", func() {
+		problems, err := locker.Doctor(false, "some-user")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(gitRepo.CloneOrPullCallCount()).To(Equal(1))
+		Expect(gitRepo.CommitAndPushCallCount()).To(Equal(0))
+		Expect(problems).To(Equal([]Problem{
+			{Kind: ProblemMissingDir, Pool: "aws/missing-claimed", File: "aws/missing-claimed/claimed", Fixable: true},
+			{Kind: ProblemLockNameMismatch, Pool: "claimed-mismatch", File: "claimed-mismatch/claimed/some-lock", Fixable: false},
+			{Kind: ProblemMissingDir, Pool: "gcp/no-lock-dirs", File: "gcp/no-lock-dirs/claimed", Fixable: true},
+			{Kind: ProblemMissingDir, Pool: "gcp/no-lock-dirs", File: "gcp/no-lock-dirs/unclaimed", Fixable: true},
+			{Kind: ProblemOrphanedLock, Pool: "gcp/no-lock-dirs", File: "gcp/no-lock-dirs/no-lock-dirs", Fixable: true},
+			{Kind: ProblemLockNameMismatch, Pool: "mismatch", File: "mismatch/unclaimed/some-lock", Fixable: true},
+			{Kind: ProblemNoLock, Pool: "no-lock", File: "no-lock/unclaimed/no-lock", Fixable: true},
+			{Kind: ProblemOrphanedLock, Pool: "orphaned", File: "orphaned/orphaned", Fixable: true},
+			{Kind: ProblemStaleClaimFile, Pool: "stale", File: "stale/claim.yml", Fixable: true},
+			{Kind: ProblemStrayFile, Pool: "stray", File: "stray/unclaimed/some-dir"},
+			{Kind: ProblemStrayFile, Pool: "stray", File: "stray/some-dir"},
+			{Kind: ProblemTooManyLocks, Pool: "too-many-locks", File: "too-many-locks/claimed/lock-a, too-many-locks/unclaimed/lock-b"},
+			{Kind: ProblemStrayFile, Pool: "gcp", File: "gcp/some-file"},
+		}))
+	})
+
+	Context("when fixing the problems", func() {
+		It("repairs what it can in a single commit", func() {
+			problems, err := locker.Doctor(true, "some-user")
+			Expect(err).NotTo(HaveOccurred())
+
+			var fixed []string
+			for _, problem := range problems {
+				Expect(problem.Fixed).To(Equal(problem.Fixable))
+				if problem.Fixed {
+					fixed = append(fixed, problem.File)
+				}
+			}
+			Expect(fixed).To(HaveLen(8))
+
+			Expect(filepath.Join(gitDir, "aws/missing-claimed/claimed/.gitkeep")).To(BeAnExistingFile())
+			Expect(filepath.Join(gitDir, "mismatch/unclaimed/mismatch")).To(BeAnExistingFile())
+			Expect(filepath.Join(gitDir, "claimed-mismatch/claimed/some-lock")).To(BeAnExistingFile())
+			Expect(filepath.Join(gitDir, "no-lock/unclaimed/no-lock")).To(BeAnExistingFile())
+			Expect(filepath.Join(gitDir, "orphaned/unclaimed/orphaned")).To(BeAnExistingFile())
+			Expect(filepath.Join(gitDir, "stale/claim.yml")).NotTo(BeAnExistingFile())
+			Expect(filepath.Join(gitDir, "stray/some-dir/some-file")).To(BeAnExistingFile())
+			Expect(filepath.Join(gitDir, "gcp/no-lock-dirs/claimed/.gitkeep")).To(BeAnExistingFile())
+			Expect(filepath.Join(gitDir, "gcp/no-lock-dirs/unclaimed/no-lock-dirs")).To(BeAnExistingFile())
+
+			Expect(gitRepo.CommitAndPushCallCount()).To(Equal(1))
+			message, author := gitRepo.CommitAndPushArgsForCall(0)
+			Expect(message).To(Equal("Claimer repairing aws/missing-claimed, gcp/no-lock-dirs, mismatch, no-lock, orphaned, stale"))
+			Expect(author).To(Equal(git.Identity{Name: "some-user"}))
+
+			problems, err = locker.Doctor(false, "some-user")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(problems).To(HaveLen(5))
+		})
+
+		Context("when nothing can be repaired", func() {
+			It("does not commit", func() {
+				Expect(os.RemoveAll(gitDir)).To(Succeed())
+				touch("too-many-locks/claimed/lock-a")
+				touch("too-many-locks/unclaimed/lock-b")
+
+				problems, err := locker.Doctor(true, "some-user")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(problems).To(HaveLen(1))
+				Expect(gitRepo.CommitAndPushCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when pushing fails", func() {
+			It("returns an error", func() {
+				gitRepo.CommitAndPushReturns(errors.New("some-error"))
+
+				_, err := locker.Doctor(true, "some-user")
+				Expect(err).To(MatchError("failed to commit and push: some-error"))
+			})
+		})
+	})
+
+	Context("when cloning the repo fails", func() {
+		It("returns an error", func() {
+			gitRepo.CloneOrPullReturns(errors.New("some-error"))
+
+			_, err := locker.Doctor(false, "some-user")
+			Expect(err).To(MatchError("failed to clone or pull: some-error"))
+		})
+	})
+
+	Context("when listing pools fails", func() {
+		It("returns an error", func() {
+			gitRepo.DirReturns(filepath.Join(gitDir, "some-missing-dir"))
+
+			_, err := locker.Doctor(false, "some-user")
+			Expect(err).To(MatchError(ContainSubstring("failed to list pools: ")))
+		})
+	})
+})
@@ -1,81 +1,329 @@
 package locker
 
 import (
+	"github.com/mdelillo/claimer/git"
 	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	"path"
 	"path/filepath"
+	"strings"
+	"sync"
+	"time"
 )
 
 //go:generate counterfeiter . gitRepo
 type gitRepo interface {
 	CloneOrPull() error
-	CommitAndPush(message, user string) error
+	CommitAndPush(message string, author git.Identity) error
 	Dir() string
+	Head() (string, error)
 	LatestCommit(pool string) (committer, date, message string, err error)
+	LatestCommits(paths []string) (map[string]git.Commit, error)
+	Log(path string) ([]git.Commit, error)
+}
+
+//go:generate counterfeiter . authors
+type authors interface {
+	Email(username string) string
 }
 
 //go:generate counterfeiter . fs
 type fs interface {
+	Exists(path string) (bool, error)
 	Ls(dir string) ([]string, error)
 	LsDirs(dir string) ([]string, error)
+	LsPools(dir string) ([]string, error)
 	Mv(src, dst string) error
+	ReadFile(file string) ([]byte, error)
 	Rm(path string) error
 	Touch(file string) error
+	WriteFile(file string, contents []byte) error
 }
 
+// Locker is the contract shared by the git-backed locker in this package and
+// the alternative backends in the store package.
+type Locker interface {
+	AnnotateLock(pool, user, message string) error
+	ClaimAny(pools []string, user, message string) (string, error)
+	ClaimLock(pool, user, message string) error
+	ClaimLocks(pools []string, user, message string) error
+	CreatePool(pool, user string, metadata Metadata) error
+	DestroyPool(pool, user string) error
+	DisablePool(pool, user, reason string) error
+	Doctor(fix bool, user string) ([]Problem, error)
+	EnablePool(pool, user string) error
+	ExtendLock(pool, user string, expires time.Time) error
+	Histories() ([]History, error)
+	History(pool string) (History, error)
+	ReleaseLock(pool, user string) error
+	ReleaseLocks(pools []string, user string) error
+	RenamePool(pool, newName, user string) error
+	Reserve(pool string, reservation Reservation) error
+	Status() ([]Lock, error)
+	Unreserve(pool string, reservation Reservation) error
+}
+
+// DateFormat matches the default date format used by git log
+const DateFormat = "Mon Jan 2 15:04:05 2006 -0700"
+
+const (
+	claimFile    = "claim.yml"
+	metadataFile = "pool.yml"
+)
+
 type Lock struct {
 	Name    string
 	Owner   string
 	Date    string
 	Message string
+	Expires time.Time
 	Claimed bool
+	Metadata
+
+	Reservations []Reservation
+
+	// Maintenance is set while the pool is disabled. Disabled pools are
+	// neither claimed nor available to claim.
+	Maintenance *Maintenance
+}
+
+// ValidPoolName returns whether a name can be used for a pool. Pools are
+// directories in the repo, so their names must be clean relative paths which
+// stay inside it. Names with hidden parts, such as ".git", are refused as
+// well, as hidden directories are never pools.
+func ValidPoolName(name string) bool {
+	if name == "" || strings.HasPrefix(name, "/") || path.Clean(name) != name {
+		return false
+	}
+	for _, part := range strings.Split(name, "/") {
+		if strings.HasPrefix(part, ".") {
+			return false
+		}
+	}
+	return true
+}
+
+// Metadata describes what a pool is for. It is optional and kept in pool.yml
+// in the directory of the pool.
+type Metadata struct {
+	Description string   `yaml:"description,omitempty"`
+	Team        string   `yaml:"team,omitempty"`
+	Tags        []string `yaml:"tags,omitempty"`
+	Links       []string `yaml:"links,omitempty"`
+}
+
+func (m Metadata) IsEmpty() bool {
+	return m.Description == "" && m.Team == "" && len(m.Tags) == 0 && len(m.Links) == 0
+}
+
+// History describes the current incarnation of a pool. Claims are ordered
+// from oldest to newest.
+type History struct {
+	Pool    string
+	Creator string
+	Created time.Time
+	Claims  []Claim
+}
+
+// Claim is a single claim of a pool. Released is zero while the claim is
+// still held.
+type Claim struct {
+	Owner      string
+	Message    string
+	Claimed    time.Time
+	Released   time.Time
+	ReleasedBy string
+}
+
+func (c Claim) Active() bool {
+	return c.Released.IsZero()
+}
+
+func (c Claim) Duration(now time.Time) time.Duration {
+	if c.Active() {
+		return now.Sub(c.Claimed)
+	}
+	return c.Released.Sub(c.Claimed)
+}
+
+type claimMetadata struct {
+	Owner   string    `yaml:"owner"`
+	Message string    `yaml:"message,omitempty"`
+	Expires time.Time `yaml:"expires,omitempty"`
 }
 
 type locker struct {
 	fs      fs
 	gitRepo gitRepo
+	authors authors
+
+	// minFetchInterval is how long reads may use the clone before pulling
+	// again. Writes always pull first.
+	minFetchInterval time.Duration
+	lastFetch        time.Time
+
+	// statusHead is the commit statusCache was computed from
+	statusHead  string
+	statusCache []Lock
+
+	// mutex serializes operations on the working copy of the repo, which is
+	// shared by the bot and the scheduler
+	mutex sync.Mutex
 }
 
-func NewLocker(fs fs, gitRepo gitRepo) *locker {
+func NewLocker(fs fs, gitRepo gitRepo, authors authors, minFetchInterval time.Duration) *locker {
 	return &locker{
-		fs:      fs,
-		gitRepo: gitRepo,
+		fs:               fs,
+		gitRepo:          gitRepo,
+		authors:          authors,
+		minFetchInterval: minFetchInterval,
 	}
 }
 
 func (l *locker) ClaimLock(pool, user, message string) error {
-	if err := l.gitRepo.CloneOrPull(); err != nil {
+	return l.ClaimLocks([]string{pool}, user, message)
+}
+
+// ClaimLocks claims all of the pools in a single commit. Every pool is
+// checked before any lock is moved, so either all of them are claimed or
+// none are.
+func (l *locker) ClaimLocks(pools []string, user, message string) error {
+	author := l.author(user)
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if err := l.pull(); err != nil {
 		return errors.Wrap(err, "failed to clone or pull")
 	}
 
-	locks, err := l.fs.Ls(filepath.Join(l.gitRepo.Dir(), pool, "unclaimed"))
+	locks := map[string]string{}
+	for _, pool := range pools {
+		lock, err := l.singleLock(pool, "unclaimed")
+		if err != nil {
+			return err
+		}
+		locks[pool] = lock
+	}
+	for _, pool := range pools {
+		if err := l.moveLock(pool, locks[pool], "unclaimed", "claimed"); err != nil {
+			return err
+		}
+	}
+
+	if err := l.gitRepo.CommitAndPush(claimCommitMessage(pools, message), author); err != nil {
+		return errors.Wrap(err, "failed to commit and push")
+	}
+	return nil
+}
+
+// ClaimAny claims the first of the pools which is unclaimed and returns its
+// name, or an empty name if they are all claimed. Checking and claiming
+// happen after a single pull, so two users asking for any pool never race
+// for the same one.
+func (l *locker) ClaimAny(pools []string, user, message string) (string, error) {
+	author := l.author(user)
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if err := l.pull(); err != nil {
+		return "", errors.Wrap(err, "failed to clone or pull")
+	}
+
+	existingPools, err := l.fs.LsPools(l.gitRepo.Dir())
+	if err != nil {
+		return "", errors.Wrap(err, "failed to list pools")
+	}
+
+	for _, pool := range pools {
+		if !contains(existingPools, pool) {
+			continue
+		}
+		claimedLocks, err := l.fs.Ls(filepath.Join(l.gitRepo.Dir(), pool, "claimed"))
+		if err != nil {
+			return "", errors.Wrap(err, "failed to list claimed locks")
+		}
+		unclaimedLocks, err := l.fs.Ls(filepath.Join(l.gitRepo.Dir(), pool, "unclaimed"))
+		if err != nil {
+			return "", errors.Wrap(err, "failed to list unclaimed locks")
+		}
+		if len(claimedLocks) == 0 && len(unclaimedLocks) == 1 {
+			if err := l.moveLock(pool, unclaimedLocks[0], "unclaimed", "claimed"); err != nil {
+				return "", err
+			}
+			if err := l.gitRepo.CommitAndPush(claimCommitMessage([]string{pool}, message), author); err != nil {
+				return "", errors.Wrap(err, "failed to commit and push")
+			}
+			return pool, nil
+		}
+	}
+	return "", nil
+}
+
+// singleLock returns the only lock in the claimed or unclaimed directory of
+// a pool
+func (l *locker) singleLock(pool, dir string) (string, error) {
+	locks, err := l.fs.Ls(filepath.Join(l.gitRepo.Dir(), pool, dir))
 	if err != nil {
-		return errors.Wrap(err, "failed to list unclaimed locks")
+		return "", errors.Wrapf(err, "failed to list %s locks", dir)
 	}
 
 	if len(locks) == 0 {
-		return errors.Errorf("no unclaimed locks for pool %s", pool)
+		return "", errors.Errorf("no %s locks for pool %s", dir, pool)
 	} else if len(locks) > 1 {
-		return errors.Errorf("too many unclaimed locks for pool %s", pool)
+		return "", errors.Errorf("too many %s locks for pool %s", dir, pool)
 	}
+	return locks[0], nil
+}
 
-	unclaimedLock := filepath.Join(l.gitRepo.Dir(), pool, "unclaimed", locks[0])
-	claimedLock := filepath.Join(l.gitRepo.Dir(), pool, "claimed", locks[0])
-	if err := l.fs.Mv(unclaimedLock, claimedLock); err != nil {
+// moveLock moves a lock between the claimed and unclaimed directories of a
+// pool, discarding the claim file of the previous claim
+func (l *locker) moveLock(pool, lock, from, to string) error {
+	if err := l.fs.Mv(filepath.Join(l.gitRepo.Dir(), pool, from, lock), filepath.Join(l.gitRepo.Dir(), pool, to, lock)); err != nil {
 		return errors.Wrap(err, "failed to move file")
 	}
+	if err := l.fs.Rm(filepath.Join(l.gitRepo.Dir(), pool, claimFile)); err != nil {
+		return errors.Wrap(err, "failed to remove claim file")
+	}
+	return nil
+}
+
+func (l *locker) AnnotateLock(pool, user, message string) error {
+	author := l.author(user)
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if err := l.pull(); err != nil {
+		return errors.Wrap(err, "failed to clone or pull")
+	}
+
+	metadata, err := l.claimMetadata(pool, user)
+	if err != nil {
+		return err
+	}
+	metadata.Message = message
+	if err := l.writeClaimMetadata(pool, metadata); err != nil {
+		return err
+	}
 
-	commitMessage := "Claimer claiming " + pool
+	commitMessage := "Claimer annotating " + pool
 	if message != "" {
 		commitMessage += "\n\n" + message
 	}
-	if err := l.gitRepo.CommitAndPush(commitMessage, user); err != nil {
+	if err := l.gitRepo.CommitAndPush(commitMessage, author); err != nil {
 		return errors.Wrap(err, "failed to commit and push")
 	}
 	return nil
 }
 
-func (l *locker) CreatePool(pool, user string) error {
-	if err := l.gitRepo.CloneOrPull(); err != nil {
+func (l *locker) CreatePool(pool, user string, metadata Metadata) error {
+	if !ValidPoolName(pool) {
+		return errors.Errorf("%s is not a valid pool name", pool)
+	}
+	author := l.author(user)
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if err := l.pull(); err != nil {
 		return errors.Wrap(err, "failed to clone or pull")
 	}
 
@@ -85,31 +333,185 @@ func (l *locker) CreatePool(pool, user string) error {
 	if err := l.fs.Touch(filepath.Join(l.gitRepo.Dir(), pool, "unclaimed", ".gitkeep")); err != nil {
 		return errors.Wrap(err, "failed to touch 'unclaimed/.gitkeep'")
 	}
-	if err := l.fs.Touch(filepath.Join(l.gitRepo.Dir(), pool, "unclaimed", pool)); err != nil {
+	if err := l.fs.Touch(filepath.Join(l.gitRepo.Dir(), pool, "unclaimed", path.Base(pool))); err != nil {
 		return errors.Wrap(err, "failed to touch lock file")
 	}
+	if !metadata.IsEmpty() {
+		contents, err := yaml.Marshal(metadata)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal pool file")
+		}
+		if err := l.fs.WriteFile(filepath.Join(l.gitRepo.Dir(), pool, metadataFile), contents); err != nil {
+			return errors.Wrap(err, "failed to write pool file")
+		}
+	}
 
-	if err := l.gitRepo.CommitAndPush("Claimer creating "+pool, user); err != nil {
+	if err := l.gitRepo.CommitAndPush("Claimer creating "+pool, author); err != nil {
 		return errors.Wrap(err, "failed to commit and push")
 	}
 	return nil
 }
 
 func (l *locker) DestroyPool(pool, user string) error {
-	if err := l.gitRepo.CloneOrPull(); err != nil {
+	author := l.author(user)
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if err := l.pull(); err != nil {
 		return errors.Wrap(err, "failed to clone or pull")
 	}
 	if err := l.fs.Rm(filepath.Join(l.gitRepo.Dir(), pool)); err != nil {
 		return errors.Wrap(err, "failed to remove directory")
 	}
-	if err := l.gitRepo.CommitAndPush("Claimer destroying "+pool, user); err != nil {
+	if err := l.gitRepo.CommitAndPush("Claimer destroying "+pool, author); err != nil {
+		return errors.Wrap(err, "failed to commit and push")
+	}
+	return nil
+}
+
+func (l *locker) ExtendLock(pool, user string, expires time.Time) error {
+	author := l.author(user)
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if err := l.pull(); err != nil {
+		return errors.Wrap(err, "failed to clone or pull")
+	}
+
+	metadata, err := l.claimMetadata(pool, user)
+	if err != nil {
+		return err
+	}
+	metadata.Expires = expires
+	if err := l.writeClaimMetadata(pool, metadata); err != nil {
+		return err
+	}
+
+	commitMessage := "Claimer extending " + pool + " until " + expires.Format(DateFormat)
+	if err := l.gitRepo.CommitAndPush(commitMessage, author); err != nil {
 		return errors.Wrap(err, "failed to commit and push")
 	}
 	return nil
 }
 
+func (l *locker) History(pool string) (History, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if err := l.fetch(); err != nil {
+		return History{}, errors.Wrap(err, "failed to clone or pull")
+	}
+	return l.history(pool)
+}
+
+func (l *locker) Histories() ([]History, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if err := l.fetch(); err != nil {
+		return nil, errors.Wrap(err, "failed to clone or pull")
+	}
+
+	pools, err := l.fs.LsPools(l.gitRepo.Dir())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list pools")
+	}
+
+	// a single pass over the whole log is much cheaper than a pass per pool
+	commits, err := l.gitRepo.Log("")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get log")
+	}
+	logOf := func(pool string) ([]git.Commit, error) {
+		return touching(commits, pool), nil
+	}
+
+	var histories []History
+	for _, pool := range pools {
+		history, err := l.historyBefore(pool, "", logOf)
+		if err != nil {
+			return nil, err
+		}
+		histories = append(histories, history)
+	}
+	return histories, nil
+}
+
+func (l *locker) history(pool string) (History, error) {
+	return l.historyBefore(pool, "", l.gitRepo.Log)
+}
+
+// historyBefore replays the commits touching a pool which are older than
+// the commit with the given hash, or every commit if the hash is empty. A
+// pool which was renamed carries on the history of its old name. log returns
+// the commits touching a pool, as gitRepo.Log does.
+func (l *locker) historyBefore(pool, hash string, log func(string) ([]git.Commit, error)) (History, error) {
+	commits, err := log(pool)
+	if err != nil {
+		return History{}, errors.Wrap(err, "failed to get log")
+	}
+	if hash != "" {
+		for i, commit := range commits {
+			if commit.Hash == hash {
+				commits = commits[i+1:]
+				break
+			}
+		}
+	}
+
+	history := History{Pool: pool}
+	var current *Claim
+	for i := len(commits) - 1; i >= 0; i-- {
+		commit := commits[i]
+		switch {
+		case renamedFrom(commit, pool) != "":
+			previous, err := l.historyBefore(renamedFrom(commit, pool), commit.Hash, log)
+			if err != nil {
+				return History{}, err
+			}
+			history = History{Pool: pool, Creator: previous.Creator, Created: previous.Created, Claims: previous.Claims}
+			current = nil
+			if n := len(history.Claims); n > 0 && history.Claims[n-1].Active() {
+				claim := history.Claims[n-1]
+				current = &claim
+				history.Claims = history.Claims[:n-1]
+			}
+		case changes(commit, path.Join(pool, maintenanceFile)) && !removesLock(commit, pool):
+			// disabling and enabling a pool move its lock like a claim and
+			// a release, but are not claims
+		case movesLockTo(commit, pool, "claimed"):
+			if current != nil {
+				history.Claims = append(history.Claims, *current)
+			}
+			current = &Claim{Owner: commit.Author, Message: commit.Body, Claimed: commit.Date}
+		case movesLockTo(commit, pool, "unclaimed"):
+			if current != nil {
+				current.Released = commit.Date
+				current.ReleasedBy = commit.Author
+				history.Claims = append(history.Claims, *current)
+				current = nil
+			} else if addsLock(commit, pool) {
+				history = History{Pool: pool, Creator: commit.Author, Created: commit.Date}
+			}
+		case removesLock(commit, pool):
+			history = History{Pool: pool}
+			current = nil
+		case current != nil && changes(commit, path.Join(pool, claimFile)) && commit.Body != "":
+			current.Message = commit.Body
+		}
+	}
+	if current != nil {
+		history.Claims = append(history.Claims, *current)
+	}
+
+	return history, nil
+}
+
 func (l *locker) Owner(pool string) (string, string, string, error) {
-	if err := l.gitRepo.CloneOrPull(); err != nil {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if err := l.fetch(); err != nil {
 		return "", "", "", errors.Wrap(err, "failed to clone or pull")
 	}
 
@@ -121,44 +523,133 @@ func (l *locker) Owner(pool string) (string, string, string, error) {
 }
 
 func (l *locker) ReleaseLock(pool, user string) error {
-	if err := l.gitRepo.CloneOrPull(); err != nil {
+	return l.ReleaseLocks([]string{pool}, user)
+}
+
+// ReleaseLocks releases all of the pools in a single commit, or none of them
+// if any is not claimed
+func (l *locker) ReleaseLocks(pools []string, user string) error {
+	author := l.author(user)
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if err := l.pull(); err != nil {
 		return errors.Wrap(err, "failed to clone or pull")
 	}
 
-	locks, err := l.fs.Ls(filepath.Join(l.gitRepo.Dir(), pool, "claimed"))
-	if err != nil {
-		return errors.Wrap(err, "failed to list claimed locks")
+	locks := map[string]string{}
+	for _, pool := range pools {
+		maintenance, err := l.maintenance(pool)
+		if err != nil {
+			return err
+		}
+		if maintenance != nil {
+			return errors.Errorf("pool %s is disabled", pool)
+		}
+		lock, err := l.singleLock(pool, "claimed")
+		if err != nil {
+			return err
+		}
+		locks[pool] = lock
+	}
+	for _, pool := range pools {
+		if err := l.moveLock(pool, locks[pool], "claimed", "unclaimed"); err != nil {
+			return err
+		}
 	}
 
-	if len(locks) == 0 {
-		return errors.Errorf("no claimed locks for pool %s", pool)
-	} else if len(locks) > 1 {
-		return errors.Errorf("too many claimed locks for pool %s", pool)
+	if err := l.gitRepo.CommitAndPush("Claimer releasing "+strings.Join(pools, ", "), author); err != nil {
+		return errors.Wrap(err, "failed to commit and push")
 	}
+	return nil
+}
 
-	claimedLock := filepath.Join(l.gitRepo.Dir(), pool, "claimed", locks[0])
-	unclaimedLock := filepath.Join(l.gitRepo.Dir(), pool, "unclaimed", locks[0])
-	if err := l.fs.Mv(claimedLock, unclaimedLock); err != nil {
-		return errors.Wrap(err, "failed to move file")
+// RenamePool moves a pool to a new name, which may be in a different group,
+// keeping its lock, claim and metadata. History follows the pool to its new
+// name.
+func (l *locker) RenamePool(pool, newName, user string) error {
+	if !ValidPoolName(newName) {
+		return errors.Errorf("%s is not a valid pool name", newName)
+	}
+	author := l.author(user)
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if err := l.pull(); err != nil {
+		return errors.Wrap(err, "failed to clone or pull")
+	}
+
+	if err := l.checkPoolExists(pool); err != nil {
+		return err
+	}
+	if newName == pool || strings.HasPrefix(newName, pool+"/") {
+		return errors.Errorf("cannot move pool %s into itself", pool)
+	}
+	exists, err := l.fs.Exists(filepath.Join(l.gitRepo.Dir(), newName))
+	if err != nil {
+		return errors.Wrap(err, "failed to check for destination")
+	}
+	if exists {
+		return errors.Errorf("%s already exists", newName)
 	}
 
-	if err := l.gitRepo.CommitAndPush("Claimer releasing "+pool, user); err != nil {
+	if err := l.fs.Mv(filepath.Join(l.gitRepo.Dir(), pool), filepath.Join(l.gitRepo.Dir(), newName)); err != nil {
+		return errors.Wrap(err, "failed to move pool")
+	}
+	if err := l.renameLock(newName, path.Base(pool)); err != nil {
+		return err
+	}
+	if err := l.gitRepo.CommitAndPush("Claimer renaming "+pool+" to "+newName, author); err != nil {
 		return errors.Wrap(err, "failed to commit and push")
 	}
 	return nil
 }
 
+// renameLock renames the lock of a pool which was named after the pool's old
+// name, so that it stays named after the pool. Locks with other names are
+// left alone.
+func (l *locker) renameLock(pool, oldName string) error {
+	if path.Base(pool) == oldName {
+		return nil
+	}
+	for _, dir := range []string{"claimed", "unclaimed"} {
+		locks, err := l.fs.Ls(filepath.Join(l.gitRepo.Dir(), pool, dir))
+		if err != nil {
+			return errors.Wrapf(err, "failed to list %s locks", dir)
+		}
+		if contains(locks, oldName) {
+			lockDir := filepath.Join(l.gitRepo.Dir(), pool, dir)
+			if err := l.fs.Mv(filepath.Join(lockDir, oldName), filepath.Join(lockDir, path.Base(pool))); err != nil {
+				return errors.Wrap(err, "failed to rename lock")
+			}
+		}
+	}
+	return nil
+}
+
 func (l *locker) Status() ([]Lock, error) {
-	var locks []Lock
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
 
-	if err := l.gitRepo.CloneOrPull(); err != nil {
+	if err := l.fetch(); err != nil {
 		return nil, errors.Wrap(err, "failed to clone or pull")
 	}
 
-	pools, err := l.fs.LsDirs(l.gitRepo.Dir())
+	head, err := l.gitRepo.Head()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get head")
+	}
+	if head != "" && head == l.statusHead {
+		return append([]Lock(nil), l.statusCache...), nil
+	}
+
+	pools, err := l.fs.LsPools(l.gitRepo.Dir())
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list pools")
 	}
+
+	var locks []Lock
+	var claimedDirs []string
 	for _, pool := range pools {
 		claimedLocks, err := l.fs.Ls(filepath.Join(l.gitRepo.Dir(), pool, "claimed"))
 		if err != nil {
@@ -169,26 +660,253 @@ func (l *locker) Status() ([]Lock, error) {
 			return nil, errors.Wrap(err, "failed to list unclaimed locks")
 		}
 
-		if len(claimedLocks) == 0 && len(unclaimedLocks) == 1 {
-			locks = append(locks, Lock{
-				Name:    pool,
-				Claimed: false,
-			})
+		var lock Lock
+		switch {
+		case len(claimedLocks) == 0 && len(unclaimedLocks) == 1:
+			lock = Lock{Name: pool, Claimed: false}
+		case len(claimedLocks) == 1 && len(unclaimedLocks) == 0:
+			maintenance, err := l.maintenance(pool)
+			if err != nil {
+				return nil, err
+			}
+			if maintenance != nil {
+				lock = Lock{Name: pool, Maintenance: maintenance}
+				break
+			}
+			lock = Lock{Name: pool, Claimed: true}
+			claimedDirs = append(claimedDirs, path.Join(pool, "claimed"))
+		default:
+			continue
+		}
+
+		lock.Metadata, err = l.poolMetadata(pool)
+		if err != nil {
+			return nil, err
+		}
+		lock.Reservations, err = l.reservations(pool)
+		if err != nil {
+			return nil, err
+		}
+		locks = append(locks, lock)
+	}
+
+	if len(claimedDirs) > 0 {
+		commits, err := l.gitRepo.LatestCommits(claimedDirs)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get latest commits")
 		}
-		if len(claimedLocks) == 1 && len(unclaimedLocks) == 0 {
-			author, date, message, err := l.gitRepo.LatestCommit(pool)
+		for i, lock := range locks {
+			if !lock.Claimed {
+				continue
+			}
+			commit := commits[path.Join(lock.Name, "claimed")]
+			if renamedFrom(commit, lock.Name) != "" {
+				history, err := l.history(lock.Name)
+				if err != nil {
+					return nil, err
+				}
+				if n := len(history.Claims); n > 0 {
+					claim := history.Claims[n-1]
+					commit = git.Commit{Author: claim.Owner, Date: claim.Claimed, Body: claim.Message}
+				}
+			}
+			metadata, err := l.claimMetadata(lock.Name, commit.Author)
 			if err != nil {
-				return nil, errors.Wrap(err, "failed to get latest commit")
+				return nil, err
+			}
+			locks[i].Owner = commit.Author
+			locks[i].Date = commit.Date.Format(DateFormat)
+			locks[i].Message = commit.Body
+			if metadata.Message != "" {
+				locks[i].Message = metadata.Message
+			}
+			locks[i].Expires = metadata.Expires
+		}
+	}
+
+	l.statusHead = head
+	l.statusCache = locks
+	return append([]Lock(nil), locks...), nil
+}
+
+// author returns the git identity of a chat user. It may ask Slack for the
+// email, so it is called before taking the mutex.
+func (l *locker) author(user string) git.Identity {
+	return git.Identity{Name: user, Email: l.authors.Email(user)}
+}
+
+// fetch updates the clone for a read, unless it was updated less than
+// minFetchInterval ago
+func (l *locker) fetch() error {
+	if l.minFetchInterval > 0 && time.Since(l.lastFetch) < l.minFetchInterval {
+		return nil
+	}
+	if err := l.gitRepo.CloneOrPull(); err != nil {
+		return err
+	}
+	l.lastFetch = time.Now()
+	return nil
+}
+
+// pull updates the clone for a write. The next read pulls again, since a
+// failed write can leave changes behind in the working copy.
+func (l *locker) pull() error {
+	l.lastFetch = time.Time{}
+	return l.gitRepo.CloneOrPull()
+}
+
+// claimMetadata returns the metadata recorded for the current claim of the
+// pool. Metadata left behind by a previous owner is ignored.
+func (l *locker) claimMetadata(pool, owner string) (claimMetadata, error) {
+	metadata := claimMetadata{Owner: owner}
+
+	path := filepath.Join(l.gitRepo.Dir(), pool, claimFile)
+	exists, err := l.fs.Exists(path)
+	if err != nil {
+		return metadata, errors.Wrap(err, "failed to check for claim file")
+	}
+	if !exists {
+		return metadata, nil
+	}
+
+	contents, err := l.fs.ReadFile(path)
+	if err != nil {
+		return metadata, errors.Wrap(err, "failed to read claim file")
+	}
+	var existing claimMetadata
+	if err := yaml.Unmarshal(contents, &existing); err != nil {
+		return metadata, errors.Wrap(err, "failed to parse claim file")
+	}
+	if existing.Owner != owner {
+		return metadata, nil
+	}
+	return existing, nil
+}
+
+// poolMetadata returns the contents of pool.yml, which most pools do not have
+func (l *locker) poolMetadata(pool string) (Metadata, error) {
+	var metadata Metadata
+
+	path := filepath.Join(l.gitRepo.Dir(), pool, metadataFile)
+	exists, err := l.fs.Exists(path)
+	if err != nil {
+		return metadata, errors.Wrap(err, "failed to check for pool file")
+	}
+	if !exists {
+		return metadata, nil
+	}
+
+	contents, err := l.fs.ReadFile(path)
+	if err != nil {
+		return metadata, errors.Wrap(err, "failed to read pool file")
+	}
+	if err := yaml.Unmarshal(contents, &metadata); err != nil {
+		return metadata, errors.Wrap(err, "failed to parse pool file")
+	}
+	return metadata, nil
+}
+
+func (l *locker) writeClaimMetadata(pool string, metadata claimMetadata) error {
+	contents, err := yaml.Marshal(metadata)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal claim file")
+	}
+	if err := l.fs.WriteFile(filepath.Join(l.gitRepo.Dir(), pool, claimFile), contents); err != nil {
+		return errors.Wrap(err, "failed to write claim file")
+	}
+	return nil
+}
+
+func claimCommitMessage(pools []string, message string) string {
+	commitMessage := "Claimer claiming " + strings.Join(pools, ", ")
+	if message != "" {
+		commitMessage += "\n\n" + message
+	}
+	return commitMessage
+}
+
+func movesLockTo(commit git.Commit, pool, dir string) bool {
+	for _, change := range commit.Changes {
+		if (change.Status == "A" || change.Status == "R" && inPool(change.OldPath, pool)) && isLock(change.Path, pool, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+func addsLock(commit git.Commit, pool string) bool {
+	for _, change := range commit.Changes {
+		if change.Status == "A" && isLock(change.Path, pool, "unclaimed") {
+			return true
+		}
+	}
+	return false
+}
+
+func removesLock(commit git.Commit, pool string) bool {
+	for _, change := range commit.Changes {
+		if change.Status == "D" && (isLock(change.Path, pool, "claimed") || isLock(change.Path, pool, "unclaimed")) {
+			return true
+		}
+		if change.Status == "R" && !inPool(change.Path, pool) && (isLock(change.OldPath, pool, "claimed") || isLock(change.OldPath, pool, "unclaimed")) {
+			return true
+		}
+	}
+	return false
+}
+
+// renamedFrom returns the pool whose lock a commit moved into the pool, or
+// an empty string if the commit did not rename a pool to this one
+func renamedFrom(commit git.Commit, pool string) string {
+	for _, change := range commit.Changes {
+		if change.Status == "R" && !inPool(change.OldPath, pool) && (isLock(change.Path, pool, "claimed") || isLock(change.Path, pool, "unclaimed")) {
+			return path.Dir(path.Dir(change.OldPath))
+		}
+	}
+	return ""
+}
+
+// touching picks the commits from a log of the whole repo which touch a pool,
+// keeping only their changes inside it
+func touching(commits []git.Commit, pool string) []git.Commit {
+	var result []git.Commit
+	for _, commit := range commits {
+		var changes []git.Change
+		for _, change := range commit.Changes {
+			if inPool(change.Path, pool) || inPool(change.OldPath, pool) {
+				changes = append(changes, change)
 			}
-			locks = append(locks, Lock{
-				Name:    pool,
-				Claimed: true,
-				Owner:   author,
-				Date:    date,
-				Message: message,
-			})
+		}
+		if len(changes) > 0 {
+			commit.Changes = changes
+			result = append(result, commit)
+		}
+	}
+	return result
+}
+
+func changes(commit git.Commit, file string) bool {
+	for _, change := range commit.Changes {
+		if change.Path == file {
+			return true
 		}
 	}
+	return false
+}
+
+func contains(list []string, item string) bool {
+	for _, i := range list {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
+
+func inPool(file, pool string) bool {
+	return strings.HasPrefix(file, pool+"/")
+}
 
-	return locks, nil
+func isLock(file, pool, dir string) bool {
+	return path.Dir(file) == path.Join(pool, dir) && !strings.HasPrefix(path.Base(file), ".")
 }
@@ -5,21 +5,217 @@ import (
 
 	"errors"
 	"fmt"
+	"github.com/mdelillo/claimer/git"
 	"github.com/mdelillo/claimer/locker/lockerfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"path/filepath"
+	"strings"
+	"time"
 )
 
 var _ = Describe("Locker", func() {
 	var (
 		fs      *lockerfakes.FakeFs
 		gitRepo *lockerfakes.FakeGitRepo
+		authors *lockerfakes.FakeAuthors
 	)
 
 	BeforeEach(func() {
 		fs = new(lockerfakes.FakeFs)
 		gitRepo = new(lockerfakes.FakeGitRepo)
+		authors = new(lockerfakes.FakeAuthors)
+	})
+
+	Describe("ClaimAny", func() {
+		It("claims the first pool which is unclaimed", func() {
+			gitDir := "some-dir"
+			gitRepo.DirReturns(gitDir)
+			fs.LsPoolsReturns([]string{"pool-1", "pool-2", "pool-3"}, nil)
+			fs.LsStub = func(dir string) ([]string, error) {
+				switch dir {
+				case filepath.Join(gitDir, "pool-1", "claimed"), filepath.Join(gitDir, "pool-2", "unclaimed"), filepath.Join(gitDir, "pool-3", "unclaimed"):
+					return []string{"some-lock"}, nil
+				}
+				return []string{}, nil
+			}
+
+			locker := NewLocker(fs, gitRepo, authors, 0)
+			Expect(locker.ClaimAny([]string{"destroyed-pool", "pool-1", "pool-2", "pool-3"}, "some-user", "some-message")).To(Equal("pool-2"))
+
+			Expect(gitRepo.CloneOrPullCallCount()).To(Equal(1))
+
+			Expect(fs.MvCallCount()).To(Equal(1))
+			oldPath, newPath := fs.MvArgsForCall(0)
+			Expect(oldPath).To(Equal(filepath.Join(gitDir, "pool-2", "unclaimed", "some-lock")))
+			Expect(newPath).To(Equal(filepath.Join(gitDir, "pool-2", "claimed", "some-lock")))
+
+			Expect(gitRepo.CommitAndPushCallCount()).To(Equal(1))
+			message, author := gitRepo.CommitAndPushArgsForCall(0)
+			Expect(message).To(Equal("Claimer claiming pool-2\n\nsome-message"))
+			Expect(author).To(Equal(git.Identity{Name: "some-user"}))
+		})
+
+		Context("when every pool is claimed", func() {
+			It("returns an empty pool", func() {
+				fs.LsPoolsReturns([]string{"pool-1", "pool-2"}, nil)
+				fs.LsStub = func(dir string) ([]string, error) {
+					if filepath.Base(dir) == "claimed" {
+						return []string{"some-lock"}, nil
+					}
+					return []string{}, nil
+				}
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.ClaimAny([]string{"pool-1", "pool-2"}, "some-user", "")).To(BeEmpty())
+				Expect(gitRepo.CommitAndPushCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when cloning the repo fails", func() {
+			It("returns an error", func() {
+				gitRepo.CloneOrPullReturns(errors.New("some-error"))
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				_, err := locker.ClaimAny([]string{"some-pool"}, "", "")
+				Expect(err).To(MatchError("failed to clone or pull: some-error"))
+			})
+		})
+
+		Context("when listing the pools fails", func() {
+			It("returns an error", func() {
+				fs.LsPoolsReturns(nil, errors.New("some-error"))
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				_, err := locker.ClaimAny([]string{"some-pool"}, "", "")
+				Expect(err).To(MatchError("failed to list pools: some-error"))
+			})
+		})
+
+		Context("when listing claimed locks fails", func() {
+			It("returns an error", func() {
+				fs.LsPoolsReturns([]string{"some-pool"}, nil)
+				fs.LsReturns(nil, errors.New("some-error"))
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				_, err := locker.ClaimAny([]string{"some-pool"}, "", "")
+				Expect(err).To(MatchError("failed to list claimed locks: some-error"))
+			})
+		})
+
+		Context("when listing unclaimed locks fails", func() {
+			It("returns an error", func() {
+				fs.LsPoolsReturns([]string{"some-pool"}, nil)
+				fs.LsReturnsOnCall(1, nil, errors.New("some-error"))
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				_, err := locker.ClaimAny([]string{"some-pool"}, "", "")
+				Expect(err).To(MatchError("failed to list unclaimed locks: some-error"))
+			})
+		})
+
+		Context("when pushing fails", func() {
+			It("returns an error", func() {
+				fs.LsPoolsReturns([]string{"some-pool"}, nil)
+				fs.LsReturnsOnCall(1, []string{"some-lock"}, nil)
+				gitRepo.CommitAndPushReturns(errors.New("some-error"))
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				_, err := locker.ClaimAny([]string{"some-pool"}, "", "")
+				Expect(err).To(MatchError("failed to commit and push: some-error"))
+			})
+		})
+	})
+
+	Describe("ClaimLocks", func() {
+		It("claims every pool in a single commit", func() {
+			gitDir := "some-dir"
+			gitRepo.DirReturns(gitDir)
+			fs.LsReturns([]string{"some-lock"}, nil)
+
+			locker := NewLocker(fs, gitRepo, authors, 0)
+			Expect(locker.ClaimLocks([]string{"pool-1", "pool-2"}, "some-user", "some-message")).To(Succeed())
+
+			Expect(gitRepo.CloneOrPullCallCount()).To(Equal(1))
+
+			Expect(fs.MvCallCount()).To(Equal(2))
+			oldPath, newPath := fs.MvArgsForCall(0)
+			Expect(oldPath).To(Equal(filepath.Join(gitDir, "pool-1", "unclaimed", "some-lock")))
+			Expect(newPath).To(Equal(filepath.Join(gitDir, "pool-1", "claimed", "some-lock")))
+			oldPath, newPath = fs.MvArgsForCall(1)
+			Expect(oldPath).To(Equal(filepath.Join(gitDir, "pool-2", "unclaimed", "some-lock")))
+			Expect(newPath).To(Equal(filepath.Join(gitDir, "pool-2", "claimed", "some-lock")))
+
+			Expect(gitRepo.CommitAndPushCallCount()).To(Equal(1))
+			message, author := gitRepo.CommitAndPushArgsForCall(0)
+			Expect(message).To(Equal("Claimer claiming pool-1, pool-2\n\nsome-message"))
+			Expect(author).To(Equal(git.Identity{Name: "some-user"}))
+		})
+
+		It("looks up the email of the author before pulling", func() {
+			fs.LsReturns([]string{"some-lock"}, nil)
+			authors.EmailStub = func(string) string {
+				Expect(gitRepo.CloneOrPullCallCount()).To(Equal(0))
+				return "some-email"
+			}
+
+			locker := NewLocker(fs, gitRepo, authors, 0)
+			Expect(locker.ClaimLocks([]string{"pool-1"}, "some-user", "")).To(Succeed())
+
+			Expect(authors.EmailCallCount()).To(Equal(1))
+			_, author := gitRepo.CommitAndPushArgsForCall(0)
+			Expect(author).To(Equal(git.Identity{Name: "some-user", Email: "some-email"}))
+		})
+
+		Context("when one of the pools cannot be claimed", func() {
+			It("does not move any lock", func() {
+				fs.LsStub = func(dir string) ([]string, error) {
+					if strings.Contains(dir, "pool-2") {
+						return []string{}, nil
+					}
+					return []string{"some-lock"}, nil
+				}
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.ClaimLocks([]string{"pool-1", "pool-2"}, "some-user", "")).To(MatchError("no unclaimed locks for pool pool-2"))
+
+				Expect(fs.MvCallCount()).To(Equal(0))
+				Expect(gitRepo.CommitAndPushCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("ReleaseLocks", func() {
+		It("releases every pool in a single commit", func() {
+			gitDir := "some-dir"
+			gitRepo.DirReturns(gitDir)
+			fs.LsReturns([]string{"some-lock"}, nil)
+
+			locker := NewLocker(fs, gitRepo, authors, 0)
+			Expect(locker.ReleaseLocks([]string{"pool-1", "pool-2"}, "some-user")).To(Succeed())
+
+			Expect(fs.MvCallCount()).To(Equal(2))
+			oldPath, newPath := fs.MvArgsForCall(1)
+			Expect(oldPath).To(Equal(filepath.Join(gitDir, "pool-2", "claimed", "some-lock")))
+			Expect(newPath).To(Equal(filepath.Join(gitDir, "pool-2", "unclaimed", "some-lock")))
+
+			Expect(gitRepo.CommitAndPushCallCount()).To(Equal(1))
+			message, _ := gitRepo.CommitAndPushArgsForCall(0)
+			Expect(message).To(Equal("Claimer releasing pool-1, pool-2"))
+		})
+
+		Context("when one of the pools is not claimed", func() {
+			It("does not move any lock", func() {
+				fs.LsReturnsOnCall(0, []string{"some-lock"}, nil)
+				fs.LsReturnsOnCall(1, []string{}, nil)
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.ReleaseLocks([]string{"pool-1", "pool-2"}, "some-user")).To(MatchError("no claimed locks for pool pool-2"))
+
+				Expect(fs.MvCallCount()).To(Equal(0))
+				Expect(gitRepo.CommitAndPushCallCount()).To(Equal(0))
+			})
+		})
 	})
 
 	Describe("ClaimLock", func() {
@@ -33,7 +229,7 @@ var _ = Describe("Locker", func() {
 			gitRepo.DirReturns(gitDir)
 			fs.LsReturns([]string{lock}, nil)
 
-			locker := NewLocker(fs, gitRepo)
+			locker := NewLocker(fs, gitRepo, authors, 0)
 			Expect(locker.ClaimLock(pool, user, message)).To(Succeed())
 
 			Expect(gitRepo.CloneOrPullCallCount()).To(Equal(1))
@@ -46,10 +242,27 @@ var _ = Describe("Locker", func() {
 			Expect(oldPath).To(Equal(filepath.Join(gitDir, pool, "unclaimed", lock)))
 			Expect(newPath).To(Equal(filepath.Join(gitDir, pool, "claimed", lock)))
 
+			Expect(fs.RmCallCount()).To(Equal(1))
+			Expect(fs.RmArgsForCall(0)).To(Equal(filepath.Join(gitDir, pool, "claim.yml")))
+
 			actualMessage, actualUser := gitRepo.CommitAndPushArgsForCall(0)
 			Expect(gitRepo.CommitAndPushCallCount()).To(Equal(1))
 			Expect(actualMessage).To(Equal(fmt.Sprintf("Claimer claiming %s\n\n%s", pool, message)))
-			Expect(actualUser).To(Equal(user))
+			Expect(actualUser).To(Equal(git.Identity{Name: user}))
+		})
+
+		Context("when the email of the user is known", func() {
+			It("commits with that email", func() {
+				fs.LsReturns([]string{"some-lock"}, nil)
+				authors.EmailReturns("some-user@example.com")
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.ClaimLock("some-pool", "some-user", "")).To(Succeed())
+
+				Expect(authors.EmailArgsForCall(0)).To(Equal("some-user"))
+				_, author := gitRepo.CommitAndPushArgsForCall(0)
+				Expect(author).To(Equal(git.Identity{Name: "some-user", Email: "some-user@example.com"}))
+			})
 		})
 
 		Context("when the message is empty", func() {
@@ -62,7 +275,7 @@ var _ = Describe("Locker", func() {
 				gitRepo.DirReturns(gitDir)
 				fs.LsReturns([]string{lock}, nil)
 
-				locker := NewLocker(fs, gitRepo)
+				locker := NewLocker(fs, gitRepo, authors, 0)
 				Expect(locker.ClaimLock(pool, user, "")).To(Succeed())
 
 				Expect(gitRepo.CloneOrPullCallCount()).To(Equal(1))
@@ -78,7 +291,7 @@ var _ = Describe("Locker", func() {
 				message, actualUser := gitRepo.CommitAndPushArgsForCall(0)
 				Expect(gitRepo.CommitAndPushCallCount()).To(Equal(1))
 				Expect(message).To(Equal("Claimer claiming " + pool))
-				Expect(actualUser).To(Equal(user))
+				Expect(actualUser).To(Equal(git.Identity{Name: user}))
 			})
 		})
 
@@ -86,7 +299,7 @@ var _ = Describe("Locker", func() {
 			It("returns an error", func() {
 				gitRepo.CloneOrPullReturns(errors.New("some-error"))
 
-				locker := NewLocker(fs, gitRepo)
+				locker := NewLocker(fs, gitRepo, authors, 0)
 				Expect(locker.ClaimLock("", "", "")).To(MatchError("failed to clone or pull: some-error"))
 			})
 		})
@@ -95,7 +308,7 @@ var _ = Describe("Locker", func() {
 			It("returns an error", func() {
 				fs.LsReturns(nil, errors.New("some-error"))
 
-				locker := NewLocker(fs, gitRepo)
+				locker := NewLocker(fs, gitRepo, authors, 0)
 				Expect(locker.ClaimLock("", "", "")).To(MatchError("failed to list unclaimed locks: some-error"))
 			})
 		})
@@ -106,7 +319,7 @@ var _ = Describe("Locker", func() {
 
 				fs.LsReturns([]string{}, nil)
 
-				locker := NewLocker(fs, gitRepo)
+				locker := NewLocker(fs, gitRepo, authors, 0)
 				Expect(locker.ClaimLock(pool, "", "")).To(MatchError("no unclaimed locks for pool " + pool))
 			})
 		})
@@ -117,7 +330,7 @@ var _ = Describe("Locker", func() {
 
 				fs.LsReturns([]string{"some-lock", "some-other-lock"}, nil)
 
-				locker := NewLocker(fs, gitRepo)
+				locker := NewLocker(fs, gitRepo, authors, 0)
 				Expect(locker.ClaimLock(pool, "", "")).To(MatchError("too many unclaimed locks for pool " + pool))
 			})
 		})
@@ -127,22 +340,139 @@ var _ = Describe("Locker", func() {
 				fs.LsReturns([]string{"some-lock"}, nil)
 				fs.MvReturns(errors.New("some-error"))
 
-				locker := NewLocker(fs, gitRepo)
+				locker := NewLocker(fs, gitRepo, authors, 0)
 				Expect(locker.ClaimLock("", "", "")).To(MatchError("failed to move file: some-error"))
 			})
 		})
 
+		Context("when removing the claim file fails", func() {
+			It("returns an error", func() {
+				fs.LsReturns([]string{"some-lock"}, nil)
+				fs.RmReturns(errors.New("some-error"))
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.ClaimLock("", "", "")).To(MatchError("failed to remove claim file: some-error"))
+			})
+		})
+
 		Context("when pushing fails", func() {
 			It("returns an error", func() {
 				fs.LsReturns([]string{"some-lock"}, nil)
 				gitRepo.CommitAndPushReturns(errors.New("some-error"))
 
-				locker := NewLocker(fs, gitRepo)
+				locker := NewLocker(fs, gitRepo, authors, 0)
 				Expect(locker.ClaimLock("", "", "")).To(MatchError("failed to commit and push: some-error"))
 			})
 		})
 	})
 
+	Describe("AnnotateLock", func() {
+		It("records the message in the claim file", func() {
+			pool := "some-pool"
+			gitDir := "some-dir"
+			user := "some-user"
+			message := "some-message"
+
+			gitRepo.DirReturns(gitDir)
+
+			locker := NewLocker(fs, gitRepo, authors, 0)
+			Expect(locker.AnnotateLock(pool, user, message)).To(Succeed())
+
+			Expect(gitRepo.CloneOrPullCallCount()).To(Equal(1))
+
+			Expect(fs.WriteFileCallCount()).To(Equal(1))
+			path, contents := fs.WriteFileArgsForCall(0)
+			Expect(path).To(Equal(filepath.Join(gitDir, pool, "claim.yml")))
+			Expect(string(contents)).To(Equal("owner: some-user\nmessage: some-message\n"))
+
+			actualMessage, actualUser := gitRepo.CommitAndPushArgsForCall(0)
+			Expect(gitRepo.CommitAndPushCallCount()).To(Equal(1))
+			Expect(actualMessage).To(Equal(fmt.Sprintf("Claimer annotating %s\n\n%s", pool, message)))
+			Expect(actualUser).To(Equal(git.Identity{Name: user}))
+		})
+
+		Context("when the claim file already belongs to the user", func() {
+			It("keeps the existing expiry", func() {
+				fs.ExistsReturns(true, nil)
+				fs.ReadFileReturns([]byte("owner: some-user\nexpires: 2017-03-01T12:00:00Z\n"), nil)
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.AnnotateLock("some-pool", "some-user", "some-message")).To(Succeed())
+
+				_, contents := fs.WriteFileArgsForCall(0)
+				Expect(string(contents)).To(Equal("owner: some-user\nmessage: some-message\nexpires: 2017-03-01T12:00:00Z\n"))
+			})
+		})
+
+		Context("when the claim file belongs to a previous owner", func() {
+			It("discards the existing metadata", func() {
+				fs.ExistsReturns(true, nil)
+				fs.ReadFileReturns([]byte("owner: some-other-user\nexpires: 2017-03-01T12:00:00Z\n"), nil)
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.AnnotateLock("some-pool", "some-user", "some-message")).To(Succeed())
+
+				_, contents := fs.WriteFileArgsForCall(0)
+				Expect(string(contents)).To(Equal("owner: some-user\nmessage: some-message\n"))
+			})
+		})
+
+		Context("when cloning the repo fails", func() {
+			It("returns an error", func() {
+				gitRepo.CloneOrPullReturns(errors.New("some-error"))
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.AnnotateLock("", "", "")).To(MatchError("failed to clone or pull: some-error"))
+			})
+		})
+
+		Context("when reading the claim file fails", func() {
+			It("returns an error", func() {
+				fs.ExistsReturns(true, nil)
+				fs.ReadFileReturns(nil, errors.New("some-error"))
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.AnnotateLock("", "", "")).To(MatchError("failed to read claim file: some-error"))
+			})
+		})
+
+		Context("when writing the claim file fails", func() {
+			It("returns an error", func() {
+				fs.WriteFileReturns(errors.New("some-error"))
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.AnnotateLock("", "", "")).To(MatchError("failed to write claim file: some-error"))
+			})
+		})
+
+		Context("when pushing fails", func() {
+			It("returns an error", func() {
+				gitRepo.CommitAndPushReturns(errors.New("some-error"))
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.AnnotateLock("", "", "")).To(MatchError("failed to commit and push: some-error"))
+			})
+		})
+	})
+
+	Describe("ValidPoolName", func() {
+		It("accepts clean relative paths", func() {
+			Expect(ValidPoolName("some-pool")).To(BeTrue())
+			Expect(ValidPoolName("aws/us-east/env-1")).To(BeTrue())
+		})
+
+		It("refuses names which leave the repo or are not clean", func() {
+			for _, name := range []string{"", "/some-pool", "../some-pool", "aws/../../some-pool", "..", "aws//env-1", "aws/env-1/", "./some-pool"} {
+				Expect(ValidPoolName(name)).To(BeFalse(), name)
+			}
+		})
+
+		It("refuses names with hidden parts", func() {
+			Expect(ValidPoolName(".git/hooks")).To(BeFalse())
+			Expect(ValidPoolName("aws/.env-1")).To(BeFalse())
+		})
+	})
+
 	Describe("CreatePool", func() {
 		It("creates a pool with an unclaimed lock", func() {
 			pool := "some-pool"
@@ -151,8 +481,8 @@ var _ = Describe("Locker", func() {
 
 			gitRepo.DirReturns(gitDir)
 
-			locker := NewLocker(fs, gitRepo)
-			Expect(locker.CreatePool(pool, user)).To(Succeed())
+			locker := NewLocker(fs, gitRepo, authors, 0)
+			Expect(locker.CreatePool(pool, user, Metadata{})).To(Succeed())
 
 			Expect(gitRepo.CloneOrPullCallCount()).To(Equal(1))
 
@@ -164,15 +494,51 @@ var _ = Describe("Locker", func() {
 			message, actualUser := gitRepo.CommitAndPushArgsForCall(0)
 			Expect(gitRepo.CommitAndPushCallCount()).To(Equal(1))
 			Expect(message).To(Equal("Claimer creating " + pool))
-			Expect(actualUser).To(Equal(user))
+			Expect(actualUser).To(Equal(git.Identity{Name: user}))
+
+			Expect(fs.WriteFileCallCount()).To(Equal(0))
+		})
+
+		It("writes the metadata of the pool to pool.yml", func() {
+			gitRepo.DirReturns("some-dir")
+
+			locker := NewLocker(fs, gitRepo, authors, 0)
+			Expect(locker.CreatePool("some-pool", "some-user", Metadata{
+				Description: "some description",
+				Tags:        []string{"some-tag", "some-other-tag"},
+			})).To(Succeed())
+
+			Expect(fs.WriteFileCallCount()).To(Equal(1))
+			path, contents := fs.WriteFileArgsForCall(0)
+			Expect(path).To(Equal(filepath.Join("some-dir", "some-pool", "pool.yml")))
+			Expect(contents).To(MatchYAML("description: some description\ntags: [some-tag, some-other-tag]"))
+			Expect(gitRepo.CommitAndPushCallCount()).To(Equal(1))
 		})
 
+		Context("when the name is not a valid pool name", func() {
+			It("returns an error", func() {
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.CreatePool("../some-pool", "", Metadata{})).To(MatchError("../some-pool is not a valid pool name"))
+				Expect(gitRepo.CloneOrPullCallCount()).To(Equal(0))
+				Expect(fs.TouchCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when writing pool.yml fails", func() {
+			It("returns an error", func() {
+				fs.WriteFileReturns(errors.New("some-error"))
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.CreatePool("some-pool", "", Metadata{Team: "some-team"})).To(MatchError("failed to write pool file: some-error"))
+				Expect(gitRepo.CommitAndPushCallCount()).To(Equal(0))
+			})
+		})
 		Context("when cloning the repo fails", func() {
 			It("returns an error", func() {
 				gitRepo.CloneOrPullReturns(errors.New("some-error"))
 
-				locker := NewLocker(fs, gitRepo)
-				Expect(locker.CreatePool("", "")).To(MatchError("failed to clone or pull: some-error"))
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.CreatePool("some-pool", "", Metadata{})).To(MatchError("failed to clone or pull: some-error"))
 			})
 		})
 
@@ -180,8 +546,8 @@ var _ = Describe("Locker", func() {
 			It("returns an error", func() {
 				fs.TouchReturnsOnCall(0, errors.New("some-error"))
 
-				locker := NewLocker(fs, gitRepo)
-				Expect(locker.CreatePool("", "")).To(MatchError("failed to touch 'claimed/.gitkeep': some-error"))
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.CreatePool("some-pool", "", Metadata{})).To(MatchError("failed to touch 'claimed/.gitkeep': some-error"))
 			})
 		})
 
@@ -189,8 +555,8 @@ var _ = Describe("Locker", func() {
 			It("returns an error", func() {
 				fs.TouchReturnsOnCall(1, errors.New("some-error"))
 
-				locker := NewLocker(fs, gitRepo)
-				Expect(locker.CreatePool("", "")).To(MatchError("failed to touch 'unclaimed/.gitkeep': some-error"))
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.CreatePool("some-pool", "", Metadata{})).To(MatchError("failed to touch 'unclaimed/.gitkeep': some-error"))
 			})
 		})
 
@@ -198,8 +564,8 @@ var _ = Describe("Locker", func() {
 			It("returns an error", func() {
 				fs.TouchReturnsOnCall(2, errors.New("some-error"))
 
-				locker := NewLocker(fs, gitRepo)
-				Expect(locker.CreatePool("", "")).To(MatchError("failed to touch lock file: some-error"))
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.CreatePool("some-pool", "", Metadata{})).To(MatchError("failed to touch lock file: some-error"))
 			})
 		})
 
@@ -207,8 +573,8 @@ var _ = Describe("Locker", func() {
 			It("returns an error", func() {
 				gitRepo.CommitAndPushReturns(errors.New("some-error"))
 
-				locker := NewLocker(fs, gitRepo)
-				Expect(locker.CreatePool("", "")).To(MatchError("failed to commit and push: some-error"))
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.CreatePool("some-pool", "", Metadata{})).To(MatchError("failed to commit and push: some-error"))
 			})
 		})
 	})
@@ -221,7 +587,7 @@ var _ = Describe("Locker", func() {
 
 			gitRepo.DirReturns(gitDir)
 
-			locker := NewLocker(fs, gitRepo)
+			locker := NewLocker(fs, gitRepo, authors, 0)
 			Expect(locker.DestroyPool(pool, user)).To(Succeed())
 
 			Expect(gitRepo.CloneOrPullCallCount()).To(Equal(1))
@@ -232,14 +598,14 @@ var _ = Describe("Locker", func() {
 			message, actualUser := gitRepo.CommitAndPushArgsForCall(0)
 			Expect(gitRepo.CommitAndPushCallCount()).To(Equal(1))
 			Expect(message).To(Equal("Claimer destroying " + pool))
-			Expect(actualUser).To(Equal(user))
+			Expect(actualUser).To(Equal(git.Identity{Name: user}))
 		})
 
 		Context("when cloning the repo fails", func() {
 			It("returns an error", func() {
 				gitRepo.CloneOrPullReturns(errors.New("some-error"))
 
-				locker := NewLocker(fs, gitRepo)
+				locker := NewLocker(fs, gitRepo, authors, 0)
 				Expect(locker.DestroyPool("", "")).To(MatchError("failed to clone or pull: some-error"))
 			})
 		})
@@ -248,7 +614,7 @@ var _ = Describe("Locker", func() {
 			It("returns an error", func() {
 				fs.RmReturns(errors.New("some-error"))
 
-				locker := NewLocker(fs, gitRepo)
+				locker := NewLocker(fs, gitRepo, authors, 0)
 				Expect(locker.DestroyPool("", "")).To(MatchError("failed to remove directory: some-error"))
 			})
 		})
@@ -257,12 +623,561 @@ var _ = Describe("Locker", func() {
 			It("returns an error", func() {
 				gitRepo.CommitAndPushReturns(errors.New("some-error"))
 
-				locker := NewLocker(fs, gitRepo)
+				locker := NewLocker(fs, gitRepo, authors, 0)
 				Expect(locker.DestroyPool("", "")).To(MatchError("failed to commit and push: some-error"))
 			})
 		})
 	})
 
+	Describe("RenamePool", func() {
+		var gitDir string
+
+		BeforeEach(func() {
+			gitDir = "some-dir"
+			gitRepo.DirReturns(gitDir)
+			fs.LsPoolsReturns([]string{"some-pool", "some-other-pool"}, nil)
+		})
+
+		It("moves the pool and commits", func() {
+			user := "some-user"
+
+			locker := NewLocker(fs, gitRepo, authors, 0)
+			Expect(locker.RenamePool("some-pool", "some-group/some-new-pool", user)).To(Succeed())
+
+			Expect(gitRepo.CloneOrPullCallCount()).To(Equal(1))
+			Expect(fs.LsPoolsArgsForCall(0)).To(Equal(gitDir))
+			Expect(fs.ExistsArgsForCall(0)).To(Equal(filepath.Join(gitDir, "some-group", "some-new-pool")))
+
+			Expect(fs.MvCallCount()).To(Equal(1))
+			src, dst := fs.MvArgsForCall(0)
+			Expect(src).To(Equal(filepath.Join(gitDir, "some-pool")))
+			Expect(dst).To(Equal(filepath.Join(gitDir, "some-group", "some-new-pool")))
+
+			Expect(gitRepo.CommitAndPushCallCount()).To(Equal(1))
+			message, actualUser := gitRepo.CommitAndPushArgsForCall(0)
+			Expect(message).To(Equal("Claimer renaming some-pool to some-group/some-new-pool"))
+			Expect(actualUser).To(Equal(git.Identity{Name: user}))
+		})
+
+		It("renames a lock named after the pool along with it", func() {
+			fs.LsStub = func(dir string) ([]string, error) {
+				if dir == filepath.Join(gitDir, "some-group", "some-new-pool", "claimed") {
+					return []string{"some-pool"}, nil
+				}
+				return nil, nil
+			}
+
+			locker := NewLocker(fs, gitRepo, authors, 0)
+			Expect(locker.RenamePool("some-pool", "some-group/some-new-pool", "some-user")).To(Succeed())
+
+			Expect(fs.MvCallCount()).To(Equal(2))
+			src, dst := fs.MvArgsForCall(1)
+			Expect(src).To(Equal(filepath.Join(gitDir, "some-group", "some-new-pool", "claimed", "some-pool")))
+			Expect(dst).To(Equal(filepath.Join(gitDir, "some-group", "some-new-pool", "claimed", "some-new-pool")))
+			Expect(gitRepo.CommitAndPushCallCount()).To(Equal(1))
+		})
+
+		It("leaves locks named after something else alone", func() {
+			fs.LsReturns([]string{"some-lock"}, nil)
+
+			locker := NewLocker(fs, gitRepo, authors, 0)
+			Expect(locker.RenamePool("some-pool", "some-new-pool", "some-user")).To(Succeed())
+			Expect(fs.MvCallCount()).To(Equal(1))
+		})
+
+		Context("when listing the locks fails", func() {
+			It("returns an error", func() {
+				fs.LsReturns(nil, errors.New("some-error"))
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.RenamePool("some-pool", "some-new-pool", "")).To(MatchError("failed to list claimed locks: some-error"))
+				Expect(gitRepo.CommitAndPushCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the pool does not exist", func() {
+			It("returns an error", func() {
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.RenamePool("some-missing-pool", "some-new-pool", "")).To(MatchError("pool some-missing-pool does not exist"))
+				Expect(fs.MvCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the new name is not a valid pool name", func() {
+			It("returns an error", func() {
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.RenamePool("some-pool", "some-group/../../some-new-pool", "")).To(MatchError("some-group/../../some-new-pool is not a valid pool name"))
+				Expect(fs.MvCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the new name is inside the pool", func() {
+			It("returns an error", func() {
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.RenamePool("some-pool", "some-pool/some-new-pool", "")).To(MatchError("cannot move pool some-pool into itself"))
+				Expect(fs.MvCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the destination exists", func() {
+			It("returns an error", func() {
+				fs.ExistsReturns(true, nil)
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.RenamePool("some-pool", "some-other-pool", "")).To(MatchError("some-other-pool already exists"))
+				Expect(fs.MvCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when cloning the repo fails", func() {
+			It("returns an error", func() {
+				gitRepo.CloneOrPullReturns(errors.New("some-error"))
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.RenamePool("some-pool", "some-new-pool", "")).To(MatchError("failed to clone or pull: some-error"))
+			})
+		})
+
+		Context("when listing pools fails", func() {
+			It("returns an error", func() {
+				fs.LsPoolsReturns(nil, errors.New("some-error"))
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.RenamePool("some-pool", "some-new-pool", "")).To(MatchError("failed to list pools: some-error"))
+			})
+		})
+
+		Context("when checking for the destination fails", func() {
+			It("returns an error", func() {
+				fs.ExistsReturns(false, errors.New("some-error"))
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.RenamePool("some-pool", "some-new-pool", "")).To(MatchError("failed to check for destination: some-error"))
+			})
+		})
+
+		Context("when moving the pool fails", func() {
+			It("returns an error", func() {
+				fs.MvReturns(errors.New("some-error"))
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.RenamePool("some-pool", "some-new-pool", "")).To(MatchError("failed to move pool: some-error"))
+			})
+		})
+
+		Context("when pushing fails", func() {
+			It("returns an error", func() {
+				gitRepo.CommitAndPushReturns(errors.New("some-error"))
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.RenamePool("some-pool", "some-new-pool", "")).To(MatchError("failed to commit and push: some-error"))
+			})
+		})
+	})
+
+	Describe("ExtendLock", func() {
+		It("records the expiry in the claim file", func() {
+			pool := "some-pool"
+			gitDir := "some-dir"
+			user := "some-user"
+			expires := time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC)
+
+			gitRepo.DirReturns(gitDir)
+			fs.ExistsReturns(true, nil)
+			fs.ReadFileReturns([]byte("owner: some-user\nmessage: some-message\n"), nil)
+
+			locker := NewLocker(fs, gitRepo, authors, 0)
+			Expect(locker.ExtendLock(pool, user, expires)).To(Succeed())
+
+			Expect(gitRepo.CloneOrPullCallCount()).To(Equal(1))
+
+			Expect(fs.ReadFileArgsForCall(0)).To(Equal(filepath.Join(gitDir, pool, "claim.yml")))
+			Expect(fs.WriteFileCallCount()).To(Equal(1))
+			path, contents := fs.WriteFileArgsForCall(0)
+			Expect(path).To(Equal(filepath.Join(gitDir, pool, "claim.yml")))
+			Expect(string(contents)).To(Equal("owner: some-user\nmessage: some-message\nexpires: 2017-03-01T12:00:00Z\n"))
+
+			message, actualUser := gitRepo.CommitAndPushArgsForCall(0)
+			Expect(gitRepo.CommitAndPushCallCount()).To(Equal(1))
+			Expect(message).To(Equal("Claimer extending some-pool until Wed Mar 1 12:00:00 2017 +0000"))
+			Expect(actualUser).To(Equal(git.Identity{Name: user}))
+		})
+
+		Context("when cloning the repo fails", func() {
+			It("returns an error", func() {
+				gitRepo.CloneOrPullReturns(errors.New("some-error"))
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.ExtendLock("", "", time.Time{})).To(MatchError("failed to clone or pull: some-error"))
+			})
+		})
+
+		Context("when checking for the claim file fails", func() {
+			It("returns an error", func() {
+				fs.ExistsReturns(false, errors.New("some-error"))
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.ExtendLock("", "", time.Time{})).To(MatchError("failed to check for claim file: some-error"))
+			})
+		})
+
+		Context("when the claim file is invalid", func() {
+			It("returns an error", func() {
+				fs.ExistsReturns(true, nil)
+				fs.ReadFileReturns([]byte("some-invalid-yaml"), nil)
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.ExtendLock("", "", time.Time{})).To(MatchError(ContainSubstring("failed to parse claim file: ")))
+			})
+		})
+
+		Context("when writing the claim file fails", func() {
+			It("returns an error", func() {
+				fs.WriteFileReturns(errors.New("some-error"))
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.ExtendLock("", "", time.Time{})).To(MatchError("failed to write claim file: some-error"))
+			})
+		})
+
+		Context("when pushing fails", func() {
+			It("returns an error", func() {
+				gitRepo.CommitAndPushReturns(errors.New("some-error"))
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.ExtendLock("", "", time.Time{})).To(MatchError("failed to commit and push: some-error"))
+			})
+		})
+	})
+
+	Describe("History", func() {
+		var date func(day int) time.Time
+
+		BeforeEach(func() {
+			date = func(day int) time.Time {
+				return time.Date(2017, 3, day, 12, 0, 0, 0, time.UTC)
+			}
+		})
+
+		It("returns the claims of the pool since it was created", func() {
+			pool := "some-pool"
+
+			gitRepo.LogReturns([]git.Commit{
+				{
+					Author:  "some-other-user",
+					Date:    date(6),
+					Subject: "Claimer claiming some-pool",
+					Changes: []git.Change{{Status: "R", OldPath: "some-pool/unclaimed/lock", Path: "some-pool/claimed/lock"}},
+				},
+				{
+					Author:  "some-releaser",
+					Date:    date(5),
+					Subject: "Claimer releasing some-pool",
+					Changes: []git.Change{
+						{Status: "D", Path: "some-pool/claimed/lock"},
+						{Status: "A", Path: "some-pool/unclaimed/lock"},
+					},
+				},
+				{
+					Author:  "some-user",
+					Date:    date(4),
+					Subject: "Claimer annotating some-pool",
+					Body:    "some updated message",
+					Changes: []git.Change{{Status: "A", Path: "some-pool/claim.yml"}},
+				},
+				{
+					Author:  "some-user",
+					Date:    date(3),
+					Subject: "Claimer claiming some-pool",
+					Body:    "some message",
+					Changes: []git.Change{{Status: "R", OldPath: "some-pool/unclaimed/lock", Path: "some-pool/claimed/lock"}},
+				},
+				{
+					Author:  "some-creator",
+					Date:    date(2),
+					Subject: "Claimer creating some-pool",
+					Changes: []git.Change{
+						{Status: "A", Path: "some-pool/claimed/.gitkeep"},
+						{Status: "A", Path: "some-pool/unclaimed/.gitkeep"},
+						{Status: "A", Path: "some-pool/unclaimed/lock"},
+					},
+				},
+				{
+					Author:  "some-destroyer",
+					Date:    date(1),
+					Subject: "Claimer destroying some-pool",
+					Changes: []git.Change{
+						{Status: "D", Path: "some-pool/claimed/.gitkeep"},
+						{Status: "D", Path: "some-pool/claimed/lock"},
+						{Status: "D", Path: "some-pool/unclaimed/.gitkeep"},
+					},
+				},
+				{
+					Author:  "some-old-user",
+					Date:    date(1),
+					Subject: "Claimer claiming some-pool",
+					Changes: []git.Change{{Status: "R", OldPath: "some-pool/unclaimed/lock", Path: "some-pool/claimed/lock"}},
+				},
+			}, nil)
+
+			locker := NewLocker(fs, gitRepo, authors, 0)
+			history, err := locker.History(pool)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(gitRepo.CloneOrPullCallCount()).To(Equal(1))
+			Expect(gitRepo.LogArgsForCall(0)).To(Equal(pool))
+
+			Expect(history).To(Equal(History{
+				Pool:    pool,
+				Creator: "some-creator",
+				Created: date(2),
+				Claims: []Claim{
+					{
+						Owner:      "some-user",
+						Message:    "some updated message",
+						Claimed:    date(3),
+						Released:   date(5),
+						ReleasedBy: "some-releaser",
+					},
+					{
+						Owner:   "some-other-user",
+						Claimed: date(6),
+					},
+				},
+			}))
+			Expect(history.Claims[0].Active()).To(BeFalse())
+			Expect(history.Claims[0].Duration(date(10))).To(Equal(48 * time.Hour))
+			Expect(history.Claims[1].Active()).To(BeTrue())
+			Expect(history.Claims[1].Duration(date(10))).To(Equal(96 * time.Hour))
+		})
+
+		Context("when the pool was disabled", func() {
+			It("does not count the maintenance as a claim", func() {
+				gitRepo.LogReturns([]git.Commit{
+					{
+						Author:  "some-enabler",
+						Date:    date(4),
+						Subject: "Claimer enabling some-pool",
+						Changes: []git.Change{
+							{Status: "R", OldPath: "some-pool/claimed/lock", Path: "some-pool/unclaimed/lock"},
+							{Status: "D", Path: "some-pool/disabled.yml"},
+						},
+					},
+					{
+						Author:  "some-disabler",
+						Date:    date(3),
+						Subject: "Claimer disabling some-pool",
+						Body:    "repaving",
+						Changes: []git.Change{
+							{Status: "R", OldPath: "some-pool/unclaimed/lock", Path: "some-pool/claimed/lock"},
+							{Status: "A", Path: "some-pool/disabled.yml"},
+						},
+					},
+					{
+						Author:  "some-user",
+						Date:    date(2),
+						Subject: "Claimer releasing some-pool",
+						Changes: []git.Change{{Status: "R", OldPath: "some-pool/claimed/lock", Path: "some-pool/unclaimed/lock"}},
+					},
+					{
+						Author:  "some-user",
+						Date:    date(1),
+						Subject: "Claimer claiming some-pool",
+						Changes: []git.Change{{Status: "R", OldPath: "some-pool/unclaimed/lock", Path: "some-pool/claimed/lock"}},
+					},
+				}, nil)
+
+				history, err := NewLocker(fs, gitRepo, authors, 0).History("some-pool")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(history.Claims).To(Equal([]Claim{
+					{Owner: "some-user", Claimed: date(1), Released: date(2), ReleasedBy: "some-user"},
+				}))
+			})
+		})
+
+		Context("when the pool was renamed", func() {
+			It("continues the history of the old name", func() {
+				rename := git.Commit{
+					Hash:    "some-rename-hash",
+					Author:  "some-renamer",
+					Date:    date(4),
+					Subject: "Claimer renaming some-old-pool to some-pool",
+					Changes: []git.Change{{Status: "R", OldPath: "some-old-pool/claimed/lock", Path: "some-pool/claimed/lock"}},
+				}
+				gitRepo.LogStub = func(pool string) ([]git.Commit, error) {
+					if pool == "some-pool" {
+						return []git.Commit{rename}, nil
+					}
+					return []git.Commit{
+						{
+							Author:  "some-recreator",
+							Date:    date(5),
+							Subject: "Claimer creating some-old-pool",
+							Changes: []git.Change{{Status: "A", Path: "some-old-pool/unclaimed/lock"}},
+						},
+						rename,
+						{
+							Author:  "some-user",
+							Date:    date(3),
+							Subject: "Claimer claiming some-old-pool",
+							Body:    "some message",
+							Changes: []git.Change{{Status: "R", OldPath: "some-old-pool/unclaimed/lock", Path: "some-old-pool/claimed/lock"}},
+						},
+						{
+							Author:  "some-creator",
+							Date:    date(2),
+							Subject: "Claimer creating some-old-pool",
+							Changes: []git.Change{{Status: "A", Path: "some-old-pool/unclaimed/lock"}},
+						},
+					}, nil
+				}
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				history, err := locker.History("some-pool")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(gitRepo.LogCallCount()).To(Equal(2))
+				Expect(gitRepo.LogArgsForCall(1)).To(Equal("some-old-pool"))
+				Expect(history).To(Equal(History{
+					Pool:    "some-pool",
+					Creator: "some-creator",
+					Created: date(2),
+					Claims:  []Claim{{Owner: "some-user", Message: "some message", Claimed: date(3)}},
+				}))
+
+				history, err = locker.History("some-old-pool")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(history).To(Equal(History{Pool: "some-old-pool", Creator: "some-recreator", Created: date(5)}))
+			})
+		})
+
+		Context("when cloning the repo fails", func() {
+			It("returns an error", func() {
+				gitRepo.CloneOrPullReturns(errors.New("some-error"))
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				_, err := locker.History("")
+				Expect(err).To(MatchError("failed to clone or pull: some-error"))
+			})
+		})
+
+		Context("when getting the log fails", func() {
+			It("returns an error", func() {
+				gitRepo.LogReturns(nil, errors.New("some-error"))
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				_, err := locker.History("")
+				Expect(err).To(MatchError("failed to get log: some-error"))
+			})
+		})
+	})
+
+	Describe("Histories", func() {
+		It("returns the history of every pool", func() {
+			gitDir := "some-dir"
+			gitRepo.DirReturns(gitDir)
+			fs.LsPoolsReturns([]string{"pool-1", "pool-2"}, nil)
+			gitRepo.LogReturns([]git.Commit{
+				{
+					Author: "some-user",
+					Date:   time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC),
+					Changes: []git.Change{
+						{Status: "R", OldPath: "pool-1/unclaimed/lock", Path: "pool-1/claimed/lock"},
+						{Status: "R", OldPath: "pool-2/unclaimed/lock", Path: "pool-2/claimed/lock"},
+					},
+				},
+				{
+					Author:  "some-other-user",
+					Date:    time.Date(2017, 2, 1, 12, 0, 0, 0, time.UTC),
+					Changes: []git.Change{{Status: "A", Path: "pool-3/unclaimed/lock"}},
+				},
+			}, nil)
+
+			locker := NewLocker(fs, gitRepo, authors, 0)
+			histories, err := locker.Histories()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(gitRepo.CloneOrPullCallCount()).To(Equal(1))
+			Expect(fs.LsPoolsArgsForCall(0)).To(Equal(gitDir))
+
+			Expect(histories).To(HaveLen(2))
+			Expect(histories[0].Pool).To(Equal("pool-1"))
+			Expect(histories[0].Claims).To(HaveLen(1))
+			Expect(histories[0].Claims[0].Owner).To(Equal("some-user"))
+			Expect(histories[1].Pool).To(Equal("pool-2"))
+			Expect(histories[1].Claims).To(HaveLen(1))
+		})
+
+		It("reads the log once for all of the pools", func() {
+			fs.LsPoolsReturns([]string{"pool-1", "pool-2", "pool-3"}, nil)
+
+			locker := NewLocker(fs, gitRepo, authors, 0)
+			_, err := locker.Histories()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(gitRepo.LogCallCount()).To(Equal(1))
+			Expect(gitRepo.LogArgsForCall(0)).To(Equal(""))
+		})
+
+		It("carries on the history of renamed pools", func() {
+			fs.LsPoolsReturns([]string{"pool-x"}, nil)
+			gitRepo.LogReturns([]git.Commit{
+				{
+					Hash:    "rename",
+					Author:  "some-user",
+					Date:    time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC),
+					Changes: []git.Change{{Status: "R", OldPath: "pool-1/unclaimed/pool-1", Path: "pool-x/unclaimed/pool-1"}},
+				},
+				{
+					Hash:    "create",
+					Author:  "some-creator",
+					Date:    time.Date(2017, 2, 1, 12, 0, 0, 0, time.UTC),
+					Changes: []git.Change{{Status: "A", Path: "pool-1/unclaimed/pool-1"}},
+				},
+			}, nil)
+
+			locker := NewLocker(fs, gitRepo, authors, 0)
+			histories, err := locker.Histories()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(histories).To(HaveLen(1))
+			Expect(histories[0].Pool).To(Equal("pool-x"))
+			Expect(histories[0].Creator).To(Equal("some-creator"))
+			Expect(gitRepo.LogCallCount()).To(Equal(1))
+		})
+
+		Context("when cloning the repo fails", func() {
+			It("returns an error", func() {
+				gitRepo.CloneOrPullReturns(errors.New("some-error"))
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				_, err := locker.Histories()
+				Expect(err).To(MatchError("failed to clone or pull: some-error"))
+			})
+		})
+
+		Context("when listing the git repo fails", func() {
+			It("returns an error", func() {
+				fs.LsPoolsReturns(nil, errors.New("some-error"))
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				_, err := locker.Histories()
+				Expect(err).To(MatchError("failed to list pools: some-error"))
+			})
+		})
+
+		Context("when getting the log fails", func() {
+			It("returns an error", func() {
+				fs.LsPoolsReturns([]string{"some-pool"}, nil)
+				gitRepo.LogReturns(nil, errors.New("some-error"))
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				_, err := locker.Histories()
+				Expect(err).To(MatchError("failed to get log: some-error"))
+			})
+		})
+	})
+
 	Describe("ReleaseLock", func() {
 		It("releases the lock file in the git repo", func() {
 			pool := "some-pool"
@@ -273,7 +1188,7 @@ var _ = Describe("Locker", func() {
 			gitRepo.DirReturns(gitDir)
 			fs.LsReturns([]string{lock}, nil)
 
-			locker := NewLocker(fs, gitRepo)
+			locker := NewLocker(fs, gitRepo, authors, 0)
 			Expect(locker.ReleaseLock(pool, user)).To(Succeed())
 
 			Expect(gitRepo.CloneOrPullCallCount()).To(Equal(1))
@@ -286,17 +1201,20 @@ var _ = Describe("Locker", func() {
 			Expect(oldPath).To(Equal(filepath.Join(gitDir, pool, "claimed", lock)))
 			Expect(newPath).To(Equal(filepath.Join(gitDir, pool, "unclaimed", lock)))
 
+			Expect(fs.RmCallCount()).To(Equal(1))
+			Expect(fs.RmArgsForCall(0)).To(Equal(filepath.Join(gitDir, pool, "claim.yml")))
+
 			message, actualUser := gitRepo.CommitAndPushArgsForCall(0)
 			Expect(gitRepo.CommitAndPushCallCount()).To(Equal(1))
 			Expect(message).To(Equal("Claimer releasing " + pool))
-			Expect(actualUser).To(Equal(user))
+			Expect(actualUser).To(Equal(git.Identity{Name: user}))
 		})
 
 		Context("when cloning the repo fails", func() {
 			It("returns an error", func() {
 				gitRepo.CloneOrPullReturns(errors.New("some-error"))
 
-				locker := NewLocker(fs, gitRepo)
+				locker := NewLocker(fs, gitRepo, authors, 0)
 				Expect(locker.ReleaseLock("", "")).To(MatchError("failed to clone or pull: some-error"))
 			})
 		})
@@ -305,7 +1223,7 @@ var _ = Describe("Locker", func() {
 			It("returns an error", func() {
 				fs.LsReturns(nil, errors.New("some-error"))
 
-				locker := NewLocker(fs, gitRepo)
+				locker := NewLocker(fs, gitRepo, authors, 0)
 				Expect(locker.ReleaseLock("", "")).To(MatchError("failed to list claimed locks: some-error"))
 			})
 		})
@@ -316,7 +1234,7 @@ var _ = Describe("Locker", func() {
 
 				fs.LsReturns([]string{}, nil)
 
-				locker := NewLocker(fs, gitRepo)
+				locker := NewLocker(fs, gitRepo, authors, 0)
 				Expect(locker.ReleaseLock(pool, "")).To(MatchError("no claimed locks for pool " + pool))
 			})
 		})
@@ -327,7 +1245,7 @@ var _ = Describe("Locker", func() {
 
 				fs.LsReturns([]string{"some-lock", "some-other-lock"}, nil)
 
-				locker := NewLocker(fs, gitRepo)
+				locker := NewLocker(fs, gitRepo, authors, 0)
 				Expect(locker.ReleaseLock(pool, "")).To(MatchError("too many claimed locks for pool " + pool))
 			})
 		})
@@ -337,17 +1255,27 @@ var _ = Describe("Locker", func() {
 				fs.LsReturns([]string{"some-lock"}, nil)
 				fs.MvReturns(errors.New("some-error"))
 
-				locker := NewLocker(fs, gitRepo)
+				locker := NewLocker(fs, gitRepo, authors, 0)
 				Expect(locker.ReleaseLock("", "")).To(MatchError("failed to move file: some-error"))
 			})
 		})
 
+		Context("when removing the claim file fails", func() {
+			It("returns an error", func() {
+				fs.LsReturns([]string{"some-lock"}, nil)
+				fs.RmReturns(errors.New("some-error"))
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.ReleaseLock("", "")).To(MatchError("failed to remove claim file: some-error"))
+			})
+		})
+
 		Context("when pushing fails", func() {
 			It("returns an error", func() {
 				fs.LsReturns([]string{"some-lock"}, nil)
 				gitRepo.CommitAndPushReturns(errors.New("some-error"))
 
-				locker := NewLocker(fs, gitRepo)
+				locker := NewLocker(fs, gitRepo, authors, 0)
 				Expect(locker.ReleaseLock("", "")).To(MatchError("failed to commit and push: some-error"))
 			})
 		})
@@ -356,13 +1284,13 @@ var _ = Describe("Locker", func() {
 	Describe("Status", func() {
 		It("returns a list of pools with exactly one lock", func() {
 			author := "some-author"
-			date := "some-date"
+			date := time.Date(2017, 3, 1, 12, 0, 0, 0, time.FixedZone("", -5*60*60))
 			message := "some-message"
 
 			gitDir := "some-dir"
 			gitRepo.DirReturns(gitDir)
 
-			fs.LsDirsStub = func(dir string) ([]string, error) {
+			fs.LsPoolsStub = func(dir string) ([]string, error) {
 				if dir == gitDir {
 					return []string{"pool-1", "pool-2", "empty-pool", "full-pool"}, nil
 				} else {
@@ -383,35 +1311,218 @@ var _ = Describe("Locker", func() {
 				}
 			}
 
-			gitRepo.LatestCommitReturns(author, date, message, nil)
+			gitRepo.LatestCommitsReturns(map[string]git.Commit{
+				"pool-1/claimed": {Author: author, Date: date, Body: message},
+			}, nil)
 
-			locker := NewLocker(fs, gitRepo)
+			locker := NewLocker(fs, gitRepo, authors, 0)
 			locks, err := locker.Status()
 			Expect(err).NotTo(HaveOccurred())
 			Expect(locks).To(ConsistOf(
-				Lock{Name: "pool-1", Claimed: true, Owner: author, Date: date, Message: message},
+				Lock{Name: "pool-1", Claimed: true, Owner: author, Date: "Wed Mar 1 12:00:00 2017 -0500", Message: message},
 				Lock{Name: "pool-2", Claimed: false},
 			))
 
 			Expect(gitRepo.CloneOrPullCallCount()).To(Equal(1))
-			Expect(gitRepo.LatestCommitCallCount()).To(Equal(1))
+			Expect(gitRepo.LatestCommitsCallCount()).To(Equal(1))
+			Expect(gitRepo.LatestCommitsArgsForCall(0)).To(Equal([]string{"pool-1/claimed"}))
+		})
+
+		Context("when no pools are claimed", func() {
+			It("does not read the log", func() {
+				fs.LsPoolsReturns([]string{"some-pool"}, nil)
+				fs.LsReturnsOnCall(1, []string{"lock"}, nil)
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.Status()).To(ConsistOf(Lock{Name: "some-pool", Claimed: false}))
+				Expect(gitRepo.LatestCommitsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the head has not changed since the last call", func() {
+			It("returns the cached status", func() {
+				fs.LsPoolsReturns([]string{"some-pool"}, nil)
+				fs.LsReturnsOnCall(0, []string{"lock"}, nil)
+				fs.LsReturnsOnCall(1, []string{}, nil)
+				gitRepo.HeadReturns("some-sha", nil)
+				gitRepo.LatestCommitsReturns(map[string]git.Commit{"some-pool/claimed": {Author: "some-author"}}, nil)
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				first, err := locker.Status()
+				Expect(err).NotTo(HaveOccurred())
+				second, err := locker.Status()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(second).To(Equal(first))
+
+				Expect(gitRepo.CloneOrPullCallCount()).To(Equal(2))
+				Expect(fs.LsPoolsCallCount()).To(Equal(1))
+				Expect(gitRepo.LatestCommitsCallCount()).To(Equal(1))
+
+				gitRepo.HeadReturns("some-other-sha", nil)
+				fs.LsReturnsOnCall(2, []string{}, nil)
+				fs.LsReturnsOnCall(3, []string{"lock"}, nil)
+				Expect(locker.Status()).To(ConsistOf(Lock{Name: "some-pool", Claimed: false}))
+				Expect(fs.LsPoolsCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when a minimum fetch interval is configured", func() {
+			It("only pulls once the interval has passed", func() {
+				locker := NewLocker(fs, gitRepo, authors, time.Hour)
+				_, err := locker.Status()
+				Expect(err).NotTo(HaveOccurred())
+				_, err = locker.Status()
+				Expect(err).NotTo(HaveOccurred())
+				_, err = locker.History("some-pool")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(gitRepo.CloneOrPullCallCount()).To(Equal(1))
+			})
+
+			It("always pulls before writing and after a write", func() {
+				fs.LsReturns([]string{"some-lock"}, nil)
+
+				locker := NewLocker(fs, gitRepo, authors, time.Hour)
+				_, err := locker.Status()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(locker.ClaimLock("some-pool", "some-user", "")).To(Succeed())
+				Expect(gitRepo.CloneOrPullCallCount()).To(Equal(2))
+
+				_, err = locker.Status()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(gitRepo.CloneOrPullCallCount()).To(Equal(3))
+			})
+
+			Context("when pulling fails", func() {
+				It("pulls again on the next read", func() {
+					gitRepo.CloneOrPullReturnsOnCall(0, errors.New("some-error"))
+
+					locker := NewLocker(fs, gitRepo, authors, time.Hour)
+					_, err := locker.Status()
+					Expect(err).To(MatchError("failed to clone or pull: some-error"))
+					_, err = locker.Status()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(gitRepo.CloneOrPullCallCount()).To(Equal(2))
+				})
+			})
+		})
+
+		Context("when the claim has been extended or annotated by its owner", func() {
+			It("returns the message and expiry from the claim file", func() {
+				gitDir := "some-dir"
+				gitRepo.DirReturns(gitDir)
+
+				fs.LsPoolsReturns([]string{"some-pool"}, nil)
+				fs.LsReturnsOnCall(0, []string{"lock"}, nil)
+				fs.LsReturnsOnCall(1, []string{}, nil)
+				fs.ExistsStub = func(path string) (bool, error) {
+					return filepath.Base(path) != "disabled.yml", nil
+				}
+				fs.ReadFileReturns([]byte("owner: some-author\nmessage: some-new-message\nexpires: 2017-03-01T12:00:00Z\n"), nil)
+				gitRepo.LatestCommitsReturns(map[string]git.Commit{
+					"some-pool/claimed": {Author: "some-author", Body: "some-message"},
+				}, nil)
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				locks, err := locker.Status()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(locks).To(HaveLen(1))
+				Expect(locks[0].Message).To(Equal("some-new-message"))
+				Expect(locks[0].Expires).To(BeTemporally("==", time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC)))
+
+				Expect(fs.ExistsArgsForCall(3)).To(Equal(filepath.Join(gitDir, "some-pool", "claim.yml")))
+			})
+		})
+
+		Context("when a pool has a pool file", func() {
+			It("returns the metadata of the pool", func() {
+				gitDir := "some-dir"
+				gitRepo.DirReturns(gitDir)
+
+				fs.LsPoolsReturns([]string{"some-pool"}, nil)
+				fs.LsReturnsOnCall(1, []string{"lock"}, nil)
+				fs.ExistsStub = func(path string) (bool, error) {
+					return path == filepath.Join(gitDir, "some-pool", "pool.yml"), nil
+				}
+				fs.ReadFileReturns([]byte("description: some description\nteam: some-team\ntags: [some-tag]\nlinks: [some-link]\n"), nil)
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				Expect(locker.Status()).To(ConsistOf(Lock{
+					Name:    "some-pool",
+					Claimed: false,
+					Metadata: Metadata{
+						Description: "some description",
+						Team:        "some-team",
+						Tags:        []string{"some-tag"},
+						Links:       []string{"some-link"},
+					},
+				}))
+				Expect(fs.ReadFileArgsForCall(0)).To(Equal(filepath.Join(gitDir, "some-pool", "pool.yml")))
+			})
+
+			Context("when the pool file is invalid", func() {
+				It("returns an error", func() {
+					fs.LsPoolsReturns([]string{"some-pool"}, nil)
+					fs.LsReturnsOnCall(1, []string{"lock"}, nil)
+					fs.ExistsReturns(true, nil)
+					fs.ReadFileReturns([]byte("some-invalid-yaml"), nil)
+
+					locker := NewLocker(fs, gitRepo, authors, 0)
+					_, err := locker.Status()
+					Expect(err).To(MatchError(ContainSubstring("failed to parse pool file: ")))
+				})
+			})
+		})
+
+		Context("when the claim file belongs to a previous owner", func() {
+			It("ignores the claim file", func() {
+				fs.LsPoolsReturns([]string{"some-pool"}, nil)
+				fs.LsReturnsOnCall(0, []string{"lock"}, nil)
+				fs.LsReturnsOnCall(1, []string{}, nil)
+				fs.ExistsStub = func(path string) (bool, error) {
+					return filepath.Base(path) == "claim.yml", nil
+				}
+				fs.ReadFileReturns([]byte("owner: some-other-author\nmessage: some-old-message\n"), nil)
+				date := time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC)
+				gitRepo.LatestCommitsReturns(map[string]git.Commit{
+					"some-pool/claimed": {Author: "some-author", Date: date, Body: "some-message"},
+				}, nil)
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				locks, err := locker.Status()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(locks).To(ConsistOf(
+					Lock{Name: "some-pool", Claimed: true, Owner: "some-author", Date: date.Format(DateFormat), Message: "some-message"},
+				))
+			})
 		})
 
 		Context("when cloning the repo fails", func() {
 			It("returns an error", func() {
 				gitRepo.CloneOrPullReturns(errors.New("some-error"))
 
-				locker := NewLocker(fs, gitRepo)
+				locker := NewLocker(fs, gitRepo, authors, 0)
 				_, err := locker.Status()
 				Expect(err).To(MatchError("failed to clone or pull: some-error"))
 			})
 		})
 
+		Context("when getting the head fails", func() {
+			It("returns an error", func() {
+				gitRepo.HeadReturns("", errors.New("some-error"))
+
+				locker := NewLocker(fs, gitRepo, authors, 0)
+				_, err := locker.Status()
+				Expect(err).To(MatchError("failed to get head: some-error"))
+			})
+		})
+
 		Context("when listing the git repo fails", func() {
 			It("returns an error", func() {
-				fs.LsDirsReturns(nil, errors.New("some-error"))
+				fs.LsPoolsReturns(nil, errors.New("some-error"))
 
-				locker := NewLocker(fs, gitRepo)
+				locker := NewLocker(fs, gitRepo, authors, 0)
 				_, err := locker.Status()
 				Expect(err).To(MatchError("failed to list pools: some-error"))
 			})
@@ -419,10 +1530,10 @@ var _ = Describe("Locker", func() {
 
 		Context("when listing claimed locks fails", func() {
 			It("returns an error", func() {
-				fs.LsDirsReturns([]string{"some-pool"}, nil)
+				fs.LsPoolsReturns([]string{"some-pool"}, nil)
 				fs.LsReturns(nil, errors.New("some-error"))
 
-				locker := NewLocker(fs, gitRepo)
+				locker := NewLocker(fs, gitRepo, authors, 0)
 				_, err := locker.Status()
 				Expect(err).To(MatchError("failed to list claimed locks: some-error"))
 			})
@@ -430,24 +1541,24 @@ var _ = Describe("Locker", func() {
 
 		Context("when listing unclaimed locks fails", func() {
 			It("returns an error", func() {
-				fs.LsDirsReturns([]string{"some-pool"}, nil)
+				fs.LsPoolsReturns([]string{"some-pool"}, nil)
 				fs.LsReturnsOnCall(1, nil, errors.New("some-error"))
 
-				locker := NewLocker(fs, gitRepo)
+				locker := NewLocker(fs, gitRepo, authors, 0)
 				_, err := locker.Status()
 				Expect(err).To(MatchError("failed to list unclaimed locks: some-error"))
 			})
 		})
 
-		Context("when getting the author fails", func() {
+		Context("when getting the latest commits fails", func() {
 			It("returns an error", func() {
-				fs.LsDirsReturns([]string{"some-pool"}, nil)
+				fs.LsPoolsReturns([]string{"some-pool"}, nil)
 				fs.LsReturnsOnCall(0, []string{"some-lock"}, nil)
-				gitRepo.LatestCommitReturns("", "", "", errors.New("some-error"))
+				gitRepo.LatestCommitsReturns(nil, errors.New("some-error"))
 
-				locker := NewLocker(fs, gitRepo)
+				locker := NewLocker(fs, gitRepo, authors, 0)
 				_, err := locker.Status()
-				Expect(err).To(MatchError("failed to get latest commit: some-error"))
+				Expect(err).To(MatchError("failed to get latest commits: some-error"))
 			})
 		})
 	})
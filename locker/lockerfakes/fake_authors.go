@@ -0,0 +1,90 @@
+// This file was generated by counterfeiter
+package lockerfakes
+
+import (
+	"sync"
+)
+
+type FakeAuthors struct {
+	EmailStub        func(username string) string
+	emailMutex       sync.RWMutex
+	emailArgsForCall []struct {
+		username string
+	}
+	emailReturns struct {
+		result1 string
+	}
+	emailReturnsOnCall map[int]struct {
+		result1 string
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAuthors) Email(username string) string {
+	fake.emailMutex.Lock()
+	ret, specificReturn := fake.emailReturnsOnCall[len(fake.emailArgsForCall)]
+	fake.emailArgsForCall = append(fake.emailArgsForCall, struct {
+		username string
+	}{username})
+	fake.recordInvocation("Email", []interface{}{username})
+	fake.emailMutex.Unlock()
+	if fake.EmailStub != nil {
+		return fake.EmailStub(username)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.emailReturns.result1
+}
+
+func (fake *FakeAuthors) EmailCallCount() int {
+	fake.emailMutex.RLock()
+	defer fake.emailMutex.RUnlock()
+	return len(fake.emailArgsForCall)
+}
+
+func (fake *FakeAuthors) EmailArgsForCall(i int) string {
+	fake.emailMutex.RLock()
+	defer fake.emailMutex.RUnlock()
+	return fake.emailArgsForCall[i].username
+}
+
+func (fake *FakeAuthors) EmailReturns(result1 string) {
+	fake.EmailStub = nil
+	fake.emailReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeAuthors) EmailReturnsOnCall(i int, result1 string) {
+	fake.EmailStub = nil
+	if fake.emailReturnsOnCall == nil {
+		fake.emailReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.emailReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeAuthors) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.emailMutex.RLock()
+	defer fake.emailMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeAuthors) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
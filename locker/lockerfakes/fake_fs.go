@@ -6,6 +6,19 @@ import (
 )
 
 type FakeFs struct {
+	ExistsStub        func(path string) (bool, error)
+	existsMutex       sync.RWMutex
+	existsArgsForCall []struct {
+		path string
+	}
+	existsReturns struct {
+		result1 bool
+		result2 error
+	}
+	existsReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	LsStub        func(dir string) ([]string, error)
 	lsMutex       sync.RWMutex
 	lsArgsForCall []struct {
@@ -32,6 +45,19 @@ type FakeFs struct {
 		result1 []string
 		result2 error
 	}
+	LsPoolsStub        func(dir string) ([]string, error)
+	lsPoolsMutex       sync.RWMutex
+	lsPoolsArgsForCall []struct {
+		dir string
+	}
+	lsPoolsReturns struct {
+		result1 []string
+		result2 error
+	}
+	lsPoolsReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
 	MvStub        func(src, dst string) error
 	mvMutex       sync.RWMutex
 	mvArgsForCall []struct {
@@ -44,6 +70,19 @@ type FakeFs struct {
 	mvReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ReadFileStub        func(file string) ([]byte, error)
+	readFileMutex       sync.RWMutex
+	readFileArgsForCall []struct {
+		file string
+	}
+	readFileReturns struct {
+		result1 []byte
+		result2 error
+	}
+	readFileReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
 	RmStub        func(path string) error
 	rmMutex       sync.RWMutex
 	rmArgsForCall []struct {
@@ -66,10 +105,73 @@ type FakeFs struct {
 	touchReturnsOnCall map[int]struct {
 		result1 error
 	}
+	WriteFileStub        func(file string, contents []byte) error
+	writeFileMutex       sync.RWMutex
+	writeFileArgsForCall []struct {
+		file     string
+		contents []byte
+	}
+	writeFileReturns struct {
+		result1 error
+	}
+	writeFileReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeFs) Exists(path string) (bool, error) {
+	fake.existsMutex.Lock()
+	ret, specificReturn := fake.existsReturnsOnCall[len(fake.existsArgsForCall)]
+	fake.existsArgsForCall = append(fake.existsArgsForCall, struct {
+		path string
+	}{path})
+	fake.recordInvocation("Exists", []interface{}{path})
+	fake.existsMutex.Unlock()
+	if fake.ExistsStub != nil {
+		return fake.ExistsStub(path)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.existsReturns.result1, fake.existsReturns.result2
+}
+
+func (fake *FakeFs) ExistsCallCount() int {
+	fake.existsMutex.RLock()
+	defer fake.existsMutex.RUnlock()
+	return len(fake.existsArgsForCall)
+}
+
+func (fake *FakeFs) ExistsArgsForCall(i int) string {
+	fake.existsMutex.RLock()
+	defer fake.existsMutex.RUnlock()
+	return fake.existsArgsForCall[i].path
+}
+
+func (fake *FakeFs) ExistsReturns(result1 bool, result2 error) {
+	fake.ExistsStub = nil
+	fake.existsReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFs) ExistsReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.ExistsStub = nil
+	if fake.existsReturnsOnCall == nil {
+		fake.existsReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.existsReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeFs) Ls(dir string) ([]string, error) {
 	fake.lsMutex.Lock()
 	ret, specificReturn := fake.lsReturnsOnCall[len(fake.lsArgsForCall)]
@@ -172,6 +274,57 @@ func (fake *FakeFs) LsDirsReturnsOnCall(i int, result1 []string, result2 error)
 	}{result1, result2}
 }
 
+func (fake *FakeFs) LsPools(dir string) ([]string, error) {
+	fake.lsPoolsMutex.Lock()
+	ret, specificReturn := fake.lsPoolsReturnsOnCall[len(fake.lsPoolsArgsForCall)]
+	fake.lsPoolsArgsForCall = append(fake.lsPoolsArgsForCall, struct {
+		dir string
+	}{dir})
+	fake.recordInvocation("LsPools", []interface{}{dir})
+	fake.lsPoolsMutex.Unlock()
+	if fake.LsPoolsStub != nil {
+		return fake.LsPoolsStub(dir)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.lsPoolsReturns.result1, fake.lsPoolsReturns.result2
+}
+
+func (fake *FakeFs) LsPoolsCallCount() int {
+	fake.lsPoolsMutex.RLock()
+	defer fake.lsPoolsMutex.RUnlock()
+	return len(fake.lsPoolsArgsForCall)
+}
+
+func (fake *FakeFs) LsPoolsArgsForCall(i int) string {
+	fake.lsPoolsMutex.RLock()
+	defer fake.lsPoolsMutex.RUnlock()
+	return fake.lsPoolsArgsForCall[i].dir
+}
+
+func (fake *FakeFs) LsPoolsReturns(result1 []string, result2 error) {
+	fake.LsPoolsStub = nil
+	fake.lsPoolsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFs) LsPoolsReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.LsPoolsStub = nil
+	if fake.lsPoolsReturnsOnCall == nil {
+		fake.lsPoolsReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.lsPoolsReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeFs) Mv(src string, dst string) error {
 	fake.mvMutex.Lock()
 	ret, specificReturn := fake.mvReturnsOnCall[len(fake.mvArgsForCall)]
@@ -221,6 +374,57 @@ func (fake *FakeFs) MvReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeFs) ReadFile(file string) ([]byte, error) {
+	fake.readFileMutex.Lock()
+	ret, specificReturn := fake.readFileReturnsOnCall[len(fake.readFileArgsForCall)]
+	fake.readFileArgsForCall = append(fake.readFileArgsForCall, struct {
+		file string
+	}{file})
+	fake.recordInvocation("ReadFile", []interface{}{file})
+	fake.readFileMutex.Unlock()
+	if fake.ReadFileStub != nil {
+		return fake.ReadFileStub(file)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.readFileReturns.result1, fake.readFileReturns.result2
+}
+
+func (fake *FakeFs) ReadFileCallCount() int {
+	fake.readFileMutex.RLock()
+	defer fake.readFileMutex.RUnlock()
+	return len(fake.readFileArgsForCall)
+}
+
+func (fake *FakeFs) ReadFileArgsForCall(i int) string {
+	fake.readFileMutex.RLock()
+	defer fake.readFileMutex.RUnlock()
+	return fake.readFileArgsForCall[i].file
+}
+
+func (fake *FakeFs) ReadFileReturns(result1 []byte, result2 error) {
+	fake.ReadFileStub = nil
+	fake.readFileReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFs) ReadFileReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.ReadFileStub = nil
+	if fake.readFileReturnsOnCall == nil {
+		fake.readFileReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.readFileReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeFs) Rm(path string) error {
 	fake.rmMutex.Lock()
 	ret, specificReturn := fake.rmReturnsOnCall[len(fake.rmArgsForCall)]
@@ -317,19 +521,76 @@ func (fake *FakeFs) TouchReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeFs) WriteFile(file string, contents []byte) error {
+	fake.writeFileMutex.Lock()
+	ret, specificReturn := fake.writeFileReturnsOnCall[len(fake.writeFileArgsForCall)]
+	fake.writeFileArgsForCall = append(fake.writeFileArgsForCall, struct {
+		file     string
+		contents []byte
+	}{file, contents})
+	fake.recordInvocation("WriteFile", []interface{}{file, contents})
+	fake.writeFileMutex.Unlock()
+	if fake.WriteFileStub != nil {
+		return fake.WriteFileStub(file, contents)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.writeFileReturns.result1
+}
+
+func (fake *FakeFs) WriteFileCallCount() int {
+	fake.writeFileMutex.RLock()
+	defer fake.writeFileMutex.RUnlock()
+	return len(fake.writeFileArgsForCall)
+}
+
+func (fake *FakeFs) WriteFileArgsForCall(i int) (string, []byte) {
+	fake.writeFileMutex.RLock()
+	defer fake.writeFileMutex.RUnlock()
+	return fake.writeFileArgsForCall[i].file, fake.writeFileArgsForCall[i].contents
+}
+
+func (fake *FakeFs) WriteFileReturns(result1 error) {
+	fake.WriteFileStub = nil
+	fake.writeFileReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeFs) WriteFileReturnsOnCall(i int, result1 error) {
+	fake.WriteFileStub = nil
+	if fake.writeFileReturnsOnCall == nil {
+		fake.writeFileReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeFileReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeFs) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.existsMutex.RLock()
+	defer fake.existsMutex.RUnlock()
 	fake.lsMutex.RLock()
 	defer fake.lsMutex.RUnlock()
 	fake.lsDirsMutex.RLock()
 	defer fake.lsDirsMutex.RUnlock()
+	fake.lsPoolsMutex.RLock()
+	defer fake.lsPoolsMutex.RUnlock()
 	fake.mvMutex.RLock()
 	defer fake.mvMutex.RUnlock()
+	fake.readFileMutex.RLock()
+	defer fake.readFileMutex.RUnlock()
 	fake.rmMutex.RLock()
 	defer fake.rmMutex.RUnlock()
 	fake.touchMutex.RLock()
 	defer fake.touchMutex.RUnlock()
+	fake.writeFileMutex.RLock()
+	defer fake.writeFileMutex.RUnlock()
 	return fake.invocations
 }
 
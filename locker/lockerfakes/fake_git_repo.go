@@ -3,6 +3,8 @@ package lockerfakes
 
 import (
 	"sync"
+
+	"github.com/mdelillo/claimer/git"
 )
 
 type FakeGitRepo struct {
@@ -15,11 +17,11 @@ type FakeGitRepo struct {
 	cloneOrPullReturnsOnCall map[int]struct {
 		result1 error
 	}
-	CommitAndPushStub        func(message, user string) error
+	CommitAndPushStub        func(message string, author git.Identity) error
 	commitAndPushMutex       sync.RWMutex
 	commitAndPushArgsForCall []struct {
 		message string
-		user    string
+		author  git.Identity
 	}
 	commitAndPushReturns struct {
 		result1 error
@@ -36,6 +38,17 @@ type FakeGitRepo struct {
 	dirReturnsOnCall map[int]struct {
 		result1 string
 	}
+	HeadStub        func() (string, error)
+	headMutex       sync.RWMutex
+	headArgsForCall []struct{}
+	headReturns     struct {
+		result1 string
+		result2 error
+	}
+	headReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
 	LatestCommitStub        func(pool string) (committer, date, message string, err error)
 	latestCommitMutex       sync.RWMutex
 	latestCommitArgsForCall []struct {
@@ -53,6 +66,32 @@ type FakeGitRepo struct {
 		result3 string
 		result4 error
 	}
+	LatestCommitsStub        func(paths []string) (map[string]git.Commit, error)
+	latestCommitsMutex       sync.RWMutex
+	latestCommitsArgsForCall []struct {
+		paths []string
+	}
+	latestCommitsReturns struct {
+		result1 map[string]git.Commit
+		result2 error
+	}
+	latestCommitsReturnsOnCall map[int]struct {
+		result1 map[string]git.Commit
+		result2 error
+	}
+	LogStub        func(path string) ([]git.Commit, error)
+	logMutex       sync.RWMutex
+	logArgsForCall []struct {
+		path string
+	}
+	logReturns struct {
+		result1 []git.Commit
+		result2 error
+	}
+	logReturnsOnCall map[int]struct {
+		result1 []git.Commit
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -97,17 +136,17 @@ func (fake *FakeGitRepo) CloneOrPullReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeGitRepo) CommitAndPush(message string, user string) error {
+func (fake *FakeGitRepo) CommitAndPush(message string, author git.Identity) error {
 	fake.commitAndPushMutex.Lock()
 	ret, specificReturn := fake.commitAndPushReturnsOnCall[len(fake.commitAndPushArgsForCall)]
 	fake.commitAndPushArgsForCall = append(fake.commitAndPushArgsForCall, struct {
 		message string
-		user    string
-	}{message, user})
-	fake.recordInvocation("CommitAndPush", []interface{}{message, user})
+		author  git.Identity
+	}{message, author})
+	fake.recordInvocation("CommitAndPush", []interface{}{message, author})
 	fake.commitAndPushMutex.Unlock()
 	if fake.CommitAndPushStub != nil {
-		return fake.CommitAndPushStub(message, user)
+		return fake.CommitAndPushStub(message, author)
 	}
 	if specificReturn {
 		return ret.result1
@@ -121,10 +160,10 @@ func (fake *FakeGitRepo) CommitAndPushCallCount() int {
 	return len(fake.commitAndPushArgsForCall)
 }
 
-func (fake *FakeGitRepo) CommitAndPushArgsForCall(i int) (string, string) {
+func (fake *FakeGitRepo) CommitAndPushArgsForCall(i int) (string, git.Identity) {
 	fake.commitAndPushMutex.RLock()
 	defer fake.commitAndPushMutex.RUnlock()
-	return fake.commitAndPushArgsForCall[i].message, fake.commitAndPushArgsForCall[i].user
+	return fake.commitAndPushArgsForCall[i].message, fake.commitAndPushArgsForCall[i].author
 }
 
 func (fake *FakeGitRepo) CommitAndPushReturns(result1 error) {
@@ -186,6 +225,49 @@ func (fake *FakeGitRepo) DirReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeGitRepo) Head() (string, error) {
+	fake.headMutex.Lock()
+	ret, specificReturn := fake.headReturnsOnCall[len(fake.headArgsForCall)]
+	fake.headArgsForCall = append(fake.headArgsForCall, struct{}{})
+	fake.recordInvocation("Head", []interface{}{})
+	fake.headMutex.Unlock()
+	if fake.HeadStub != nil {
+		return fake.HeadStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.headReturns.result1, fake.headReturns.result2
+}
+
+func (fake *FakeGitRepo) HeadCallCount() int {
+	fake.headMutex.RLock()
+	defer fake.headMutex.RUnlock()
+	return len(fake.headArgsForCall)
+}
+
+func (fake *FakeGitRepo) HeadReturns(result1 string, result2 error) {
+	fake.HeadStub = nil
+	fake.headReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGitRepo) HeadReturnsOnCall(i int, result1 string, result2 error) {
+	fake.HeadStub = nil
+	if fake.headReturnsOnCall == nil {
+		fake.headReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.headReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeGitRepo) LatestCommit(pool string) (committer, date, message string, err error) {
 	fake.latestCommitMutex.Lock()
 	ret, specificReturn := fake.latestCommitReturnsOnCall[len(fake.latestCommitArgsForCall)]
@@ -243,6 +325,113 @@ func (fake *FakeGitRepo) LatestCommitReturnsOnCall(i int, result1 string, result
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeGitRepo) LatestCommits(paths []string) (map[string]git.Commit, error) {
+	var pathsCopy []string
+	if paths != nil {
+		pathsCopy = make([]string, len(paths))
+		copy(pathsCopy, paths)
+	}
+	fake.latestCommitsMutex.Lock()
+	ret, specificReturn := fake.latestCommitsReturnsOnCall[len(fake.latestCommitsArgsForCall)]
+	fake.latestCommitsArgsForCall = append(fake.latestCommitsArgsForCall, struct {
+		paths []string
+	}{pathsCopy})
+	fake.recordInvocation("LatestCommits", []interface{}{pathsCopy})
+	fake.latestCommitsMutex.Unlock()
+	if fake.LatestCommitsStub != nil {
+		return fake.LatestCommitsStub(paths)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.latestCommitsReturns.result1, fake.latestCommitsReturns.result2
+}
+
+func (fake *FakeGitRepo) LatestCommitsCallCount() int {
+	fake.latestCommitsMutex.RLock()
+	defer fake.latestCommitsMutex.RUnlock()
+	return len(fake.latestCommitsArgsForCall)
+}
+
+func (fake *FakeGitRepo) LatestCommitsArgsForCall(i int) []string {
+	fake.latestCommitsMutex.RLock()
+	defer fake.latestCommitsMutex.RUnlock()
+	return fake.latestCommitsArgsForCall[i].paths
+}
+
+func (fake *FakeGitRepo) LatestCommitsReturns(result1 map[string]git.Commit, result2 error) {
+	fake.LatestCommitsStub = nil
+	fake.latestCommitsReturns = struct {
+		result1 map[string]git.Commit
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGitRepo) LatestCommitsReturnsOnCall(i int, result1 map[string]git.Commit, result2 error) {
+	fake.LatestCommitsStub = nil
+	if fake.latestCommitsReturnsOnCall == nil {
+		fake.latestCommitsReturnsOnCall = make(map[int]struct {
+			result1 map[string]git.Commit
+			result2 error
+		})
+	}
+	fake.latestCommitsReturnsOnCall[i] = struct {
+		result1 map[string]git.Commit
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGitRepo) Log(path string) ([]git.Commit, error) {
+	fake.logMutex.Lock()
+	ret, specificReturn := fake.logReturnsOnCall[len(fake.logArgsForCall)]
+	fake.logArgsForCall = append(fake.logArgsForCall, struct {
+		path string
+	}{path})
+	fake.recordInvocation("Log", []interface{}{path})
+	fake.logMutex.Unlock()
+	if fake.LogStub != nil {
+		return fake.LogStub(path)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.logReturns.result1, fake.logReturns.result2
+}
+
+func (fake *FakeGitRepo) LogCallCount() int {
+	fake.logMutex.RLock()
+	defer fake.logMutex.RUnlock()
+	return len(fake.logArgsForCall)
+}
+
+func (fake *FakeGitRepo) LogArgsForCall(i int) string {
+	fake.logMutex.RLock()
+	defer fake.logMutex.RUnlock()
+	return fake.logArgsForCall[i].path
+}
+
+func (fake *FakeGitRepo) LogReturns(result1 []git.Commit, result2 error) {
+	fake.LogStub = nil
+	fake.logReturns = struct {
+		result1 []git.Commit
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGitRepo) LogReturnsOnCall(i int, result1 []git.Commit, result2 error) {
+	fake.LogStub = nil
+	if fake.logReturnsOnCall == nil {
+		fake.logReturnsOnCall = make(map[int]struct {
+			result1 []git.Commit
+			result2 error
+		})
+	}
+	fake.logReturnsOnCall[i] = struct {
+		result1 []git.Commit
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeGitRepo) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -252,8 +441,14 @@ func (fake *FakeGitRepo) Invocations() map[string][][]interface{} {
 	defer fake.commitAndPushMutex.RUnlock()
 	fake.dirMutex.RLock()
 	defer fake.dirMutex.RUnlock()
+	fake.headMutex.RLock()
+	defer fake.headMutex.RUnlock()
 	fake.latestCommitMutex.RLock()
 	defer fake.latestCommitMutex.RUnlock()
+	fake.latestCommitsMutex.RLock()
+	defer fake.latestCommitsMutex.RUnlock()
+	fake.logMutex.RLock()
+	defer fake.logMutex.RUnlock()
 	return fake.invocations
 }
 
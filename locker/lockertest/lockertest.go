@@ -0,0 +1,317 @@
+// Package lockertest contains specs which every implementation of
+// locker.Locker must pass, so that the chat commands behave the same whichever
+// backend is used.
+package lockertest
+
+import (
+	"time"
+
+	"github.com/mdelillo/claimer/locker"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// DescribeLocker defines the shared specs. newLocker is called before each
+// spec and must return a locker with no pools.
+func DescribeLocker(newLocker func() locker.Locker) {
+	Describe("Locker contract", func() {
+		var l locker.Locker
+
+		BeforeEach(func() {
+			l = newLocker()
+		})
+
+		findLock := func(pool string) locker.Lock {
+			locks, err := l.Status()
+			ExpectWithOffset(1, err).NotTo(HaveOccurred())
+			for _, lock := range locks {
+				if lock.Name == pool {
+					return lock
+				}
+			}
+			Fail("no lock named " + pool)
+			return locker.Lock{}
+		}
+
+		It("creates pools which start unclaimed", func() {
+			Expect(l.CreatePool("pool-b", "some-user", locker.Metadata{})).To(Succeed())
+			Expect(l.CreatePool("pool-a", "some-user", locker.Metadata{})).To(Succeed())
+
+			Expect(l.Status()).To(Equal([]locker.Lock{
+				{Name: "pool-a", Claimed: false},
+				{Name: "pool-b", Claimed: false},
+			}))
+		})
+
+		It("keeps the metadata of pools", func() {
+			metadata := locker.Metadata{Description: "some description", Tags: []string{"some-tag", "some-other-tag"}}
+			Expect(l.CreatePool("pool-a", "some-user", metadata)).To(Succeed())
+			Expect(l.ClaimLock("pool-a", "some-owner", "")).To(Succeed())
+
+			Expect(findLock("pool-a").Metadata).To(Equal(metadata))
+		})
+
+		It("claims and releases locks", func() {
+			Expect(l.CreatePool("pool-a", "some-user", locker.Metadata{})).To(Succeed())
+
+			Expect(l.ClaimLock("pool-a", "some-owner", "some message")).To(Succeed())
+			lock := findLock("pool-a")
+			Expect(lock.Claimed).To(BeTrue())
+			Expect(lock.Owner).To(Equal("some-owner"))
+			Expect(lock.Message).To(Equal("some message"))
+			Expect(lock.Expires).To(BeZero())
+			date, err := time.Parse(locker.DateFormat, lock.Date)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(date).To(BeTemporally("~", time.Now(), 10*time.Second))
+
+			Expect(l.ClaimLock("pool-a", "some-other-owner", "")).NotTo(Succeed())
+			Expect(findLock("pool-a").Owner).To(Equal("some-owner"))
+
+			Expect(l.ReleaseLock("pool-a", "some-owner")).To(Succeed())
+			Expect(findLock("pool-a")).To(Equal(locker.Lock{Name: "pool-a", Claimed: false}))
+
+			Expect(l.ReleaseLock("pool-a", "some-owner")).NotTo(Succeed())
+		})
+
+		It("claims the first unclaimed pool of several", func() {
+			Expect(l.CreatePool("pool-a", "some-user", locker.Metadata{})).To(Succeed())
+			Expect(l.CreatePool("pool-b", "some-user", locker.Metadata{})).To(Succeed())
+			Expect(l.ClaimLock("pool-a", "some-owner", "")).To(Succeed())
+
+			Expect(l.ClaimAny([]string{"pool-c", "pool-a", "pool-b"}, "some-other-owner", "some message")).To(Equal("pool-b"))
+			lock := findLock("pool-b")
+			Expect(lock.Owner).To(Equal("some-other-owner"))
+			Expect(lock.Message).To(Equal("some message"))
+
+			Expect(l.ClaimAny([]string{"pool-a", "pool-b"}, "some-other-owner", "")).To(BeEmpty())
+		})
+
+		It("claims and releases several pools at once or not at all", func() {
+			Expect(l.CreatePool("pool-a", "some-user", locker.Metadata{})).To(Succeed())
+			Expect(l.CreatePool("pool-b", "some-user", locker.Metadata{})).To(Succeed())
+			Expect(l.CreatePool("pool-c", "some-user", locker.Metadata{})).To(Succeed())
+			Expect(l.ClaimLock("pool-c", "some-other-owner", "")).To(Succeed())
+
+			Expect(l.ClaimLocks([]string{"pool-a", "pool-c"}, "some-owner", "")).NotTo(Succeed())
+			Expect(findLock("pool-a").Claimed).To(BeFalse())
+
+			Expect(l.ClaimLocks([]string{"pool-a", "pool-b"}, "some-owner", "some message")).To(Succeed())
+			Expect(findLock("pool-a").Owner).To(Equal("some-owner"))
+			Expect(findLock("pool-b").Owner).To(Equal("some-owner"))
+			Expect(findLock("pool-b").Message).To(Equal("some message"))
+
+			Expect(l.ReleaseLocks([]string{"pool-a", "pool-b", "pool-c"}, "some-owner")).To(Succeed())
+			Expect(findLock("pool-a").Claimed).To(BeFalse())
+			Expect(findLock("pool-b").Claimed).To(BeFalse())
+			Expect(findLock("pool-c").Claimed).To(BeFalse())
+
+			Expect(l.ReleaseLocks([]string{"pool-a"}, "some-owner")).NotTo(Succeed())
+		})
+
+		It("supports pools nested in groups", func() {
+			Expect(l.CreatePool("aws/us-east/env-1", "some-user", locker.Metadata{})).To(Succeed())
+			Expect(l.CreatePool("aws/us-west/env-1", "some-user", locker.Metadata{})).To(Succeed())
+			Expect(l.CreatePool("pool-a", "some-user", locker.Metadata{})).To(Succeed())
+
+			Expect(l.ClaimLock("aws/us-east/env-1", "some-owner", "")).To(Succeed())
+			Expect(l.Status()).To(Equal([]locker.Lock{
+				{Name: "aws/us-east/env-1", Claimed: true, Owner: "some-owner", Date: findLock("aws/us-east/env-1").Date},
+				{Name: "aws/us-west/env-1", Claimed: false},
+				{Name: "pool-a", Claimed: false},
+			}))
+
+			history, err := l.History("aws/us-east/env-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history.Claims).To(HaveLen(1))
+
+			Expect(l.DestroyPool("aws/us-west/env-1", "some-user")).To(Succeed())
+			Expect(l.Status()).To(HaveLen(2))
+		})
+
+		It("refuses to claim or release pools which do not exist", func() {
+			Expect(l.ClaimLock("some-pool", "some-owner", "")).NotTo(Succeed())
+			Expect(l.ReleaseLock("some-pool", "some-owner")).NotTo(Succeed())
+		})
+
+		It("updates the message and expiry of claims", func() {
+			Expect(l.CreatePool("pool-a", "some-user", locker.Metadata{})).To(Succeed())
+			Expect(l.ClaimLock("pool-a", "some-owner", "some message")).To(Succeed())
+
+			expires := time.Now().Add(2 * time.Hour).Truncate(time.Second)
+			Expect(l.ExtendLock("pool-a", "some-owner", expires)).To(Succeed())
+			Expect(l.AnnotateLock("pool-a", "some-owner", "some other message")).To(Succeed())
+
+			lock := findLock("pool-a")
+			Expect(lock.Message).To(Equal("some other message"))
+			Expect(lock.Expires).To(BeTemporally("==", expires))
+
+			Expect(l.ReleaseLock("pool-a", "some-owner")).To(Succeed())
+			Expect(l.ClaimLock("pool-a", "some-other-owner", "")).To(Succeed())
+
+			lock = findLock("pool-a")
+			Expect(lock.Message).To(BeEmpty())
+			Expect(lock.Expires).To(BeZero())
+		})
+
+		It("destroys pools", func() {
+			Expect(l.CreatePool("pool-a", "some-user", locker.Metadata{})).To(Succeed())
+			Expect(l.CreatePool("pool-b", "some-user", locker.Metadata{})).To(Succeed())
+			Expect(l.ClaimLock("pool-a", "some-owner", "")).To(Succeed())
+
+			Expect(l.DestroyPool("pool-a", "some-user")).To(Succeed())
+
+			Expect(l.Status()).To(Equal([]locker.Lock{{Name: "pool-b", Claimed: false}}))
+			history, err := l.History("pool-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history.Creator).To(BeEmpty())
+			Expect(history.Claims).To(BeEmpty())
+		})
+
+		It("renames pools, keeping their claims and history", func() {
+			metadata := locker.Metadata{Description: "some description"}
+			Expect(l.CreatePool("pool-a", "some-creator", metadata)).To(Succeed())
+			Expect(l.CreatePool("pool-b", "some-creator", locker.Metadata{})).To(Succeed())
+			Expect(l.ClaimLock("pool-a", "some-owner", "some message")).To(Succeed())
+			expires := time.Now().Add(2 * time.Hour).Truncate(time.Second)
+			Expect(l.ExtendLock("pool-a", "some-owner", expires)).To(Succeed())
+			before := findLock("pool-a")
+
+			Expect(l.RenamePool("pool-a", "pool-b", "some-user")).NotTo(Succeed())
+			Expect(l.RenamePool("pool-a", "pool-a/nested", "some-user")).NotTo(Succeed())
+			Expect(l.RenamePool("pool-c", "pool-d", "some-user")).NotTo(Succeed())
+
+			Expect(l.RenamePool("pool-a", "some-group/pool-c", "some-user")).To(Succeed())
+			lock := findLock("some-group/pool-c")
+			Expect(lock.Claimed).To(BeTrue())
+			Expect(lock.Owner).To(Equal("some-owner"))
+			Expect(lock.Date).To(Equal(before.Date))
+			Expect(lock.Message).To(Equal("some message"))
+			Expect(lock.Expires).To(BeTemporally("==", expires))
+			Expect(lock.Metadata).To(Equal(metadata))
+			Expect(l.Status()).To(HaveLen(2))
+
+			Expect(l.RenamePool("pool-b", "some-group", "some-user")).NotTo(Succeed())
+
+			Expect(l.ReleaseLock("some-group/pool-c", "some-owner")).To(Succeed())
+			history, err := l.History("some-group/pool-c")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history.Pool).To(Equal("some-group/pool-c"))
+			Expect(history.Creator).To(Equal("some-creator"))
+			Expect(history.Claims).To(HaveLen(1))
+			Expect(history.Claims[0].Owner).To(Equal("some-owner"))
+			Expect(history.Claims[0].ReleasedBy).To(Equal("some-owner"))
+
+			history, err = l.History("pool-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history.Creator).To(BeEmpty())
+			Expect(history.Claims).To(BeEmpty())
+		})
+
+		It("keeps reservations of pools", func() {
+			Expect(l.CreatePool("pool-a", "some-user", locker.Metadata{})).To(Succeed())
+
+			start := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+			later := locker.Reservation{Owner: "some-other-owner", Start: start.Add(4 * time.Hour), End: start.Add(6 * time.Hour)}
+			reservation := locker.Reservation{Owner: "some-owner", Start: start, End: start.Add(2 * time.Hour), Message: "some message"}
+			Expect(l.Reserve("pool-a", later)).To(Succeed())
+			Expect(l.Reserve("pool-a", reservation)).To(Succeed())
+
+			overlapping := locker.Reservation{Owner: "some-other-owner", Start: start.Add(time.Hour), End: start.Add(3 * time.Hour)}
+			Expect(l.Reserve("pool-a", overlapping)).NotTo(Succeed())
+			Expect(l.Reserve("pool-b", reservation)).NotTo(Succeed())
+
+			reservations := findLock("pool-a").Reservations
+			Expect(reservations).To(HaveLen(2))
+			Expect(reservations[0].Owner).To(Equal("some-owner"))
+			Expect(reservations[0].Start).To(BeTemporally("==", reservation.Start))
+			Expect(reservations[0].End).To(BeTemporally("==", reservation.End))
+			Expect(reservations[0].Message).To(Equal("some message"))
+			Expect(reservations[1].Owner).To(Equal("some-other-owner"))
+
+			Expect(l.Unreserve("pool-a", reservation)).To(Succeed())
+			Expect(l.Unreserve("pool-a", reservation)).NotTo(Succeed())
+			Expect(findLock("pool-a").Reservations).To(HaveLen(1))
+
+			Expect(l.Unreserve("pool-a", later)).To(Succeed())
+			Expect(findLock("pool-a")).To(Equal(locker.Lock{Name: "pool-a", Claimed: false}))
+		})
+
+		It("disables and enables pools", func() {
+			Expect(l.CreatePool("pool-a", "some-user", locker.Metadata{})).To(Succeed())
+			Expect(l.CreatePool("pool-b", "some-user", locker.Metadata{})).To(Succeed())
+			Expect(l.ClaimLock("pool-b", "some-owner", "")).To(Succeed())
+
+			Expect(l.DisablePool("pool-b", "some-user", "")).NotTo(Succeed())
+			Expect(l.DisablePool("pool-c", "some-user", "")).NotTo(Succeed())
+			Expect(l.DisablePool("pool-a", "some-user", "repaving")).To(Succeed())
+			Expect(l.DisablePool("pool-a", "some-user", "")).NotTo(Succeed())
+
+			lock := findLock("pool-a")
+			Expect(lock.Claimed).To(BeFalse())
+			Expect(lock.Maintenance).NotTo(BeNil())
+			Expect(lock.Maintenance.By).To(Equal("some-user"))
+			Expect(lock.Maintenance.Reason).To(Equal("repaving"))
+			Expect(lock.Maintenance.Since).To(BeTemporally("~", time.Now(), 10*time.Second))
+
+			Expect(l.ClaimLock("pool-a", "some-owner", "")).NotTo(Succeed())
+			Expect(l.ClaimAny([]string{"pool-a"}, "some-owner", "")).To(BeEmpty())
+			Expect(l.ReleaseLock("pool-a", "some-user")).NotTo(Succeed())
+
+			Expect(l.EnablePool("pool-b", "some-user")).NotTo(Succeed())
+			Expect(l.EnablePool("pool-a", "some-user")).To(Succeed())
+			Expect(findLock("pool-a")).To(Equal(locker.Lock{Name: "pool-a", Claimed: false}))
+			Expect(l.ClaimLock("pool-a", "some-owner", "")).To(Succeed())
+
+			history, err := l.History("pool-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history.Claims).To(HaveLen(1))
+			Expect(history.Claims[0].Owner).To(Equal("some-owner"))
+			Expect(history.Claims[0].Active()).To(BeTrue())
+		})
+
+		It("records the history of each pool", func() {
+			Expect(l.CreatePool("pool-a", "some-creator", locker.Metadata{})).To(Succeed())
+			Expect(l.CreatePool("pool-b", "some-creator", locker.Metadata{})).To(Succeed())
+			Expect(l.ClaimLock("pool-a", "some-owner", "some message")).To(Succeed())
+			Expect(l.ReleaseLock("pool-a", "some-releaser")).To(Succeed())
+			Expect(l.ClaimLock("pool-a", "some-other-owner", "")).To(Succeed())
+			Expect(l.AnnotateLock("pool-a", "some-other-owner", "some other message")).To(Succeed())
+
+			history, err := l.History("pool-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history.Pool).To(Equal("pool-a"))
+			Expect(history.Creator).To(Equal("some-creator"))
+			Expect(history.Created).To(BeTemporally("~", time.Now(), 10*time.Second))
+			Expect(history.Claims).To(HaveLen(2))
+
+			Expect(history.Claims[0].Owner).To(Equal("some-owner"))
+			Expect(history.Claims[0].Message).To(Equal("some message"))
+			Expect(history.Claims[0].Claimed).To(BeTemporally("~", time.Now(), 10*time.Second))
+			Expect(history.Claims[0].Active()).To(BeFalse())
+			Expect(history.Claims[0].ReleasedBy).To(Equal("some-releaser"))
+
+			Expect(history.Claims[1].Owner).To(Equal("some-other-owner"))
+			Expect(history.Claims[1].Message).To(Equal("some other message"))
+			Expect(history.Claims[1].Active()).To(BeTrue())
+
+			histories, err := l.Histories()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(histories).To(HaveLen(2))
+			Expect(histories[0]).To(Equal(history))
+			Expect(histories[1].Pool).To(Equal("pool-b"))
+			Expect(histories[1].Claims).To(BeEmpty())
+		})
+
+		It("starts a new history when a pool is recreated", func() {
+			Expect(l.CreatePool("pool-a", "some-creator", locker.Metadata{})).To(Succeed())
+			Expect(l.ClaimLock("pool-a", "some-owner", "")).To(Succeed())
+			Expect(l.DestroyPool("pool-a", "some-user")).To(Succeed())
+			Expect(l.CreatePool("pool-a", "some-other-creator", locker.Metadata{})).To(Succeed())
+
+			history, err := l.History("pool-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history.Creator).To(Equal("some-other-creator"))
+			Expect(history.Claims).To(BeEmpty())
+		})
+	})
+}
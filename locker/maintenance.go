@@ -0,0 +1,123 @@
+package locker
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+const maintenanceFile = "disabled.yml"
+
+// Maintenance describes why a pool was disabled. The lock of a disabled pool
+// is kept in the claimed directory, so concourse treats it as claimed, and
+// the reason is kept in disabled.yml in the directory of the pool.
+type Maintenance struct {
+	By     string    `yaml:"by"`
+	Reason string    `yaml:"reason,omitempty"`
+	Since  time.Time `yaml:"since"`
+}
+
+// DisablePool takes an unclaimed pool out of use, e.g. while it is being
+// repaved. Nobody can claim it until it is enabled again.
+func (l *locker) DisablePool(pool, user, reason string) error {
+	author := l.author(user)
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if err := l.pull(); err != nil {
+		return errors.Wrap(err, "failed to clone or pull")
+	}
+	if err := l.checkPoolExists(pool); err != nil {
+		return err
+	}
+	maintenance, err := l.maintenance(pool)
+	if err != nil {
+		return err
+	}
+	if maintenance != nil {
+		return errors.Errorf("pool %s is already disabled", pool)
+	}
+
+	lock, err := l.singleLock(pool, "unclaimed")
+	if err != nil {
+		return err
+	}
+	if err := l.moveLock(pool, lock, "unclaimed", "claimed"); err != nil {
+		return err
+	}
+	contents, err := yaml.Marshal(Maintenance{By: user, Reason: reason, Since: time.Now().Truncate(time.Second)})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal maintenance file")
+	}
+	if err := l.fs.WriteFile(filepath.Join(l.gitRepo.Dir(), pool, maintenanceFile), contents); err != nil {
+		return errors.Wrap(err, "failed to write maintenance file")
+	}
+
+	commitMessage := "Claimer disabling " + pool
+	if reason != "" {
+		commitMessage += "\n\n" + reason
+	}
+	if err := l.gitRepo.CommitAndPush(commitMessage, author); err != nil {
+		return errors.Wrap(err, "failed to commit and push")
+	}
+	return nil
+}
+
+// EnablePool puts a disabled pool back in use, unclaimed
+func (l *locker) EnablePool(pool, user string) error {
+	author := l.author(user)
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if err := l.pull(); err != nil {
+		return errors.Wrap(err, "failed to clone or pull")
+	}
+	maintenance, err := l.maintenance(pool)
+	if err != nil {
+		return err
+	}
+	if maintenance == nil {
+		return errors.Errorf("pool %s is not disabled", pool)
+	}
+
+	lock, err := l.singleLock(pool, "claimed")
+	if err != nil {
+		return err
+	}
+	if err := l.moveLock(pool, lock, "claimed", "unclaimed"); err != nil {
+		return err
+	}
+	if err := l.fs.Rm(filepath.Join(l.gitRepo.Dir(), pool, maintenanceFile)); err != nil {
+		return errors.Wrap(err, "failed to remove maintenance file")
+	}
+
+	if err := l.gitRepo.CommitAndPush("Claimer enabling "+pool, author); err != nil {
+		return errors.Wrap(err, "failed to commit and push")
+	}
+	return nil
+}
+
+// maintenance returns the contents of disabled.yml, or nil if the pool is
+// not disabled
+func (l *locker) maintenance(pool string) (*Maintenance, error) {
+	path := filepath.Join(l.gitRepo.Dir(), pool, maintenanceFile)
+	exists, err := l.fs.Exists(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check for maintenance file")
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	contents, err := l.fs.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read maintenance file")
+	}
+	var maintenance Maintenance
+	if err := yaml.Unmarshal(contents, &maintenance); err != nil {
+		return nil, errors.Wrap(err, "failed to parse maintenance file")
+	}
+	return &maintenance, nil
+}
@@ -0,0 +1,151 @@
+package locker_test
+
+import (
+	. "github.com/mdelillo/claimer/locker"
+
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mdelillo/claimer/fs"
+	"github.com/mdelillo/claimer/git"
+	"github.com/mdelillo/claimer/locker/lockerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+)
+
+var _ = Describe("Maintenance", func() {
+	var (
+		gitDir  string
+		gitRepo *lockerfakes.FakeGitRepo
+		locker  Locker
+	)
+
+	BeforeEach(func() {
+		var err error
+		gitDir, err = ioutil.TempDir("", "claimer-locker-maintenance")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fs.NewFs().Touch(filepath.Join(gitDir, "some-pool", "claimed", ".gitkeep"))).To(Succeed())
+		Expect(fs.NewFs().Touch(filepath.Join(gitDir, "some-pool", "unclaimed", "some-lock"))).To(Succeed())
+
+		gitRepo = new(lockerfakes.FakeGitRepo)
+		gitRepo.DirReturns(gitDir)
+		locker = NewLocker(fs.NewFs(), gitRepo, new(lockerfakes.FakeAuthors), 0)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(gitDir)
+	})
+
+	Describe("DisablePool", func() {
+		It("moves the lock to claimed, records the reason and commits", func() {
+			Expect(locker.DisablePool("some-pool", "some-user", "some reason")).To(Succeed())
+
+			Expect(gitRepo.CloneOrPullCallCount()).To(Equal(1))
+			Expect(filepath.Join(gitDir, "some-pool", "claimed", "some-lock")).To(BeAnExistingFile())
+			Expect(filepath.Join(gitDir, "some-pool", "unclaimed", "some-lock")).NotTo(BeAnExistingFile())
+
+			contents, err := ioutil.ReadFile(filepath.Join(gitDir, "some-pool", "disabled.yml"))
+			Expect(err).NotTo(HaveOccurred())
+			var maintenance Maintenance
+			Expect(yaml.Unmarshal(contents, &maintenance)).To(Succeed())
+			Expect(maintenance.By).To(Equal("some-user"))
+			Expect(maintenance.Reason).To(Equal("some reason"))
+			Expect(maintenance.Since).To(BeTemporally("~", time.Now(), 10*time.Second))
+
+			Expect(gitRepo.CommitAndPushCallCount()).To(Equal(1))
+			message, author := gitRepo.CommitAndPushArgsForCall(0)
+			Expect(message).To(Equal("Claimer disabling some-pool\n\nsome reason"))
+			Expect(author).To(Equal(git.Identity{Name: "some-user"}))
+		})
+
+		It("shows the pool as disabled rather than claimed", func() {
+			Expect(locker.DisablePool("some-pool", "some-user", "")).To(Succeed())
+
+			locks, err := locker.Status()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(locks).To(HaveLen(1))
+			Expect(locks[0].Claimed).To(BeFalse())
+			Expect(locks[0].Maintenance).NotTo(BeNil())
+			Expect(locks[0].Maintenance.By).To(Equal("some-user"))
+			Expect(gitRepo.LatestCommitsCallCount()).To(Equal(0))
+		})
+
+		Context("when the pool is already disabled", func() {
+			It("returns an error", func() {
+				Expect(locker.DisablePool("some-pool", "some-user", "")).To(Succeed())
+				Expect(locker.DisablePool("some-pool", "some-user", "")).To(MatchError("pool some-pool is already disabled"))
+			})
+		})
+
+		Context("when the pool is claimed", func() {
+			It("returns an error", func() {
+				Expect(locker.ClaimLock("some-pool", "some-user", "")).To(Succeed())
+				Expect(locker.DisablePool("some-pool", "some-user", "")).To(MatchError("no unclaimed locks for pool some-pool"))
+			})
+		})
+
+		Context("when the pool does not exist", func() {
+			It("returns an error", func() {
+				Expect(locker.DisablePool("some-missing-pool", "some-user", "")).To(MatchError("pool some-missing-pool does not exist"))
+			})
+		})
+
+		Context("when cloning the repo fails", func() {
+			It("returns an error", func() {
+				gitRepo.CloneOrPullReturns(errors.New("some-error"))
+
+				Expect(locker.DisablePool("some-pool", "some-user", "")).To(MatchError("failed to clone or pull: some-error"))
+			})
+		})
+
+		Context("when pushing fails", func() {
+			It("returns an error", func() {
+				gitRepo.CommitAndPushReturns(errors.New("some-error"))
+
+				Expect(locker.DisablePool("some-pool", "some-user", "")).To(MatchError("failed to commit and push: some-error"))
+			})
+		})
+	})
+
+	Describe("EnablePool", func() {
+		BeforeEach(func() {
+			Expect(locker.DisablePool("some-pool", "some-user", "some reason")).To(Succeed())
+		})
+
+		It("moves the lock back to unclaimed, removes the reason and commits", func() {
+			Expect(locker.EnablePool("some-pool", "some-other-user")).To(Succeed())
+
+			Expect(filepath.Join(gitDir, "some-pool", "unclaimed", "some-lock")).To(BeAnExistingFile())
+			Expect(filepath.Join(gitDir, "some-pool", "claimed", "some-lock")).NotTo(BeAnExistingFile())
+			Expect(filepath.Join(gitDir, "some-pool", "disabled.yml")).NotTo(BeAnExistingFile())
+
+			Expect(gitRepo.CommitAndPushCallCount()).To(Equal(2))
+			message, author := gitRepo.CommitAndPushArgsForCall(1)
+			Expect(message).To(Equal("Claimer enabling some-pool"))
+			Expect(author).To(Equal(git.Identity{Name: "some-other-user"}))
+		})
+
+		It("refuses to release the pool instead", func() {
+			Expect(locker.ReleaseLock("some-pool", "some-user")).To(MatchError("pool some-pool is disabled"))
+		})
+
+		Context("when the pool is not disabled", func() {
+			It("returns an error", func() {
+				Expect(locker.EnablePool("some-pool", "some-user")).To(Succeed())
+				Expect(locker.EnablePool("some-pool", "some-user")).To(MatchError("pool some-pool is not disabled"))
+			})
+		})
+
+		Context("when pushing fails", func() {
+			It("returns an error", func() {
+				gitRepo.CommitAndPushReturns(errors.New("some-error"))
+
+				Expect(locker.EnablePool("some-pool", "some-user")).To(MatchError("failed to commit and push: some-error"))
+			})
+		})
+	})
+})
@@ -0,0 +1,166 @@
+package locker
+
+import (
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+const reservationsFile = "reservations.yml"
+
+// Reservation books a pool for Owner from Start until End. Reservations are
+// kept in reservations.yml in the directory of the pool, which concourse
+// ignores, so they are only enforced by claimer.
+type Reservation struct {
+	Owner   string    `yaml:"owner"`
+	Start   time.Time `yaml:"start"`
+	End     time.Time `yaml:"end"`
+	Message string    `yaml:"message,omitempty"`
+}
+
+type reservationsFileContents struct {
+	Reservations []Reservation `yaml:"reservations"`
+}
+
+// Active reports whether the reservation covers the given time
+func (r Reservation) Active(now time.Time) bool {
+	return !now.Before(r.Start) && now.Before(r.End)
+}
+
+func (r Reservation) Overlaps(other Reservation) bool {
+	return r.Start.Before(other.End) && other.Start.Before(r.End)
+}
+
+// Reserve books a pool for a future window. Reservations which have ended
+// are dropped, and overlapping reservations are refused.
+func (l *locker) Reserve(pool string, reservation Reservation) error {
+	author := l.author(reservation.Owner)
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if err := l.pull(); err != nil {
+		return errors.Wrap(err, "failed to clone or pull")
+	}
+	if err := l.checkPoolExists(pool); err != nil {
+		return err
+	}
+
+	existing, err := l.reservations(pool)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	reservations := []Reservation{reservation}
+	for _, r := range existing {
+		if !r.End.After(now) {
+			continue
+		}
+		if r.Overlaps(reservation) {
+			return errors.Errorf("%s is already reserved by %s from %s until %s", pool, r.Owner, r.Start.Format(DateFormat), r.End.Format(DateFormat))
+		}
+		reservations = append(reservations, r)
+	}
+	sort.Slice(reservations, func(i, j int) bool { return reservations[i].Start.Before(reservations[j].Start) })
+	if err := l.writeReservations(pool, reservations); err != nil {
+		return err
+	}
+
+	commitMessage := "Claimer reserving " + pool + " from " + reservation.Start.Format(DateFormat) + " until " + reservation.End.Format(DateFormat)
+	if reservation.Message != "" {
+		commitMessage += "\n\n" + reservation.Message
+	}
+	if err := l.gitRepo.CommitAndPush(commitMessage, author); err != nil {
+		return errors.Wrap(err, "failed to commit and push")
+	}
+	return nil
+}
+
+// Unreserve removes the reservation of a pool by the same owner starting at
+// the same time
+func (l *locker) Unreserve(pool string, reservation Reservation) error {
+	author := l.author(reservation.Owner)
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if err := l.pull(); err != nil {
+		return errors.Wrap(err, "failed to clone or pull")
+	}
+
+	existing, err := l.reservations(pool)
+	if err != nil {
+		return err
+	}
+	var reservations []Reservation
+	for _, r := range existing {
+		if r.Owner != reservation.Owner || !r.Start.Equal(reservation.Start) {
+			reservations = append(reservations, r)
+		}
+	}
+	if len(reservations) == len(existing) {
+		return errors.Errorf("%s has no reservation by %s starting %s", pool, reservation.Owner, reservation.Start.Format(DateFormat))
+	}
+	if err := l.writeReservations(pool, reservations); err != nil {
+		return err
+	}
+
+	if err := l.gitRepo.CommitAndPush("Claimer unreserving "+pool, author); err != nil {
+		return errors.Wrap(err, "failed to commit and push")
+	}
+	return nil
+}
+
+func (l *locker) checkPoolExists(pool string) error {
+	pools, err := l.fs.LsPools(l.gitRepo.Dir())
+	if err != nil {
+		return errors.Wrap(err, "failed to list pools")
+	}
+	if !contains(pools, pool) {
+		return errors.Errorf("pool %s does not exist", pool)
+	}
+	return nil
+}
+
+// reservations returns the contents of reservations.yml, which most pools do
+// not have
+func (l *locker) reservations(pool string) ([]Reservation, error) {
+	path := filepath.Join(l.gitRepo.Dir(), pool, reservationsFile)
+	exists, err := l.fs.Exists(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check for reservations file")
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	contents, err := l.fs.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read reservations file")
+	}
+	var file reservationsFileContents
+	if err := yaml.Unmarshal(contents, &file); err != nil {
+		return nil, errors.Wrap(err, "failed to parse reservations file")
+	}
+	return file.Reservations, nil
+}
+
+func (l *locker) writeReservations(pool string, reservations []Reservation) error {
+	path := filepath.Join(l.gitRepo.Dir(), pool, reservationsFile)
+	if len(reservations) == 0 {
+		if err := l.fs.Rm(path); err != nil {
+			return errors.Wrap(err, "failed to remove reservations file")
+		}
+		return nil
+	}
+
+	contents, err := yaml.Marshal(reservationsFileContents{Reservations: reservations})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal reservations file")
+	}
+	if err := l.fs.WriteFile(path, contents); err != nil {
+		return errors.Wrap(err, "failed to write reservations file")
+	}
+	return nil
+}
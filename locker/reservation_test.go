@@ -0,0 +1,166 @@
+package locker_test
+
+import (
+	. "github.com/mdelillo/claimer/locker"
+
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mdelillo/claimer/fs"
+	"github.com/mdelillo/claimer/git"
+	"github.com/mdelillo/claimer/locker/lockerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Reservations", func() {
+	var (
+		gitDir  string
+		gitRepo *lockerfakes.FakeGitRepo
+		locker  Locker
+		start   time.Time
+	)
+
+	reservationsFile := func() string {
+		contents, err := ioutil.ReadFile(filepath.Join(gitDir, "some-pool", "reservations.yml"))
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		return string(contents)
+	}
+
+	BeforeEach(func() {
+		var err error
+		gitDir, err = ioutil.TempDir("", "claimer-locker-reservations")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fs.NewFs().Touch(filepath.Join(gitDir, "some-pool", "unclaimed", "some-pool"))).To(Succeed())
+
+		gitRepo = new(lockerfakes.FakeGitRepo)
+		gitRepo.DirReturns(gitDir)
+		locker = NewLocker(fs.NewFs(), gitRepo, new(lockerfakes.FakeAuthors), 0)
+
+		start = time.Now().Add(24 * time.Hour).Truncate(time.Minute).UTC()
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(gitDir)
+	})
+
+	Describe("Reserve", func() {
+		It("records the reservation in the pool and commits", func() {
+			reservation := Reservation{Owner: "some-user", Start: start, End: start.Add(2 * time.Hour), Message: "some message"}
+			Expect(locker.Reserve("some-pool", reservation)).To(Succeed())
+
+			Expect(gitRepo.CloneOrPullCallCount()).To(Equal(1))
+			Expect(reservationsFile()).To(MatchYAML(`reservations:
+- owner: some-user
+  start: ` + start.Format(time.RFC3339) + `
+  end: ` + start.Add(2*time.Hour).Format(time.RFC3339) + `
+  message: some message
+`))
+
+			Expect(gitRepo.CommitAndPushCallCount()).To(Equal(1))
+			message, author := gitRepo.CommitAndPushArgsForCall(0)
+			Expect(message).To(Equal("Claimer reserving some-pool from " + start.Format(DateFormat) + " until " + start.Add(2*time.Hour).Format(DateFormat) + "\n\nsome message"))
+			Expect(author).To(Equal(git.Identity{Name: "some-user"}))
+		})
+
+		It("drops reservations which have ended", func() {
+			ended := Reservation{Owner: "some-other-user", Start: time.Now().Add(-2 * time.Hour), End: time.Now().Add(-time.Hour)}
+			Expect(locker.Reserve("some-pool", ended)).To(Succeed())
+
+			Expect(locker.Reserve("some-pool", Reservation{Owner: "some-user", Start: start, End: start.Add(time.Hour)})).To(Succeed())
+			Expect(reservationsFile()).NotTo(ContainSubstring("some-other-user"))
+		})
+
+		Context("when the reservation overlaps another one", func() {
+			It("returns an error", func() {
+				Expect(locker.Reserve("some-pool", Reservation{Owner: "some-other-user", Start: start, End: start.Add(2 * time.Hour)})).To(Succeed())
+
+				err := locker.Reserve("some-pool", Reservation{Owner: "some-user", Start: start.Add(time.Hour), End: start.Add(3 * time.Hour)})
+				Expect(err).To(MatchError(
+					"some-pool is already reserved by some-other-user from " + start.Format(DateFormat) + " until " + start.Add(2*time.Hour).Format(DateFormat),
+				))
+				Expect(gitRepo.CommitAndPushCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the pool does not exist", func() {
+			It("returns an error", func() {
+				err := locker.Reserve("some-missing-pool", Reservation{Owner: "some-user", Start: start, End: start.Add(time.Hour)})
+				Expect(err).To(MatchError("pool some-missing-pool does not exist"))
+			})
+		})
+
+		Context("when the reservations file cannot be parsed", func() {
+			It("returns an error", func() {
+				Expect(ioutil.WriteFile(filepath.Join(gitDir, "some-pool", "reservations.yml"), []byte("reservations: {"), 0644)).To(Succeed())
+
+				err := locker.Reserve("some-pool", Reservation{Owner: "some-user", Start: start, End: start.Add(time.Hour)})
+				Expect(err).To(MatchError(ContainSubstring("failed to parse reservations file: ")))
+			})
+		})
+
+		Context("when cloning the repo fails", func() {
+			It("returns an error", func() {
+				gitRepo.CloneOrPullReturns(errors.New("some-error"))
+
+				Expect(locker.Reserve("some-pool", Reservation{})).To(MatchError("failed to clone or pull: some-error"))
+			})
+		})
+
+		Context("when pushing fails", func() {
+			It("returns an error", func() {
+				gitRepo.CommitAndPushReturns(errors.New("some-error"))
+
+				err := locker.Reserve("some-pool", Reservation{Owner: "some-user", Start: start, End: start.Add(time.Hour)})
+				Expect(err).To(MatchError("failed to commit and push: some-error"))
+			})
+		})
+	})
+
+	Describe("Unreserve", func() {
+		var reservation Reservation
+
+		BeforeEach(func() {
+			reservation = Reservation{Owner: "some-user", Start: start, End: start.Add(time.Hour)}
+			Expect(locker.Reserve("some-pool", reservation)).To(Succeed())
+		})
+
+		It("removes the reservation and commits", func() {
+			Expect(locker.Reserve("some-pool", Reservation{Owner: "some-other-user", Start: start.Add(time.Hour), End: start.Add(2 * time.Hour)})).To(Succeed())
+
+			Expect(locker.Unreserve("some-pool", reservation)).To(Succeed())
+			Expect(reservationsFile()).NotTo(ContainSubstring("owner: some-user\n"))
+			Expect(reservationsFile()).To(ContainSubstring("owner: some-other-user\n"))
+
+			Expect(gitRepo.CommitAndPushCallCount()).To(Equal(3))
+			message, author := gitRepo.CommitAndPushArgsForCall(2)
+			Expect(message).To(Equal("Claimer unreserving some-pool"))
+			Expect(author).To(Equal(git.Identity{Name: "some-user"}))
+		})
+
+		It("removes the reservations file along with the last reservation", func() {
+			Expect(locker.Unreserve("some-pool", reservation)).To(Succeed())
+			Expect(filepath.Join(gitDir, "some-pool", "reservations.yml")).NotTo(BeAnExistingFile())
+		})
+
+		Context("when there is no such reservation", func() {
+			It("returns an error", func() {
+				reservation.Owner = "some-other-user"
+				Expect(locker.Unreserve("some-pool", reservation)).To(MatchError(
+					"some-pool has no reservation by some-other-user starting " + start.Format(DateFormat),
+				))
+			})
+		})
+
+		Context("when pushing fails", func() {
+			It("returns an error", func() {
+				gitRepo.CommitAndPushReturns(errors.New("some-error"))
+
+				Expect(locker.Unreserve("some-pool", reservation)).To(MatchError("failed to commit and push: some-error"))
+			})
+		})
+	})
+})
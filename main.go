@@ -4,41 +4,81 @@ import (
 	"flag"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"os"
+	"strings"
 
+	"github.com/mdelillo/claimer/api"
 	"github.com/mdelillo/claimer/bot"
 	"github.com/mdelillo/claimer/bot/commands"
 	"github.com/mdelillo/claimer/fs"
 	"github.com/mdelillo/claimer/git"
+	"github.com/mdelillo/claimer/identity"
 	"github.com/mdelillo/claimer/locker"
+	"github.com/mdelillo/claimer/reminder"
+	"github.com/mdelillo/claimer/reservation"
+	"github.com/mdelillo/claimer/scheduler"
 	"github.com/mdelillo/claimer/slack"
 	"github.com/mdelillo/claimer/slack/requests"
+	"github.com/mdelillo/claimer/store"
 	"github.com/mdelillo/claimer/translate"
 	"github.com/mdelillo/claimer/translations"
 	"github.com/sirupsen/logrus"
 )
 
 func main() {
+	os.Exit(run())
+}
+
+// run runs claimer and returns the exit code. It returns rather than exiting
+// so that deferred cleanup, such as removing the temp directory, happens.
+func run() int {
 	apiToken := flag.String("apiToken", "", "API Token for Slack")
+	slackUrl := flag.String("slackUrl", "https://slack.com", "URL of the Slack API")
 	channelId := flag.String("channelId", "", "ID of slack channel to listen in")
 	repoUrl := flag.String("repoUrl", "", "URL for git repository of locks")
 	deployKey := flag.String("deployKey", "", "Deploy key for Github")
+	deployKeyPassphrase := flag.String("deployKeyPassphrase", "", "Passphrase for an encrypted deploy key")
+	sshAgent := flag.Bool("sshAgent", false, "Authenticate with the keys held by ssh-agent")
+	repoUsername := flag.String("repoUsername", "", "Username for an HTTPS git repository (default: x-access-token)")
+	repoToken := flag.String("repoToken", "", "Password or access token for an HTTPS git repository")
+	knownHosts := flag.String("knownHosts", "", "known_hosts file with the host key of the SSH git server (default: $SSH_KNOWN_HOSTS or ~/.ssh/known_hosts)")
+	signingFormat := flag.String("signingFormat", "gpg", "Format of the commit signing key: gpg or ssh")
+	signingKey := flag.String("signingKey", "", "Private key to sign commits with (default: commits are not signed)")
+	signingKeyFile := flag.String("signingKeyFile", "", "File containing the private key to sign commits with")
+	signingKeyPassphrase := flag.String("signingKeyPassphrase", "", "Passphrase for an encrypted signing key")
+	hostKeyFingerprints := flag.String("hostKeyFingerprints", "", "Comma-separated SHA256 fingerprints of the host key of the SSH git server")
+	authorsFile := flag.String("authorsFile", "", "Yaml file mapping chat usernames to git author emails (default: emails from Slack profiles)")
+	committerName := flag.String("committerName", git.DefaultCommitter.Name, "Name of the committer of changes to the git repository")
+	committerEmail := flag.String("committerEmail", git.DefaultCommitter.Email, "Email of the committer of changes to the git repository")
+	workDir := flag.String("workDir", "", "Directory to keep the clone of the git repository in between restarts (default: a new temp directory)")
+	minFetchInterval := flag.Duration("minFetchInterval", 0, "Minimum time between fetches of the git repository when reading locks")
 	translationFile := flag.String("translationFile", "", "Yaml file with message translations")
+	scheduleFile := flag.String("scheduleFile", "", "Yaml file with commands to run on a schedule")
+	storeType := flag.String("store", "git", "Where to keep locks: git, file or memory")
+	storeFile := flag.String("storeFile", "", "Yaml file to keep locks in when using the file store")
+	httpAddr := flag.String("httpAddr", "", "Address to serve the status of the pools as JSON and the API on, e.g. :8080 (default: not served)")
+	apiTokensFile := flag.String("apiTokensFile", "", "Yaml file mapping API tokens to the usernames they act as (default: the API refuses every request)")
+	publicStatus := flag.Bool("publicStatus", true, "Serve /status without an API token, e.g. for dashboards; set to false to require one")
+	username := flag.String("user", os.Getenv("USER"), "User to record as the author of changes made by commands run from the command line")
 	flag.Parse()
 
 	if err := translate.LoadTranslations(translations.DefaultTranslations); err != nil {
 		fmt.Printf("Error loading translations: %s\n", err)
-		os.Exit(1)
+		return 1
 	}
 	if *translationFile != "" {
 		if err := translate.LoadTranslationFile(*translationFile); err != nil {
 			fmt.Printf("Error loading translations from %s: %s\n", *translationFile, err)
-			os.Exit(1)
+			return 1
 		}
 	}
 
 	logger := logrus.New()
 	logger.Out = os.Stdout
+	if flag.NArg() > 0 {
+		logger.Out = os.Stderr
+	}
 	logger.Formatter = &logrus.TextFormatter{FullTimestamp: true}
 
 	if logLevel, ok := os.LookupEnv("LOG_LEVEL"); ok {
@@ -49,30 +89,163 @@ func main() {
 		}
 	}
 
-	gitDir, err := ioutil.TempDir("", "claimer-git-repo")
-	if err != nil {
-		fmt.Printf("Error creating temp directory: %s\n", err)
-	}
-	defer os.RemoveAll(gitDir)
-
-	claimer := bot.New(
-		commands.NewFactory(
-			locker.NewLocker(
-				fs.NewFs(),
-				git.NewRepo(*repoUrl, *deployKey, gitDir),
-			),
-		),
-		slack.NewClient(
-			requests.NewFactory("https://slack.com", *apiToken),
-			*channelId,
-			logger,
-		),
+	slackClient := slack.NewClient(
+		requests.NewFactory(*slackUrl, *apiToken),
+		*channelId,
 		logger,
 	)
 
+	var locks locker.Locker
+	switch *storeType {
+	case "git":
+		gitDir := *workDir
+		if gitDir == "" {
+			var err error
+			gitDir, err = ioutil.TempDir("", "claimer-git-repo")
+			if err != nil {
+				fmt.Printf("Error creating temp directory: %s\n", err)
+			}
+			defer os.RemoveAll(gitDir)
+		}
+
+		signer, err := newSigner(*signingFormat, *signingKey, *signingKeyFile, *signingKeyPassphrase)
+		if err != nil {
+			fmt.Printf("Error loading signing key: %s\n", err)
+			return 1
+		}
+
+		var authors map[string]string
+		if *authorsFile != "" {
+			authors, err = identity.LoadMapping(*authorsFile)
+			if err != nil {
+				fmt.Printf("Error loading authors from %s: %s\n", *authorsFile, err)
+				return 1
+			}
+		}
+
+		authorIdentity := identity.New(authors, slackClient, logger)
+		if flag.NArg() > 0 {
+			// commands run from the command line usually have no Slack
+			// token, so they only use -authorsFile
+			authorIdentity = identity.New(authors, nil, logger)
+		}
+
+		locks = locker.NewLocker(
+			fs.NewFs(),
+			git.NewRepo(*repoUrl, git.Auth{
+				DeployKey:  *deployKey,
+				Passphrase: *deployKeyPassphrase,
+				SSHAgent:   *sshAgent,
+				Username:   *repoUsername,
+				Password:   *repoToken,
+
+				KnownHosts:          *knownHosts,
+				HostKeyFingerprints: splitList(*hostKeyFingerprints),
+			}, gitDir, signer, git.Identity{Name: *committerName, Email: *committerEmail}),
+			authorIdentity,
+			*minFetchInterval,
+		)
+	case "file":
+		if *storeFile == "" {
+			fmt.Println("Error: -storeFile must be set when using the file store")
+			return 1
+		}
+		locks = store.NewFile(fs.NewFs(), *storeFile)
+	case "memory":
+		locks = store.NewMemory()
+	default:
+		fmt.Printf("Error: unknown store %s\n", *storeType)
+		return 1
+	}
+	commandFactory := commands.NewFactory(locks)
+
+	if flag.NArg() > 0 {
+		if err := runCli(flag.Args(), locks, commands.NewActions(locks), commandFactory, *username, os.Stdout); err != nil {
+			fmt.Printf("Error: %s\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	claimer := bot.New(commandFactory, slackClient, logger)
+
+	if *scheduleFile != "" {
+		config, err := scheduler.LoadConfig(*scheduleFile)
+		if err != nil {
+			fmt.Printf("Error loading schedules from %s: %s\n", *scheduleFile, err)
+			return 1
+		}
+		reminders, err := reminder.New(config.Reminders, *channelId, locks, slackClient, logger)
+		if err != nil {
+			fmt.Printf("Error loading schedules from %s: %s\n", *scheduleFile, err)
+			return 1
+		}
+		slackClient.OnDirectMessage(reminders.HandleDirectMessage)
+
+		schedules, err := scheduler.New(config, *channelId, commandFactory, reminders, slackClient, logger)
+		if err != nil {
+			fmt.Printf("Error loading schedules from %s: %s\n", *scheduleFile, err)
+			return 1
+		}
+		go schedules.Run()
+	}
+
+	go reservation.New(*channelId, locks, slackClient, logger).Run()
+
+	if *httpAddr != "" {
+		var tokens map[string]string
+		if *apiTokensFile != "" {
+			var err error
+			tokens, err = api.LoadTokens(*apiTokensFile)
+			if err != nil {
+				fmt.Printf("Error loading API tokens from %s: %s\n", *apiTokensFile, err)
+				return 1
+			}
+		}
+		go func() {
+			logger.Infof("Serving status on %s", *httpAddr)
+			if err := http.ListenAndServe(*httpAddr, api.NewServer(locks, commands.NewActions(locks), tokens, *publicStatus, logger)); err != nil {
+				logger.Errorf("failed to serve status: %s", err)
+			}
+		}()
+	}
+
 	logger.Info("Claimer starting")
 	if err := claimer.Run(); err != nil {
 		fmt.Printf("Error: %s\n", err)
 	}
 	logger.Info("Claimer finished")
+	return 0
+}
+
+func newSigner(format, key, keyFile, passphrase string) (git.Signer, error) {
+	if keyFile != "" {
+		contents, err := ioutil.ReadFile(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		key = string(contents)
+	}
+	if key == "" {
+		return nil, nil
+	}
+
+	switch format {
+	case "gpg":
+		return git.NewGPGSigner(key, passphrase)
+	case "ssh":
+		return git.NewSSHSigner(key, passphrase)
+	default:
+		return nil, fmt.Errorf("unknown signing format %s", format)
+	}
+}
+
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
@@ -0,0 +1,259 @@
+package reminder
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	clocker "github.com/mdelillo/claimer/locker"
+	. "github.com/mdelillo/claimer/translate"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const defaultThreshold = 24 * time.Hour
+
+//go:generate counterfeiter . locker
+type locker interface {
+	ReleaseLock(pool, username string) error
+	Status() ([]clocker.Lock, error)
+}
+
+//go:generate counterfeiter . slackClient
+type slackClient interface {
+	PostDirectMessage(username, message string) error
+	PostMessage(channel, message string) error
+}
+
+// Config describes when owners of claimed locks are asked whether they still
+// need them. Threshold defaults to 24h. Once an owner has ignored
+// EscalateAfter reminders the claim is reported in Channel instead; zero
+// disables escalation.
+type Config struct {
+	Threshold     string `yaml:"threshold"`
+	EscalateAfter int    `yaml:"escalate_after"`
+	Channel       string `yaml:"channel"`
+}
+
+// claim tracks the reminders sent about a single claim of a pool
+type claim struct {
+	owner     string
+	date      string
+	reminders int
+	kept      time.Time
+}
+
+type reminder struct {
+	threshold     time.Duration
+	escalateAfter int
+	channel       string
+
+	locker      locker
+	slackClient slackClient
+
+	logger *logrus.Logger
+
+	// mutex guards claims, which is used both by the scheduler and by the
+	// handler for direct messages
+	mutex  sync.Mutex
+	claims map[string]*claim
+}
+
+func New(config Config, defaultChannel string, locker locker, slackClient slackClient, logger *logrus.Logger) (*reminder, error) {
+	threshold := defaultThreshold
+	if config.Threshold != "" {
+		var err error
+		threshold, err = time.ParseDuration(config.Threshold)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse reminder threshold")
+		}
+	}
+
+	channel := config.Channel
+	if channel == "" {
+		channel = defaultChannel
+	}
+
+	return &reminder{
+		threshold:     threshold,
+		escalateAfter: config.EscalateAfter,
+		channel:       channel,
+		locker:        locker,
+		slackClient:   slackClient,
+		logger:        logger,
+		claims:        map[string]*claim{},
+	}, nil
+}
+
+// Remind sends a direct message to the owner of every lock which has been
+// claimed for longer than the threshold, or posts in the channel if the owner
+// has not answered enough previous reminders.
+func (r *reminder) Remind(now time.Time) error {
+	locks, err := r.locker.Status()
+	if err != nil {
+		return errors.Wrap(err, "failed to get status of locks")
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	claims := map[string]*claim{}
+	for _, lock := range locks {
+		if !lock.Claimed {
+			continue
+		}
+
+		c, ok := r.claims[lock.Name]
+		if !ok || c.owner != lock.Owner || c.date != lock.Date {
+			c = &claim{owner: lock.Owner, date: lock.Date}
+		}
+		claims[lock.Name] = c
+
+		if !r.due(lock, c, now) {
+			continue
+		}
+
+		if r.escalateAfter > 0 && c.reminders >= r.escalateAfter {
+			message := T("remind.escalate", TArgs{
+				"owner":     lock.Owner,
+				"pool":      lock.Name,
+				"date":      lock.Date,
+				"reminders": strconv.Itoa(c.reminders),
+			})
+			if err := r.slackClient.PostMessage(r.channel, message); err != nil {
+				r.logger.Errorf("failed to post to slack: %s", err)
+			}
+			continue
+		}
+
+		message := T("remind.question", TArgs{"pool": lock.Name, "date": lock.Date})
+		if err := r.slackClient.PostDirectMessage(lock.Owner, message); err != nil {
+			r.logger.WithFields(logrus.Fields{
+				"error": err.Error(),
+				"owner": lock.Owner,
+				"pool":  lock.Name,
+			}).Error("failed to send reminder")
+			continue
+		}
+		c.reminders++
+	}
+	r.claims = claims
+
+	return nil
+}
+
+func (r *reminder) due(lock clocker.Lock, c *claim, now time.Time) bool {
+	claimed, err := time.Parse(clocker.DateFormat, lock.Date)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"date": lock.Date,
+			"pool": lock.Name,
+		}).Error("failed to parse claim date")
+		return false
+	}
+
+	if now.Sub(claimed) < r.threshold {
+		return false
+	}
+	if lock.Expires.After(now) {
+		return false
+	}
+	if !c.kept.IsZero() && now.Sub(c.kept) < r.threshold {
+		return false
+	}
+	return true
+}
+
+// HandleDirectMessage answers a reply of "keep" or "release", optionally
+// followed by a pool, to a previous reminder.
+func (r *reminder) HandleDirectMessage(text, channel, username string) {
+	response, err := r.reply(text, username)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"text":     text,
+			"username": username,
+		}).Error("failed to handle reply to reminder")
+	}
+
+	if response != "" {
+		if err := r.slackClient.PostMessage(channel, response); err != nil {
+			r.logger.Errorf("failed to post to slack: %s", err)
+		}
+	}
+}
+
+func (r *reminder) reply(text, username string) (string, error) {
+	args := strings.Fields(text)
+	if len(args) == 0 {
+		return "", nil
+	}
+	action := strings.ToLower(args[0])
+	if action != "keep" && action != "release" {
+		return T("remind.unknown_reply", nil), nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var pool string
+	if len(args) > 1 {
+		pool = args[1]
+		if c, ok := r.claims[pool]; !ok || c.owner != username || c.reminders == 0 {
+			return T("remind.not_reminded", TArgs{"pool": pool}), nil
+		}
+	} else {
+		pools := r.remindedPools(username)
+		if len(pools) == 0 {
+			return T("remind.nothing_to_answer", nil), nil
+		} else if len(pools) > 1 {
+			return T("remind.which_pool", TArgs{"pools": strings.Join(pools, ", ")}), nil
+		}
+		pool = pools[0]
+	}
+	c := r.claims[pool]
+
+	if action == "keep" {
+		c.reminders = 0
+		c.kept = time.Now()
+		return T("remind.kept", TArgs{"pool": pool}), nil
+	}
+
+	locks, err := r.locker.Status()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get status of locks")
+	}
+	if !stillClaimed(pool, c, locks) {
+		delete(r.claims, pool)
+		return T("remind.not_reminded", TArgs{"pool": pool}), nil
+	}
+	if err := r.locker.ReleaseLock(pool, username); err != nil {
+		return "", errors.Wrap(err, "failed to release lock")
+	}
+	delete(r.claims, pool)
+	return T("remind.released", TArgs{"pool": pool}), nil
+}
+
+func (r *reminder) remindedPools(username string) []string {
+	var pools []string
+	for pool, c := range r.claims {
+		if c.owner == username && c.reminders > 0 {
+			pools = append(pools, pool)
+		}
+	}
+	sort.Strings(pools)
+	return pools
+}
+
+// stillClaimed reports whether the claim which the owner was reminded about
+// is still held, so that a late reply cannot release somebody else's claim
+func stillClaimed(pool string, c *claim, locks []clocker.Lock) bool {
+	for _, lock := range locks {
+		if lock.Name == pool {
+			return lock.Claimed && lock.Owner == c.owner && lock.Date == c.date
+		}
+	}
+	return false
+}
@@ -0,0 +1,270 @@
+package reminder_test
+
+import (
+	. "github.com/mdelillo/claimer/reminder"
+
+	"errors"
+	"time"
+
+	clocker "github.com/mdelillo/claimer/locker"
+	"github.com/mdelillo/claimer/reminder/reminderfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("Reminder", func() {
+	var (
+		locker      *reminderfakes.FakeLocker
+		slackClient *reminderfakes.FakeSlackClient
+		logger      *logrus.Logger
+		logHook     *logrustest.Hook
+		now         time.Time
+		oldDate     string
+		recentDate  string
+	)
+
+	BeforeEach(func() {
+		locker = new(reminderfakes.FakeLocker)
+		slackClient = new(reminderfakes.FakeSlackClient)
+		logger, logHook = logrustest.NewNullLogger()
+		now = time.Now()
+		oldDate = now.Add(-48 * time.Hour).Format(clocker.DateFormat)
+		recentDate = now.Add(-time.Hour).Format(clocker.DateFormat)
+	})
+
+	AfterEach(func() {
+		logHook.Reset()
+	})
+
+	Describe("New", func() {
+		Context("when the threshold is invalid", func() {
+			It("returns an error", func() {
+				_, err := New(Config{Threshold: "some-bad-duration"}, "", locker, slackClient, logger)
+				Expect(err).To(MatchError(ContainSubstring("failed to parse reminder threshold: ")))
+			})
+		})
+	})
+
+	Describe("Remind", func() {
+		It("asks owners of locks claimed for longer than the threshold whether they still need them", func() {
+			locker.StatusReturns([]clocker.Lock{
+				{Name: "pool-1", Claimed: true, Owner: "some-owner", Date: oldDate},
+				{Name: "pool-2", Claimed: true, Owner: "some-other-owner", Date: recentDate},
+				{Name: "pool-3", Claimed: false},
+				{Name: "pool-4", Claimed: true, Owner: "some-owner", Date: oldDate, Expires: now.Add(time.Hour)},
+			}, nil)
+
+			reminder, err := New(Config{Threshold: "24h"}, "", locker, slackClient, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reminder.Remind(now)).To(Succeed())
+
+			Expect(slackClient.PostDirectMessageCallCount()).To(Equal(1))
+			username, message := slackClient.PostDirectMessageArgsForCall(0)
+			Expect(username).To(Equal("some-owner"))
+			Expect(message).To(Equal(
+				"Are you still using pool-1? You claimed it on " + oldDate + ". " +
+					"Reply `keep` to hold on to it or `release` to release it.",
+			))
+			Expect(slackClient.PostMessageCallCount()).To(Equal(0))
+		})
+
+		It("posts in the channel once the owner has ignored enough reminders", func() {
+			locker.StatusReturns([]clocker.Lock{
+				{Name: "pool-1", Claimed: true, Owner: "some-owner", Date: oldDate},
+			}, nil)
+
+			reminder, err := New(Config{EscalateAfter: 2}, "some-channel", locker, slackClient, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reminder.Remind(now)).To(Succeed())
+			Expect(reminder.Remind(now)).To(Succeed())
+			Expect(slackClient.PostDirectMessageCallCount()).To(Equal(2))
+			Expect(slackClient.PostMessageCallCount()).To(Equal(0))
+
+			Expect(reminder.Remind(now)).To(Succeed())
+			Expect(slackClient.PostDirectMessageCallCount()).To(Equal(2))
+			Expect(slackClient.PostMessageCallCount()).To(Equal(1))
+			channel, message := slackClient.PostMessageArgsForCall(0)
+			Expect(channel).To(Equal("some-channel"))
+			Expect(message).To(Equal(
+				"<@some-owner> has held pool-1 since " + oldDate +
+					" and has not answered 2 reminders, please release it if it is not in use",
+			))
+		})
+
+		It("starts counting again when the pool is claimed again", func() {
+			locker.StatusReturnsOnCall(0, []clocker.Lock{
+				{Name: "pool-1", Claimed: true, Owner: "some-owner", Date: oldDate},
+			}, nil)
+			otherDate := now.Add(-30 * time.Hour).Format(clocker.DateFormat)
+			locker.StatusReturnsOnCall(1, []clocker.Lock{
+				{Name: "pool-1", Claimed: true, Owner: "some-other-owner", Date: otherDate},
+			}, nil)
+
+			reminder, err := New(Config{EscalateAfter: 1}, "some-channel", locker, slackClient, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reminder.Remind(now)).To(Succeed())
+			Expect(reminder.Remind(now)).To(Succeed())
+
+			Expect(slackClient.PostDirectMessageCallCount()).To(Equal(2))
+			username, _ := slackClient.PostDirectMessageArgsForCall(1)
+			Expect(username).To(Equal("some-other-owner"))
+			Expect(slackClient.PostMessageCallCount()).To(Equal(0))
+		})
+
+		Context("when sending a direct message fails", func() {
+			It("logs an error and does not count the reminder", func() {
+				locker.StatusReturns([]clocker.Lock{
+					{Name: "pool-1", Claimed: true, Owner: "some-owner", Date: oldDate},
+				}, nil)
+				slackClient.PostDirectMessageReturnsOnCall(0, errors.New("some-error"))
+
+				reminder, err := New(Config{EscalateAfter: 1}, "some-channel", locker, slackClient, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reminder.Remind(now)).To(Succeed())
+				Expect(logHook.LastEntry().Message).To(Equal("failed to send reminder"))
+				Expect(logHook.LastEntry().Data["error"]).To(Equal("some-error"))
+
+				Expect(reminder.Remind(now)).To(Succeed())
+				Expect(slackClient.PostDirectMessageCallCount()).To(Equal(2))
+				Expect(slackClient.PostMessageCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when getting the status fails", func() {
+			It("returns an error", func() {
+				locker.StatusReturns(nil, errors.New("some-error"))
+
+				reminder, err := New(Config{}, "", locker, slackClient, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reminder.Remind(now)).To(MatchError("failed to get status of locks: some-error"))
+			})
+		})
+	})
+
+	Describe("HandleDirectMessage", func() {
+		var reminder interface {
+			Remind(time.Time) error
+			HandleDirectMessage(text, channel, username string)
+		}
+
+		BeforeEach(func() {
+			locker.StatusReturns([]clocker.Lock{
+				{Name: "pool-1", Claimed: true, Owner: "some-owner", Date: oldDate},
+				{Name: "pool-2", Claimed: true, Owner: "some-other-owner", Date: oldDate},
+			}, nil)
+
+			var err error
+			reminder, err = New(Config{}, "", locker, slackClient, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reminder.Remind(now)).To(Succeed())
+		})
+
+		It("releases the pool when the owner replies release", func() {
+			reminder.HandleDirectMessage("release", "some-dm-channel", "some-owner")
+
+			Expect(locker.ReleaseLockCallCount()).To(Equal(1))
+			pool, username := locker.ReleaseLockArgsForCall(0)
+			Expect(pool).To(Equal("pool-1"))
+			Expect(username).To(Equal("some-owner"))
+
+			channel, message := slackClient.PostMessageArgsForCall(0)
+			Expect(channel).To(Equal("some-dm-channel"))
+			Expect(message).To(Equal("Released pool-1"))
+		})
+
+		It("stops reminding the owner until the threshold passes again when they reply keep", func() {
+			reminder.HandleDirectMessage("Keep", "some-dm-channel", "some-owner")
+
+			_, message := slackClient.PostMessageArgsForCall(0)
+			Expect(message).To(Equal("Okay, I'll leave pool-1 claimed"))
+			Expect(locker.ReleaseLockCallCount()).To(Equal(0))
+
+			Expect(reminder.Remind(now)).To(Succeed())
+			Expect(slackClient.PostDirectMessageCallCount()).To(Equal(3))
+			username, _ := slackClient.PostDirectMessageArgsForCall(2)
+			Expect(username).To(Equal("some-other-owner"))
+
+			Expect(reminder.Remind(now.Add(25 * time.Hour))).To(Succeed())
+			Expect(slackClient.PostDirectMessageCallCount()).To(Equal(5))
+		})
+
+		It("accepts the pool as an argument", func() {
+			reminder.HandleDirectMessage("release pool-1", "some-dm-channel", "some-owner")
+
+			pool, _ := locker.ReleaseLockArgsForCall(0)
+			Expect(pool).To(Equal("pool-1"))
+		})
+
+		Context("when the user was not asked about the pool", func() {
+			It("does not release it", func() {
+				reminder.HandleDirectMessage("release pool-2", "some-dm-channel", "some-owner")
+
+				Expect(locker.ReleaseLockCallCount()).To(Equal(0))
+				_, message := slackClient.PostMessageArgsForCall(0)
+				Expect(message).To(Equal("You have not been asked about pool-2"))
+			})
+		})
+
+		Context("when the user was not asked about any pools", func() {
+			It("says so", func() {
+				reminder.HandleDirectMessage("keep", "some-dm-channel", "some-third-owner")
+
+				_, message := slackClient.PostMessageArgsForCall(0)
+				Expect(message).To(Equal("You have not been asked about any environments"))
+			})
+		})
+
+		Context("when the user was asked about several pools", func() {
+			It("asks which pool they mean", func() {
+				locker.StatusReturns([]clocker.Lock{
+					{Name: "pool-1", Claimed: true, Owner: "some-owner", Date: oldDate},
+					{Name: "pool-2", Claimed: true, Owner: "some-owner", Date: oldDate},
+				}, nil)
+				Expect(reminder.Remind(now)).To(Succeed())
+
+				reminder.HandleDirectMessage("release", "some-dm-channel", "some-owner")
+
+				Expect(locker.ReleaseLockCallCount()).To(Equal(0))
+				_, message := slackClient.PostMessageArgsForCall(0)
+				Expect(message).To(Equal("You have been asked about pool-1, pool-2, please reply with `keep <env>` or `release <env>`"))
+			})
+		})
+
+		Context("when the claim has changed since the reminder", func() {
+			It("does not release the pool", func() {
+				locker.StatusReturns([]clocker.Lock{
+					{Name: "pool-1", Claimed: true, Owner: "some-third-owner", Date: recentDate},
+				}, nil)
+
+				reminder.HandleDirectMessage("release", "some-dm-channel", "some-owner")
+
+				Expect(locker.ReleaseLockCallCount()).To(Equal(0))
+				_, message := slackClient.PostMessageArgsForCall(0)
+				Expect(message).To(Equal("You have not been asked about pool-1"))
+			})
+		})
+
+		Context("when the reply is not understood", func() {
+			It("explains how to reply", func() {
+				reminder.HandleDirectMessage("some-text", "some-dm-channel", "some-owner")
+
+				_, message := slackClient.PostMessageArgsForCall(0)
+				Expect(message).To(Equal("Reply `keep [<env>]` to hold on to an environment or `release [<env>]` to release it"))
+			})
+		})
+
+		Context("when releasing the lock fails", func() {
+			It("logs an error", func() {
+				locker.ReleaseLockReturns(errors.New("some-error"))
+
+				reminder.HandleDirectMessage("release", "some-dm-channel", "some-owner")
+
+				Expect(slackClient.PostMessageCallCount()).To(Equal(0))
+				Expect(logHook.LastEntry().Message).To(Equal("failed to handle reply to reminder"))
+				Expect(logHook.LastEntry().Data["error"]).To(Equal("failed to release lock: some-error"))
+			})
+		})
+	})
+})
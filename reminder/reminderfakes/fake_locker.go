@@ -0,0 +1,150 @@
+// This file was generated by counterfeiter
+package reminderfakes
+
+import (
+	"sync"
+
+	clocker "github.com/mdelillo/claimer/locker"
+)
+
+type FakeLocker struct {
+	ReleaseLockStub        func(pool, username string) error
+	releaseLockMutex       sync.RWMutex
+	releaseLockArgsForCall []struct {
+		pool     string
+		username string
+	}
+	releaseLockReturns struct {
+		result1 error
+	}
+	releaseLockReturnsOnCall map[int]struct {
+		result1 error
+	}
+	StatusStub        func() ([]clocker.Lock, error)
+	statusMutex       sync.RWMutex
+	statusArgsForCall []struct{}
+	statusReturns     struct {
+		result1 []clocker.Lock
+		result2 error
+	}
+	statusReturnsOnCall map[int]struct {
+		result1 []clocker.Lock
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeLocker) ReleaseLock(pool string, username string) error {
+	fake.releaseLockMutex.Lock()
+	ret, specificReturn := fake.releaseLockReturnsOnCall[len(fake.releaseLockArgsForCall)]
+	fake.releaseLockArgsForCall = append(fake.releaseLockArgsForCall, struct {
+		pool     string
+		username string
+	}{pool, username})
+	fake.recordInvocation("ReleaseLock", []interface{}{pool, username})
+	fake.releaseLockMutex.Unlock()
+	if fake.ReleaseLockStub != nil {
+		return fake.ReleaseLockStub(pool, username)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.releaseLockReturns.result1
+}
+
+func (fake *FakeLocker) ReleaseLockCallCount() int {
+	fake.releaseLockMutex.RLock()
+	defer fake.releaseLockMutex.RUnlock()
+	return len(fake.releaseLockArgsForCall)
+}
+
+func (fake *FakeLocker) ReleaseLockArgsForCall(i int) (string, string) {
+	fake.releaseLockMutex.RLock()
+	defer fake.releaseLockMutex.RUnlock()
+	return fake.releaseLockArgsForCall[i].pool, fake.releaseLockArgsForCall[i].username
+}
+
+func (fake *FakeLocker) ReleaseLockReturns(result1 error) {
+	fake.ReleaseLockStub = nil
+	fake.releaseLockReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLocker) ReleaseLockReturnsOnCall(i int, result1 error) {
+	fake.ReleaseLockStub = nil
+	if fake.releaseLockReturnsOnCall == nil {
+		fake.releaseLockReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.releaseLockReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLocker) Status() ([]clocker.Lock, error) {
+	fake.statusMutex.Lock()
+	ret, specificReturn := fake.statusReturnsOnCall[len(fake.statusArgsForCall)]
+	fake.statusArgsForCall = append(fake.statusArgsForCall, struct{}{})
+	fake.recordInvocation("Status", []interface{}{})
+	fake.statusMutex.Unlock()
+	if fake.StatusStub != nil {
+		return fake.StatusStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.statusReturns.result1, fake.statusReturns.result2
+}
+
+func (fake *FakeLocker) StatusCallCount() int {
+	fake.statusMutex.RLock()
+	defer fake.statusMutex.RUnlock()
+	return len(fake.statusArgsForCall)
+}
+
+func (fake *FakeLocker) StatusReturns(result1 []clocker.Lock, result2 error) {
+	fake.StatusStub = nil
+	fake.statusReturns = struct {
+		result1 []clocker.Lock
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocker) StatusReturnsOnCall(i int, result1 []clocker.Lock, result2 error) {
+	fake.StatusStub = nil
+	if fake.statusReturnsOnCall == nil {
+		fake.statusReturnsOnCall = make(map[int]struct {
+			result1 []clocker.Lock
+			result2 error
+		})
+	}
+	fake.statusReturnsOnCall[i] = struct {
+		result1 []clocker.Lock
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocker) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.releaseLockMutex.RLock()
+	defer fake.releaseLockMutex.RUnlock()
+	fake.statusMutex.RLock()
+	defer fake.statusMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeLocker) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
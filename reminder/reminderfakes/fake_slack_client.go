@@ -0,0 +1,155 @@
+// This file was generated by counterfeiter
+package reminderfakes
+
+import (
+	"sync"
+)
+
+type FakeSlackClient struct {
+	PostDirectMessageStub        func(username, message string) error
+	postDirectMessageMutex       sync.RWMutex
+	postDirectMessageArgsForCall []struct {
+		username string
+		message  string
+	}
+	postDirectMessageReturns struct {
+		result1 error
+	}
+	postDirectMessageReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PostMessageStub        func(channel, message string) error
+	postMessageMutex       sync.RWMutex
+	postMessageArgsForCall []struct {
+		channel string
+		message string
+	}
+	postMessageReturns struct {
+		result1 error
+	}
+	postMessageReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSlackClient) PostDirectMessage(username string, message string) error {
+	fake.postDirectMessageMutex.Lock()
+	ret, specificReturn := fake.postDirectMessageReturnsOnCall[len(fake.postDirectMessageArgsForCall)]
+	fake.postDirectMessageArgsForCall = append(fake.postDirectMessageArgsForCall, struct {
+		username string
+		message  string
+	}{username, message})
+	fake.recordInvocation("PostDirectMessage", []interface{}{username, message})
+	fake.postDirectMessageMutex.Unlock()
+	if fake.PostDirectMessageStub != nil {
+		return fake.PostDirectMessageStub(username, message)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.postDirectMessageReturns.result1
+}
+
+func (fake *FakeSlackClient) PostDirectMessageCallCount() int {
+	fake.postDirectMessageMutex.RLock()
+	defer fake.postDirectMessageMutex.RUnlock()
+	return len(fake.postDirectMessageArgsForCall)
+}
+
+func (fake *FakeSlackClient) PostDirectMessageArgsForCall(i int) (string, string) {
+	fake.postDirectMessageMutex.RLock()
+	defer fake.postDirectMessageMutex.RUnlock()
+	return fake.postDirectMessageArgsForCall[i].username, fake.postDirectMessageArgsForCall[i].message
+}
+
+func (fake *FakeSlackClient) PostDirectMessageReturns(result1 error) {
+	fake.PostDirectMessageStub = nil
+	fake.postDirectMessageReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSlackClient) PostDirectMessageReturnsOnCall(i int, result1 error) {
+	fake.PostDirectMessageStub = nil
+	if fake.postDirectMessageReturnsOnCall == nil {
+		fake.postDirectMessageReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.postDirectMessageReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSlackClient) PostMessage(channel string, message string) error {
+	fake.postMessageMutex.Lock()
+	ret, specificReturn := fake.postMessageReturnsOnCall[len(fake.postMessageArgsForCall)]
+	fake.postMessageArgsForCall = append(fake.postMessageArgsForCall, struct {
+		channel string
+		message string
+	}{channel, message})
+	fake.recordInvocation("PostMessage", []interface{}{channel, message})
+	fake.postMessageMutex.Unlock()
+	if fake.PostMessageStub != nil {
+		return fake.PostMessageStub(channel, message)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.postMessageReturns.result1
+}
+
+func (fake *FakeSlackClient) PostMessageCallCount() int {
+	fake.postMessageMutex.RLock()
+	defer fake.postMessageMutex.RUnlock()
+	return len(fake.postMessageArgsForCall)
+}
+
+func (fake *FakeSlackClient) PostMessageArgsForCall(i int) (string, string) {
+	fake.postMessageMutex.RLock()
+	defer fake.postMessageMutex.RUnlock()
+	return fake.postMessageArgsForCall[i].channel, fake.postMessageArgsForCall[i].message
+}
+
+func (fake *FakeSlackClient) PostMessageReturns(result1 error) {
+	fake.PostMessageStub = nil
+	fake.postMessageReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSlackClient) PostMessageReturnsOnCall(i int, result1 error) {
+	fake.PostMessageStub = nil
+	if fake.postMessageReturnsOnCall == nil {
+		fake.postMessageReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.postMessageReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSlackClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.postDirectMessageMutex.RLock()
+	defer fake.postDirectMessageMutex.RUnlock()
+	fake.postMessageMutex.RLock()
+	defer fake.postMessageMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeSlackClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
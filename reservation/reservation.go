@@ -0,0 +1,142 @@
+package reservation
+
+import (
+	"sync"
+	"time"
+
+	clocker "github.com/mdelillo/claimer/locker"
+	. "github.com/mdelillo/claimer/translate"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate counterfeiter . locker
+type locker interface {
+	ClaimLock(pool, user, message string) error
+	ReleaseLock(pool, username string) error
+	Status() ([]clocker.Lock, error)
+	Unreserve(pool string, reservation clocker.Reservation) error
+}
+
+//go:generate counterfeiter . slackClient
+type slackClient interface {
+	PostMessage(channel, message string) error
+}
+
+type enforcer struct {
+	channel string
+
+	locker      locker
+	slackClient slackClient
+
+	logger *logrus.Logger
+
+	// mutex guards blocked, which holds the reservations whose pool was
+	// claimed by someone else when they started, so that the claimer is only
+	// asked once to release it
+	mutex   sync.Mutex
+	blocked map[string]bool
+}
+
+func New(channel string, locker locker, slackClient slackClient, logger *logrus.Logger) *enforcer {
+	return &enforcer{
+		channel:     channel,
+		locker:      locker,
+		slackClient: slackClient,
+		logger:      logger,
+		blocked:     map[string]bool{},
+	}
+}
+
+// Run enforces reservations at the start of every minute
+func (e *enforcer) Run() {
+	for {
+		time.Sleep(time.Until(time.Now().Truncate(time.Minute).Add(time.Minute)))
+		if err := e.Enforce(time.Now()); err != nil {
+			e.logger.Errorf("failed to enforce reservations: %s", err)
+		}
+	}
+}
+
+// Enforce claims pools for reservations which have started and releases them
+// when the reservations end. If a pool is claimed by someone else when a
+// reservation starts, they are asked in the channel to release it. Disabled
+// pools are left alone.
+func (e *enforcer) Enforce(now time.Time) error {
+	locks, err := e.locker.Status()
+	if err != nil {
+		return errors.Wrap(err, "failed to get status of locks")
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	blocked := map[string]bool{}
+	for _, lock := range locks {
+		if lock.Maintenance != nil {
+			continue
+		}
+		for _, reservation := range lock.Reservations {
+			key := lock.Name + "/" + reservation.Owner + "@" + reservation.Start.Format(time.RFC3339)
+			switch {
+			case !now.Before(reservation.End):
+				e.end(lock, reservation)
+			case !reservation.Active(now):
+			case !lock.Claimed:
+				e.start(lock, reservation)
+			case lock.Owner != reservation.Owner:
+				if !e.blocked[key] {
+					e.post(T("reserve.blocked", TArgs{
+						"claimer": lock.Owner,
+						"pool":    lock.Name,
+						"owner":   reservation.Owner,
+						"end":     reservation.End.Format(clocker.DateFormat),
+					}))
+				}
+				blocked[key] = true
+			}
+		}
+	}
+	e.blocked = blocked
+
+	return nil
+}
+
+func (e *enforcer) start(lock clocker.Lock, reservation clocker.Reservation) {
+	if err := e.locker.ClaimLock(lock.Name, reservation.Owner, reservation.Message); err != nil {
+		e.logError(err, "failed to claim reserved pool", lock, reservation)
+		return
+	}
+	e.post(T("reserve.started", TArgs{
+		"pool":  lock.Name,
+		"owner": reservation.Owner,
+		"end":   reservation.End.Format(clocker.DateFormat),
+	}))
+}
+
+func (e *enforcer) end(lock clocker.Lock, reservation clocker.Reservation) {
+	if lock.Claimed && lock.Owner == reservation.Owner {
+		if err := e.locker.ReleaseLock(lock.Name, reservation.Owner); err != nil {
+			e.logError(err, "failed to release reserved pool", lock, reservation)
+			return
+		}
+		e.post(T("reserve.ended", TArgs{"pool": lock.Name, "owner": reservation.Owner}))
+	}
+	if err := e.locker.Unreserve(lock.Name, reservation); err != nil {
+		e.logError(err, "failed to remove ended reservation", lock, reservation)
+	}
+}
+
+func (e *enforcer) post(message string) {
+	if err := e.slackClient.PostMessage(e.channel, message); err != nil {
+		e.logger.Errorf("failed to post to slack: %s", err)
+	}
+}
+
+func (e *enforcer) logError(err error, message string, lock clocker.Lock, reservation clocker.Reservation) {
+	e.logger.WithFields(logrus.Fields{
+		"error": err.Error(),
+		"owner": reservation.Owner,
+		"pool":  lock.Name,
+	}).Error(message)
+}
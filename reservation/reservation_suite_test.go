@@ -0,0 +1,19 @@
+package reservation_test
+
+import (
+	"github.com/mdelillo/claimer/translate"
+	"github.com/mdelillo/claimer/translations"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestReservation(t *testing.T) {
+	BeforeSuite(func() {
+		Expect(translate.LoadTranslations(translations.DefaultTranslations)).To(Succeed())
+	})
+
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Reservation Suite")
+}
@@ -0,0 +1,194 @@
+package reservation_test
+
+import (
+	. "github.com/mdelillo/claimer/reservation"
+
+	"errors"
+	"time"
+
+	clocker "github.com/mdelillo/claimer/locker"
+	"github.com/mdelillo/claimer/reservation/reservationfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("Reservation", func() {
+	var (
+		locker      *reservationfakes.FakeLocker
+		slackClient *reservationfakes.FakeSlackClient
+		logger      *logrus.Logger
+		logHook     *logrustest.Hook
+		now         time.Time
+		reservation clocker.Reservation
+	)
+
+	BeforeEach(func() {
+		locker = new(reservationfakes.FakeLocker)
+		slackClient = new(reservationfakes.FakeSlackClient)
+		logger, logHook = logrustest.NewNullLogger()
+		now = time.Now()
+		reservation = clocker.Reservation{
+			Owner:   "some-owner",
+			Start:   now.Add(-time.Minute),
+			End:     now.Add(time.Hour),
+			Message: "some message",
+		}
+	})
+
+	AfterEach(func() {
+		logHook.Reset()
+	})
+
+	Describe("Enforce", func() {
+		It("claims unclaimed pools for reservations which have started", func() {
+			locker.StatusReturns([]clocker.Lock{
+				{Name: "pool-1", Reservations: []clocker.Reservation{reservation}},
+				{Name: "pool-2", Reservations: []clocker.Reservation{{Owner: "some-owner", Start: now.Add(time.Hour), End: now.Add(2 * time.Hour)}}},
+				{Name: "pool-3", Claimed: true, Owner: "some-owner", Reservations: []clocker.Reservation{reservation}},
+			}, nil)
+
+			Expect(New("some-channel", locker, slackClient, logger).Enforce(now)).To(Succeed())
+
+			Expect(locker.ClaimLockCallCount()).To(Equal(1))
+			pool, user, message := locker.ClaimLockArgsForCall(0)
+			Expect(pool).To(Equal("pool-1"))
+			Expect(user).To(Equal("some-owner"))
+			Expect(message).To(Equal("some message"))
+
+			Expect(slackClient.PostMessageCallCount()).To(Equal(1))
+			channel, message := slackClient.PostMessageArgsForCall(0)
+			Expect(channel).To(Equal("some-channel"))
+			Expect(message).To(Equal("Claimed pool-1 for <@some-owner>, who reserved it until " + reservation.End.Format(clocker.DateFormat)))
+		})
+
+		It("releases pools and removes reservations which have ended", func() {
+			reservation.End = now
+			locker.StatusReturns([]clocker.Lock{
+				{Name: "pool-1", Claimed: true, Owner: "some-owner", Reservations: []clocker.Reservation{reservation}},
+				{Name: "pool-2", Claimed: true, Owner: "some-other-owner", Reservations: []clocker.Reservation{reservation}},
+			}, nil)
+
+			Expect(New("some-channel", locker, slackClient, logger).Enforce(now)).To(Succeed())
+
+			Expect(locker.ReleaseLockCallCount()).To(Equal(1))
+			pool, user := locker.ReleaseLockArgsForCall(0)
+			Expect(pool).To(Equal("pool-1"))
+			Expect(user).To(Equal("some-owner"))
+
+			Expect(slackClient.PostMessageCallCount()).To(Equal(1))
+			_, message := slackClient.PostMessageArgsForCall(0)
+			Expect(message).To(Equal("Released pool-1, the reservation of <@some-owner> has ended"))
+
+			Expect(locker.UnreserveCallCount()).To(Equal(2))
+			pool, actualReservation := locker.UnreserveArgsForCall(0)
+			Expect(pool).To(Equal("pool-1"))
+			Expect(actualReservation).To(Equal(reservation))
+			pool, _ = locker.UnreserveArgsForCall(1)
+			Expect(pool).To(Equal("pool-2"))
+		})
+
+		It("leaves disabled pools alone", func() {
+			locker.StatusReturns([]clocker.Lock{
+				{Name: "pool-1", Maintenance: &clocker.Maintenance{By: "some-user"}, Reservations: []clocker.Reservation{reservation}},
+			}, nil)
+
+			Expect(New("some-channel", locker, slackClient, logger).Enforce(now)).To(Succeed())
+
+			Expect(locker.ClaimLockCallCount()).To(Equal(0))
+			Expect(slackClient.PostMessageCallCount()).To(Equal(0))
+		})
+
+		Context("when the pool is claimed by someone else", func() {
+			It("asks them to release it once", func() {
+				locker.StatusReturns([]clocker.Lock{
+					{Name: "pool-1", Claimed: true, Owner: "some-other-owner", Reservations: []clocker.Reservation{reservation}},
+				}, nil)
+
+				enforcer := New("some-channel", locker, slackClient, logger)
+				Expect(enforcer.Enforce(now)).To(Succeed())
+				Expect(enforcer.Enforce(now.Add(time.Minute))).To(Succeed())
+
+				Expect(locker.ClaimLockCallCount()).To(Equal(0))
+				Expect(slackClient.PostMessageCallCount()).To(Equal(1))
+				_, message := slackClient.PostMessageArgsForCall(0)
+				Expect(message).To(Equal("<@some-other-owner> please release pool-1, it is reserved by <@some-owner> until " + reservation.End.Format(clocker.DateFormat)))
+			})
+
+			It("claims the pool once it is released", func() {
+				locker.StatusReturnsOnCall(0, []clocker.Lock{
+					{Name: "pool-1", Claimed: true, Owner: "some-other-owner", Reservations: []clocker.Reservation{reservation}},
+				}, nil)
+				locker.StatusReturnsOnCall(1, []clocker.Lock{
+					{Name: "pool-1", Reservations: []clocker.Reservation{reservation}},
+				}, nil)
+
+				enforcer := New("some-channel", locker, slackClient, logger)
+				Expect(enforcer.Enforce(now)).To(Succeed())
+				Expect(enforcer.Enforce(now.Add(time.Minute))).To(Succeed())
+
+				Expect(locker.ClaimLockCallCount()).To(Equal(1))
+				Expect(slackClient.PostMessageCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when claiming a pool fails", func() {
+			It("logs the error and carries on", func() {
+				locker.StatusReturns([]clocker.Lock{
+					{Name: "pool-1", Reservations: []clocker.Reservation{reservation}},
+					{Name: "pool-2", Reservations: []clocker.Reservation{reservation}},
+				}, nil)
+				locker.ClaimLockReturnsOnCall(0, errors.New("some-error"))
+
+				Expect(New("some-channel", locker, slackClient, logger).Enforce(now)).To(Succeed())
+
+				Expect(locker.ClaimLockCallCount()).To(Equal(2))
+				Expect(slackClient.PostMessageCallCount()).To(Equal(1))
+				Expect(logHook.LastEntry().Message).To(Equal("failed to claim reserved pool"))
+				Expect(logHook.LastEntry().Data).To(Equal(logrus.Fields{
+					"error": "some-error",
+					"owner": "some-owner",
+					"pool":  "pool-1",
+				}))
+			})
+		})
+
+		Context("when releasing a pool fails", func() {
+			It("logs the error and keeps the reservation", func() {
+				reservation.End = now
+				locker.StatusReturns([]clocker.Lock{
+					{Name: "pool-1", Claimed: true, Owner: "some-owner", Reservations: []clocker.Reservation{reservation}},
+				}, nil)
+				locker.ReleaseLockReturns(errors.New("some-error"))
+
+				Expect(New("some-channel", locker, slackClient, logger).Enforce(now)).To(Succeed())
+
+				Expect(locker.UnreserveCallCount()).To(Equal(0))
+				Expect(slackClient.PostMessageCallCount()).To(Equal(0))
+				Expect(logHook.LastEntry().Message).To(Equal("failed to release reserved pool"))
+			})
+		})
+
+		Context("when posting to slack fails", func() {
+			It("logs the error", func() {
+				locker.StatusReturns([]clocker.Lock{
+					{Name: "pool-1", Reservations: []clocker.Reservation{reservation}},
+				}, nil)
+				slackClient.PostMessageReturns(errors.New("some-error"))
+
+				Expect(New("some-channel", locker, slackClient, logger).Enforce(now)).To(Succeed())
+				Expect(logHook.LastEntry().Message).To(Equal("failed to post to slack: some-error"))
+			})
+		})
+
+		Context("when checking the status fails", func() {
+			It("returns an error", func() {
+				locker.StatusReturns(nil, errors.New("some-error"))
+
+				err := New("some-channel", locker, slackClient, logger).Enforce(now)
+				Expect(err).To(MatchError("failed to get status of locks: some-error"))
+			})
+		})
+	})
+})
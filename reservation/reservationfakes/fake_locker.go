@@ -0,0 +1,278 @@
+// This file was generated by counterfeiter
+package reservationfakes
+
+import (
+	"sync"
+
+	clocker "github.com/mdelillo/claimer/locker"
+)
+
+type FakeLocker struct {
+	ClaimLockStub        func(pool, user, message string) error
+	claimLockMutex       sync.RWMutex
+	claimLockArgsForCall []struct {
+		pool    string
+		user    string
+		message string
+	}
+	claimLockReturns struct {
+		result1 error
+	}
+	claimLockReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ReleaseLockStub        func(pool, username string) error
+	releaseLockMutex       sync.RWMutex
+	releaseLockArgsForCall []struct {
+		pool     string
+		username string
+	}
+	releaseLockReturns struct {
+		result1 error
+	}
+	releaseLockReturnsOnCall map[int]struct {
+		result1 error
+	}
+	StatusStub        func() ([]clocker.Lock, error)
+	statusMutex       sync.RWMutex
+	statusArgsForCall []struct{}
+	statusReturns     struct {
+		result1 []clocker.Lock
+		result2 error
+	}
+	statusReturnsOnCall map[int]struct {
+		result1 []clocker.Lock
+		result2 error
+	}
+	UnreserveStub        func(pool string, reservation clocker.Reservation) error
+	unreserveMutex       sync.RWMutex
+	unreserveArgsForCall []struct {
+		pool        string
+		reservation clocker.Reservation
+	}
+	unreserveReturns struct {
+		result1 error
+	}
+	unreserveReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeLocker) ClaimLock(pool string, user string, message string) error {
+	fake.claimLockMutex.Lock()
+	ret, specificReturn := fake.claimLockReturnsOnCall[len(fake.claimLockArgsForCall)]
+	fake.claimLockArgsForCall = append(fake.claimLockArgsForCall, struct {
+		pool    string
+		user    string
+		message string
+	}{pool, user, message})
+	fake.recordInvocation("ClaimLock", []interface{}{pool, user, message})
+	fake.claimLockMutex.Unlock()
+	if fake.ClaimLockStub != nil {
+		return fake.ClaimLockStub(pool, user, message)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.claimLockReturns.result1
+}
+
+func (fake *FakeLocker) ClaimLockCallCount() int {
+	fake.claimLockMutex.RLock()
+	defer fake.claimLockMutex.RUnlock()
+	return len(fake.claimLockArgsForCall)
+}
+
+func (fake *FakeLocker) ClaimLockArgsForCall(i int) (string, string, string) {
+	fake.claimLockMutex.RLock()
+	defer fake.claimLockMutex.RUnlock()
+	return fake.claimLockArgsForCall[i].pool, fake.claimLockArgsForCall[i].user, fake.claimLockArgsForCall[i].message
+}
+
+func (fake *FakeLocker) ClaimLockReturns(result1 error) {
+	fake.ClaimLockStub = nil
+	fake.claimLockReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLocker) ClaimLockReturnsOnCall(i int, result1 error) {
+	fake.ClaimLockStub = nil
+	if fake.claimLockReturnsOnCall == nil {
+		fake.claimLockReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.claimLockReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLocker) ReleaseLock(pool string, username string) error {
+	fake.releaseLockMutex.Lock()
+	ret, specificReturn := fake.releaseLockReturnsOnCall[len(fake.releaseLockArgsForCall)]
+	fake.releaseLockArgsForCall = append(fake.releaseLockArgsForCall, struct {
+		pool     string
+		username string
+	}{pool, username})
+	fake.recordInvocation("ReleaseLock", []interface{}{pool, username})
+	fake.releaseLockMutex.Unlock()
+	if fake.ReleaseLockStub != nil {
+		return fake.ReleaseLockStub(pool, username)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.releaseLockReturns.result1
+}
+
+func (fake *FakeLocker) ReleaseLockCallCount() int {
+	fake.releaseLockMutex.RLock()
+	defer fake.releaseLockMutex.RUnlock()
+	return len(fake.releaseLockArgsForCall)
+}
+
+func (fake *FakeLocker) ReleaseLockArgsForCall(i int) (string, string) {
+	fake.releaseLockMutex.RLock()
+	defer fake.releaseLockMutex.RUnlock()
+	return fake.releaseLockArgsForCall[i].pool, fake.releaseLockArgsForCall[i].username
+}
+
+func (fake *FakeLocker) ReleaseLockReturns(result1 error) {
+	fake.ReleaseLockStub = nil
+	fake.releaseLockReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLocker) ReleaseLockReturnsOnCall(i int, result1 error) {
+	fake.ReleaseLockStub = nil
+	if fake.releaseLockReturnsOnCall == nil {
+		fake.releaseLockReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.releaseLockReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLocker) Status() ([]clocker.Lock, error) {
+	fake.statusMutex.Lock()
+	ret, specificReturn := fake.statusReturnsOnCall[len(fake.statusArgsForCall)]
+	fake.statusArgsForCall = append(fake.statusArgsForCall, struct{}{})
+	fake.recordInvocation("Status", []interface{}{})
+	fake.statusMutex.Unlock()
+	if fake.StatusStub != nil {
+		return fake.StatusStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.statusReturns.result1, fake.statusReturns.result2
+}
+
+func (fake *FakeLocker) StatusCallCount() int {
+	fake.statusMutex.RLock()
+	defer fake.statusMutex.RUnlock()
+	return len(fake.statusArgsForCall)
+}
+
+func (fake *FakeLocker) StatusReturns(result1 []clocker.Lock, result2 error) {
+	fake.StatusStub = nil
+	fake.statusReturns = struct {
+		result1 []clocker.Lock
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocker) StatusReturnsOnCall(i int, result1 []clocker.Lock, result2 error) {
+	fake.StatusStub = nil
+	if fake.statusReturnsOnCall == nil {
+		fake.statusReturnsOnCall = make(map[int]struct {
+			result1 []clocker.Lock
+			result2 error
+		})
+	}
+	fake.statusReturnsOnCall[i] = struct {
+		result1 []clocker.Lock
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocker) Unreserve(pool string, reservation clocker.Reservation) error {
+	fake.unreserveMutex.Lock()
+	ret, specificReturn := fake.unreserveReturnsOnCall[len(fake.unreserveArgsForCall)]
+	fake.unreserveArgsForCall = append(fake.unreserveArgsForCall, struct {
+		pool        string
+		reservation clocker.Reservation
+	}{pool, reservation})
+	fake.recordInvocation("Unreserve", []interface{}{pool, reservation})
+	fake.unreserveMutex.Unlock()
+	if fake.UnreserveStub != nil {
+		return fake.UnreserveStub(pool, reservation)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.unreserveReturns.result1
+}
+
+func (fake *FakeLocker) UnreserveCallCount() int {
+	fake.unreserveMutex.RLock()
+	defer fake.unreserveMutex.RUnlock()
+	return len(fake.unreserveArgsForCall)
+}
+
+func (fake *FakeLocker) UnreserveArgsForCall(i int) (string, clocker.Reservation) {
+	fake.unreserveMutex.RLock()
+	defer fake.unreserveMutex.RUnlock()
+	return fake.unreserveArgsForCall[i].pool, fake.unreserveArgsForCall[i].reservation
+}
+
+func (fake *FakeLocker) UnreserveReturns(result1 error) {
+	fake.UnreserveStub = nil
+	fake.unreserveReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLocker) UnreserveReturnsOnCall(i int, result1 error) {
+	fake.UnreserveStub = nil
+	if fake.unreserveReturnsOnCall == nil {
+		fake.unreserveReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unreserveReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLocker) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.claimLockMutex.RLock()
+	defer fake.claimLockMutex.RUnlock()
+	fake.releaseLockMutex.RLock()
+	defer fake.releaseLockMutex.RUnlock()
+	fake.statusMutex.RLock()
+	defer fake.statusMutex.RUnlock()
+	fake.unreserveMutex.RLock()
+	defer fake.unreserveMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeLocker) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,40 @@
+package scheduler
+
+import (
+	"io/ioutil"
+
+	creminder "github.com/mdelillo/claimer/reminder"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Config describes commands which should be run automatically. Cron
+// expressions are evaluated in Timezone, which defaults to UTC. Reminders
+// configures the special "remind" command.
+type Config struct {
+	Timezone     string           `yaml:"timezone"`
+	SkipWeekends bool             `yaml:"skip_weekends"`
+	Holidays     []string         `yaml:"holidays"`
+	Schedules    []Schedule       `yaml:"schedules"`
+	Reminders    creminder.Config `yaml:"reminders"`
+}
+
+type Schedule struct {
+	Cron    string `yaml:"cron"`
+	Command string `yaml:"command"`
+	Args    string `yaml:"args"`
+	Channel string `yaml:"channel"`
+}
+
+func LoadConfig(path string) (Config, error) {
+	var config Config
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return config, errors.Wrap(err, "failed to read schedule file")
+	}
+	if err := yaml.Unmarshal(contents, &config); err != nil {
+		return config, errors.Wrap(err, "failed to parse schedule file")
+	}
+	return config, nil
+}
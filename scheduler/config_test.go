@@ -0,0 +1,81 @@
+package scheduler_test
+
+import (
+	. "github.com/mdelillo/claimer/scheduler"
+
+	"io/ioutil"
+	"os"
+
+	"github.com/mdelillo/claimer/reminder"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Config", func() {
+	Describe("LoadConfig", func() {
+		var configFile string
+
+		BeforeEach(func() {
+			file, err := ioutil.TempFile("", "claimer-schedule")
+			Expect(err).NotTo(HaveOccurred())
+			configFile = file.Name()
+			file.Close()
+		})
+
+		AfterEach(func() {
+			os.Remove(configFile)
+		})
+
+		It("loads the schedules from the file", func() {
+			contents := `---
+timezone: America/New_York
+skip_weekends: true
+holidays: ["2017-12-25"]
+schedules:
+- cron: "0 17 * * 1-5"
+  command: notify
+  channel: some-channel
+- cron: "0 9 * * *"
+  command: status
+reminders:
+  threshold: 72h
+  escalate_after: 2
+  channel: some-other-channel
+`
+			Expect(ioutil.WriteFile(configFile, []byte(contents), 0644)).To(Succeed())
+
+			config, err := LoadConfig(configFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config).To(Equal(Config{
+				Timezone:     "America/New_York",
+				SkipWeekends: true,
+				Holidays:     []string{"2017-12-25"},
+				Schedules: []Schedule{
+					{Cron: "0 17 * * 1-5", Command: "notify", Channel: "some-channel"},
+					{Cron: "0 9 * * *", Command: "status"},
+				},
+				Reminders: reminder.Config{
+					Threshold:     "72h",
+					EscalateAfter: 2,
+					Channel:       "some-other-channel",
+				},
+			}))
+		})
+
+		Context("when the file does not exist", func() {
+			It("returns an error", func() {
+				_, err := LoadConfig("some-missing-file")
+				Expect(err).To(MatchError(ContainSubstring("failed to read schedule file: ")))
+			})
+		})
+
+		Context("when the file is not valid YAML", func() {
+			It("returns an error", func() {
+				Expect(ioutil.WriteFile(configFile, []byte("some-invalid-yaml"), 0644)).To(Succeed())
+
+				_, err := LoadConfig(configFile)
+				Expect(err).To(MatchError(ContainSubstring("failed to parse schedule file: ")))
+			})
+		})
+	})
+})
@@ -0,0 +1,107 @@
+package scheduler
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type cronField struct {
+	min, max int
+}
+
+var cronFields = []cronField{
+	{0, 59}, // minute
+	{0, 23}, // hour
+	{1, 31}, // day of month
+	{1, 12}, // month
+	{0, 7},  // day of week, where both 0 and 7 are Sunday
+}
+
+// cronExpression is a standard five field cron expression supporting
+// wildcards, lists, ranges and steps, e.g. "0 17 * * 1-5"
+type cronExpression struct {
+	fields     [5]map[int]bool
+	restricted [5]bool
+}
+
+func parseCron(expression string) (cronExpression, error) {
+	var cron cronExpression
+
+	fields := strings.Fields(expression)
+	if len(fields) != len(cronFields) {
+		return cron, errors.Errorf("expected %d fields in cron expression '%s'", len(cronFields), expression)
+	}
+
+	for i, field := range fields {
+		values, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return cron, errors.Wrapf(err, "invalid cron expression '%s'", expression)
+		}
+		cron.fields[i] = values
+		cron.restricted[i] = !strings.HasPrefix(field, "*")
+	}
+	if cron.fields[4][7] {
+		cron.fields[4][0] = true
+	}
+
+	return cron, nil
+}
+
+func parseCronField(field string, bounds cronField) (map[int]bool, error) {
+	values := map[int]bool{}
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if split := strings.SplitN(part, "/", 2); len(split) == 2 {
+			var err error
+			step, err = strconv.Atoi(split[1])
+			if err != nil || step < 1 {
+				return nil, errors.Errorf("invalid step '%s'", split[1])
+			}
+			part = split[0]
+		}
+
+		start, end := bounds.min, bounds.max
+		if part != "*" {
+			split := strings.SplitN(part, "-", 2)
+			var err error
+			start, err = strconv.Atoi(split[0])
+			if err != nil {
+				return nil, errors.Errorf("invalid value '%s'", split[0])
+			}
+			end = start
+			if len(split) == 2 {
+				end, err = strconv.Atoi(split[1])
+				if err != nil {
+					return nil, errors.Errorf("invalid value '%s'", split[1])
+				}
+			}
+		}
+		if start < bounds.min || end > bounds.max || start > end {
+			return nil, errors.Errorf("value '%s' out of range %d-%d", part, bounds.min, bounds.max)
+		}
+
+		for value := start; value <= end; value += step {
+			values[value] = true
+		}
+	}
+	return values, nil
+}
+
+// matches reports whether the expression fires during the minute containing
+// t. As in cron, when both day of month and day of week are restricted a
+// match on either is sufficient.
+func (c cronExpression) matches(t time.Time) bool {
+	if !c.fields[0][t.Minute()] || !c.fields[1][t.Hour()] || !c.fields[3][int(t.Month())] {
+		return false
+	}
+
+	dayOfMonth := c.fields[2][t.Day()]
+	dayOfWeek := c.fields[4][int(t.Weekday())]
+	if c.restricted[2] && c.restricted[4] {
+		return dayOfMonth || dayOfWeek
+	}
+	return dayOfMonth && dayOfWeek
+}
@@ -0,0 +1,156 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/mdelillo/claimer/bot/commands"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const holidayFormat = "2006-01-02"
+
+// remindCommand sends direct messages to owners of long-held locks rather
+// than running a chat command
+const remindCommand = "remind"
+
+//go:generate counterfeiter . commandFactory
+type commandFactory interface {
+	NewCommand(command string, args string, username string) commands.Command
+}
+
+//go:generate counterfeiter . reminder
+type reminder interface {
+	Remind(now time.Time) error
+}
+
+//go:generate counterfeiter . slackClient
+type slackClient interface {
+	PostMessage(channel, message string) error
+}
+
+type job struct {
+	schedule Schedule
+	cron     cronExpression
+}
+
+type scheduler struct {
+	jobs         []job
+	location     *time.Location
+	skipWeekends bool
+	holidays     map[string]bool
+
+	commandFactory commandFactory
+	reminder       reminder
+	slackClient    slackClient
+
+	logger *logrus.Logger
+}
+
+func New(config Config, defaultChannel string, commandFactory commandFactory, reminder reminder, slackClient slackClient, logger *logrus.Logger) (*scheduler, error) {
+	location, err := time.LoadLocation(config.Timezone)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load timezone")
+	}
+
+	holidays := map[string]bool{}
+	for _, holiday := range config.Holidays {
+		date, err := time.Parse(holidayFormat, holiday)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse holiday")
+		}
+		holidays[date.Format(holidayFormat)] = true
+	}
+
+	var jobs []job
+	for _, schedule := range config.Schedules {
+		cron, err := parseCron(schedule.Cron)
+		if err != nil {
+			return nil, err
+		}
+		if schedule.Command == "" {
+			return nil, errors.Errorf("no command given for schedule '%s'", schedule.Cron)
+		}
+		if schedule.Channel == "" {
+			schedule.Channel = defaultChannel
+		}
+		jobs = append(jobs, job{schedule: schedule, cron: cron})
+	}
+
+	return &scheduler{
+		jobs:           jobs,
+		location:       location,
+		skipWeekends:   config.SkipWeekends,
+		holidays:       holidays,
+		commandFactory: commandFactory,
+		reminder:       reminder,
+		slackClient:    slackClient,
+		logger:         logger,
+	}, nil
+}
+
+// Run checks the schedules at the start of every minute. It never returns.
+func (s *scheduler) Run() {
+	last := time.Now()
+	for {
+		time.Sleep(time.Until(last.Truncate(time.Minute).Add(time.Minute)))
+		now := time.Now()
+		s.Tick(last, now)
+		last = now
+	}
+}
+
+// Tick runs every job scheduled for a minute after from and up to and
+// including to.
+func (s *scheduler) Tick(from, to time.Time) {
+	for minute := from.Truncate(time.Minute).Add(time.Minute); !minute.After(to); minute = minute.Add(time.Minute) {
+		local := minute.In(s.location)
+		if s.skipped(local) {
+			continue
+		}
+		for _, job := range s.jobs {
+			if job.cron.matches(local) {
+				s.run(job.schedule, minute)
+			}
+		}
+	}
+}
+
+func (s *scheduler) skipped(t time.Time) bool {
+	if s.skipWeekends && (t.Weekday() == time.Saturday || t.Weekday() == time.Sunday) {
+		return true
+	}
+	return s.holidays[t.Format(holidayFormat)]
+}
+
+func (s *scheduler) run(schedule Schedule, now time.Time) {
+	s.logger.WithFields(logrus.Fields{
+		"command": schedule.Command,
+		"args":    schedule.Args,
+		"channel": schedule.Channel,
+	}).Debug("Running scheduled command")
+
+	if schedule.Command == remindCommand {
+		if err := s.reminder.Remind(now); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error": err.Error(),
+			}).Error("failed to send reminders")
+		}
+		return
+	}
+
+	slackResponse, err := s.commandFactory.NewCommand(schedule.Command, schedule.Args, "").Execute()
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"command": schedule.Command,
+			"channel": schedule.Channel,
+		}).Error("failed to execute scheduled command")
+	}
+
+	if slackResponse != "" {
+		if err := s.slackClient.PostMessage(schedule.Channel, slackResponse); err != nil {
+			s.logger.Errorf("failed to post to slack: %s", err)
+		}
+	}
+}
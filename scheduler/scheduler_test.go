@@ -0,0 +1,253 @@
+package scheduler_test
+
+import (
+	. "github.com/mdelillo/claimer/scheduler"
+
+	"errors"
+	"time"
+
+	"github.com/mdelillo/claimer/bot/commands/commandsfakes"
+	"github.com/mdelillo/claimer/scheduler/schedulerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("Scheduler", func() {
+	var (
+		command        *commandsfakes.FakeCommand
+		commandFactory *schedulerfakes.FakeCommandFactory
+		reminder       *schedulerfakes.FakeReminder
+		slackClient    *schedulerfakes.FakeSlackClient
+		logger         *logrus.Logger
+		logHook        *logrustest.Hook
+	)
+
+	BeforeEach(func() {
+		command = new(commandsfakes.FakeCommand)
+		commandFactory = new(schedulerfakes.FakeCommandFactory)
+		commandFactory.NewCommandReturns(command)
+		reminder = new(schedulerfakes.FakeReminder)
+		slackClient = new(schedulerfakes.FakeSlackClient)
+		logger, logHook = logrustest.NewNullLogger()
+	})
+
+	AfterEach(func() {
+		logHook.Reset()
+	})
+
+	Describe("Tick", func() {
+		It("runs commands which are due and posts the responses in slack", func() {
+			config := Config{
+				Schedules: []Schedule{
+					{Cron: "0 17 * * 1-5", Command: "notify", Channel: "some-channel"},
+					{Cron: "30 9 * * *", Command: "status", Args: "some-args"},
+				},
+			}
+			command.ExecuteReturns("some-response", nil)
+
+			scheduler, err := New(config, "some-default-channel", commandFactory, reminder, slackClient, logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Wednesday 1 March 2017
+			scheduler.Tick(
+				time.Date(2017, 3, 1, 9, 0, 0, 0, time.UTC),
+				time.Date(2017, 3, 1, 17, 0, 0, 0, time.UTC),
+			)
+
+			Expect(commandFactory.NewCommandCallCount()).To(Equal(2))
+			cmd, args, username := commandFactory.NewCommandArgsForCall(0)
+			Expect(cmd).To(Equal("status"))
+			Expect(args).To(Equal("some-args"))
+			Expect(username).To(BeEmpty())
+			cmd, _, _ = commandFactory.NewCommandArgsForCall(1)
+			Expect(cmd).To(Equal("notify"))
+
+			Expect(slackClient.PostMessageCallCount()).To(Equal(2))
+			channel, message := slackClient.PostMessageArgsForCall(0)
+			Expect(channel).To(Equal("some-default-channel"))
+			Expect(message).To(Equal("some-response"))
+			channel, _ = slackClient.PostMessageArgsForCall(1)
+			Expect(channel).To(Equal("some-channel"))
+		})
+
+		It("evaluates schedules in the configured timezone", func() {
+			config := Config{
+				Timezone:  "America/New_York",
+				Schedules: []Schedule{{Cron: "0 17 * * *", Command: "notify"}},
+			}
+
+			scheduler, err := New(config, "", commandFactory, reminder, slackClient, logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			scheduler.Tick(
+				time.Date(2017, 3, 1, 16, 59, 0, 0, time.UTC),
+				time.Date(2017, 3, 1, 17, 0, 0, 0, time.UTC),
+			)
+			Expect(commandFactory.NewCommandCallCount()).To(Equal(0))
+
+			scheduler.Tick(
+				time.Date(2017, 3, 1, 21, 59, 0, 0, time.UTC),
+				time.Date(2017, 3, 1, 22, 0, 0, 0, time.UTC),
+			)
+			Expect(commandFactory.NewCommandCallCount()).To(Equal(1))
+		})
+
+		It("supports lists, ranges and steps", func() {
+			config := Config{
+				Schedules: []Schedule{{Cron: "*/15 9-10,12 * * *", Command: "status"}},
+			}
+
+			scheduler, err := New(config, "", commandFactory, reminder, slackClient, logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			scheduler.Tick(
+				time.Date(2017, 3, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2017, 3, 2, 0, 0, 0, 0, time.UTC),
+			)
+			Expect(commandFactory.NewCommandCallCount()).To(Equal(12))
+		})
+
+		It("sends reminders instead of running a command for the remind schedule", func() {
+			config := Config{Schedules: []Schedule{{Cron: "0 10 * * *", Command: "remind"}}}
+
+			scheduler, err := New(config, "", commandFactory, reminder, slackClient, logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			scheduler.Tick(
+				time.Date(2017, 3, 1, 9, 0, 0, 0, time.UTC),
+				time.Date(2017, 3, 1, 11, 0, 0, 0, time.UTC),
+			)
+			Expect(commandFactory.NewCommandCallCount()).To(Equal(0))
+			Expect(reminder.RemindCallCount()).To(Equal(1))
+			Expect(reminder.RemindArgsForCall(0)).To(Equal(time.Date(2017, 3, 1, 10, 0, 0, 0, time.UTC)))
+		})
+
+		Context("when weekends are skipped", func() {
+			It("does not run commands on weekends", func() {
+				config := Config{
+					SkipWeekends: true,
+					Schedules:    []Schedule{{Cron: "0 17 * * *", Command: "notify"}},
+				}
+
+				scheduler, err := New(config, "", commandFactory, reminder, slackClient, logger)
+				Expect(err).NotTo(HaveOccurred())
+
+				// Friday 3 March 2017 to Monday 6 March 2017
+				scheduler.Tick(
+					time.Date(2017, 3, 3, 0, 0, 0, 0, time.UTC),
+					time.Date(2017, 3, 6, 23, 59, 0, 0, time.UTC),
+				)
+				Expect(commandFactory.NewCommandCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when holidays are configured", func() {
+			It("does not run commands on holidays", func() {
+				config := Config{
+					Holidays:  []string{"2017-03-02"},
+					Schedules: []Schedule{{Cron: "0 17 * * *", Command: "notify"}},
+				}
+
+				scheduler, err := New(config, "", commandFactory, reminder, slackClient, logger)
+				Expect(err).NotTo(HaveOccurred())
+
+				scheduler.Tick(
+					time.Date(2017, 3, 1, 0, 0, 0, 0, time.UTC),
+					time.Date(2017, 3, 3, 23, 59, 0, 0, time.UTC),
+				)
+				Expect(commandFactory.NewCommandCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when the command fails", func() {
+			It("logs the error", func() {
+				config := Config{Schedules: []Schedule{{Cron: "* * * * *", Command: "notify"}}}
+				command.ExecuteReturns("", errors.New("some-error"))
+
+				scheduler, err := New(config, "", commandFactory, reminder, slackClient, logger)
+				Expect(err).NotTo(HaveOccurred())
+
+				now := time.Now()
+				scheduler.Tick(now.Add(-time.Minute), now)
+
+				Expect(slackClient.PostMessageCallCount()).To(Equal(0))
+				Expect(logHook.LastEntry().Message).To(Equal("failed to execute scheduled command"))
+				Expect(logHook.LastEntry().Data["error"]).To(Equal("some-error"))
+			})
+		})
+
+		Context("when sending reminders fails", func() {
+			It("logs the error", func() {
+				config := Config{Schedules: []Schedule{{Cron: "* * * * *", Command: "remind"}}}
+				reminder.RemindReturns(errors.New("some-error"))
+
+				scheduler, err := New(config, "", commandFactory, reminder, slackClient, logger)
+				Expect(err).NotTo(HaveOccurred())
+
+				now := time.Now()
+				scheduler.Tick(now.Add(-time.Minute), now)
+
+				Expect(logHook.LastEntry().Message).To(Equal("failed to send reminders"))
+				Expect(logHook.LastEntry().Data["error"]).To(Equal("some-error"))
+			})
+		})
+
+		Context("when posting to slack fails", func() {
+			It("logs the error", func() {
+				config := Config{Schedules: []Schedule{{Cron: "* * * * *", Command: "notify"}}}
+				command.ExecuteReturns("some-response", nil)
+				slackClient.PostMessageReturns(errors.New("some-error"))
+
+				scheduler, err := New(config, "", commandFactory, reminder, slackClient, logger)
+				Expect(err).NotTo(HaveOccurred())
+
+				now := time.Now()
+				scheduler.Tick(now.Add(-time.Minute), now)
+
+				Expect(logHook.LastEntry().Message).To(Equal("failed to post to slack: some-error"))
+			})
+		})
+	})
+
+	Describe("New", func() {
+		Context("when the timezone is invalid", func() {
+			It("returns an error", func() {
+				_, err := New(Config{Timezone: "some-timezone"}, "", commandFactory, reminder, slackClient, logger)
+				Expect(err).To(MatchError(ContainSubstring("failed to load timezone: ")))
+			})
+		})
+
+		Context("when a holiday is invalid", func() {
+			It("returns an error", func() {
+				_, err := New(Config{Holidays: []string{"some-date"}}, "", commandFactory, reminder, slackClient, logger)
+				Expect(err).To(MatchError(ContainSubstring("failed to parse holiday: ")))
+			})
+		})
+
+		Context("when a cron expression has the wrong number of fields", func() {
+			It("returns an error", func() {
+				config := Config{Schedules: []Schedule{{Cron: "* * *", Command: "notify"}}}
+				_, err := New(config, "", commandFactory, reminder, slackClient, logger)
+				Expect(err).To(MatchError("expected 5 fields in cron expression '* * *'"))
+			})
+		})
+
+		Context("when a cron expression is out of range", func() {
+			It("returns an error", func() {
+				config := Config{Schedules: []Schedule{{Cron: "0 24 * * *", Command: "notify"}}}
+				_, err := New(config, "", commandFactory, reminder, slackClient, logger)
+				Expect(err).To(MatchError("invalid cron expression '0 24 * * *': value '24' out of range 0-23"))
+			})
+		})
+
+		Context("when a schedule has no command", func() {
+			It("returns an error", func() {
+				config := Config{Schedules: []Schedule{{Cron: "* * * * *"}}}
+				_, err := New(config, "", commandFactory, reminder, slackClient, logger)
+				Expect(err).To(MatchError("no command given for schedule '* * * * *'"))
+			})
+		})
+	})
+})
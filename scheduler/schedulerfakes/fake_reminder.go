@@ -0,0 +1,91 @@
+// This file was generated by counterfeiter
+package schedulerfakes
+
+import (
+	"sync"
+	"time"
+)
+
+type FakeReminder struct {
+	RemindStub        func(now time.Time) error
+	remindMutex       sync.RWMutex
+	remindArgsForCall []struct {
+		now time.Time
+	}
+	remindReturns struct {
+		result1 error
+	}
+	remindReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeReminder) Remind(now time.Time) error {
+	fake.remindMutex.Lock()
+	ret, specificReturn := fake.remindReturnsOnCall[len(fake.remindArgsForCall)]
+	fake.remindArgsForCall = append(fake.remindArgsForCall, struct {
+		now time.Time
+	}{now})
+	fake.recordInvocation("Remind", []interface{}{now})
+	fake.remindMutex.Unlock()
+	if fake.RemindStub != nil {
+		return fake.RemindStub(now)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.remindReturns.result1
+}
+
+func (fake *FakeReminder) RemindCallCount() int {
+	fake.remindMutex.RLock()
+	defer fake.remindMutex.RUnlock()
+	return len(fake.remindArgsForCall)
+}
+
+func (fake *FakeReminder) RemindArgsForCall(i int) time.Time {
+	fake.remindMutex.RLock()
+	defer fake.remindMutex.RUnlock()
+	return fake.remindArgsForCall[i].now
+}
+
+func (fake *FakeReminder) RemindReturns(result1 error) {
+	fake.RemindStub = nil
+	fake.remindReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReminder) RemindReturnsOnCall(i int, result1 error) {
+	fake.RemindStub = nil
+	if fake.remindReturnsOnCall == nil {
+		fake.remindReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.remindReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReminder) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.remindMutex.RLock()
+	defer fake.remindMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeReminder) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
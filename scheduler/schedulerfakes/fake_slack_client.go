@@ -0,0 +1,92 @@
+// This file was generated by counterfeiter
+package schedulerfakes
+
+import (
+	"sync"
+)
+
+type FakeSlackClient struct {
+	PostMessageStub        func(channel, message string) error
+	postMessageMutex       sync.RWMutex
+	postMessageArgsForCall []struct {
+		channel string
+		message string
+	}
+	postMessageReturns struct {
+		result1 error
+	}
+	postMessageReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSlackClient) PostMessage(channel string, message string) error {
+	fake.postMessageMutex.Lock()
+	ret, specificReturn := fake.postMessageReturnsOnCall[len(fake.postMessageArgsForCall)]
+	fake.postMessageArgsForCall = append(fake.postMessageArgsForCall, struct {
+		channel string
+		message string
+	}{channel, message})
+	fake.recordInvocation("PostMessage", []interface{}{channel, message})
+	fake.postMessageMutex.Unlock()
+	if fake.PostMessageStub != nil {
+		return fake.PostMessageStub(channel, message)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.postMessageReturns.result1
+}
+
+func (fake *FakeSlackClient) PostMessageCallCount() int {
+	fake.postMessageMutex.RLock()
+	defer fake.postMessageMutex.RUnlock()
+	return len(fake.postMessageArgsForCall)
+}
+
+func (fake *FakeSlackClient) PostMessageArgsForCall(i int) (string, string) {
+	fake.postMessageMutex.RLock()
+	defer fake.postMessageMutex.RUnlock()
+	return fake.postMessageArgsForCall[i].channel, fake.postMessageArgsForCall[i].message
+}
+
+func (fake *FakeSlackClient) PostMessageReturns(result1 error) {
+	fake.PostMessageStub = nil
+	fake.postMessageReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSlackClient) PostMessageReturnsOnCall(i int, result1 error) {
+	fake.PostMessageStub = nil
+	if fake.postMessageReturnsOnCall == nil {
+		fake.postMessageReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.postMessageReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSlackClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.postMessageMutex.RLock()
+	defer fake.postMessageMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeSlackClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
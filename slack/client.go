@@ -11,9 +11,10 @@ import (
 )
 
 type client struct {
-	requestFactory requests.Factory
-	channelId      string
-	logger         *logrus.Logger
+	requestFactory       requests.Factory
+	channelId            string
+	directMessageHandler func(text, channel, username string)
+	logger               *logrus.Logger
 }
 
 type rtmEvent struct {
@@ -34,6 +35,12 @@ func NewClient(requestFactory requests.Factory, channelId string, logger *logrus
 	}
 }
 
+// OnDirectMessage registers a function to handle messages sent directly to
+// the bot. Direct messages are ignored unless a handler is registered.
+func (c *client) OnDirectMessage(directMessageHandler func(text, channel, username string)) {
+	c.directMessageHandler = directMessageHandler
+}
+
 func (c *client) Listen(messageHandler func(text, channel, username string)) error {
 	websocketUrl, botId, err := c.requestFactory.NewStartRtmRequest().Execute()
 	if err != nil {
@@ -82,19 +89,33 @@ func (c *client) handleEvent(data []byte, botId string, messageHandler func(stri
 		}
 
 		if inChannel(message, c.channelId) && mentionsBot(message, botId) {
-			request := c.requestFactory.NewGetUsernameRequest(message.User)
-			username, err := request.Execute()
+			username, err := c.username(message.User)
 			if err != nil {
-				return errors.Wrap(err, "failed to get username")
+				return err
 			}
 			c.logger.Debug("Handling message")
 			messageHandler(message.Text, message.Channel, username)
+		} else if isDirectMessage(message, botId) && c.directMessageHandler != nil {
+			username, err := c.username(message.User)
+			if err != nil {
+				return err
+			}
+			c.logger.Debug("Handling direct message")
+			c.directMessageHandler(message.Text, message.Channel, username)
 		}
 	}
 
 	return nil
 }
 
+func (c *client) username(userId string) (string, error) {
+	username, err := c.requestFactory.NewGetUsernameRequest(userId).Execute()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get username")
+	}
+	return username, nil
+}
+
 func isMessage(e *rtmEvent) bool {
 	return e.Type == "message"
 }
@@ -103,13 +124,39 @@ func inChannel(message *message, channelId string) bool {
 	return message.Channel == channelId
 }
 
+// isDirectMessage reports whether the message was sent to the bot in a direct
+// message channel, whose IDs start with "D"
+func isDirectMessage(message *message, botId string) bool {
+	return strings.HasPrefix(message.Channel, "D") && message.User != botId
+}
+
 func mentionsBot(message *message, botId string) bool {
 	return strings.Contains(message.Text, "<@"+botId)
 }
 
+// UserEmail returns the email address in the Slack profile of a user
+func (c *client) UserEmail(username string) (string, error) {
+	email, err := c.requestFactory.NewGetUserEmailRequest(username).Execute()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get user email")
+	}
+	return email, nil
+}
+
 func (c *client) PostMessage(channel, message string) error {
 	if err := c.requestFactory.NewPostMessageRequest(channel, message).Execute(); err != nil {
 		return errors.Wrap(err, "failed to post message")
 	}
 	return nil
 }
+
+func (c *client) PostDirectMessage(username, message string) error {
+	userId, err := c.requestFactory.NewGetUserIdRequest(username).Execute()
+	if err != nil {
+		return errors.Wrap(err, "failed to get user id")
+	}
+	if err := c.requestFactory.NewPostMessageRequest(userId, message).Execute(); err != nil {
+		return errors.Wrap(err, "failed to post message")
+	}
+	return nil
+}
@@ -17,16 +17,20 @@ import (
 
 var _ = Describe("Client", func() {
 	var (
-		requestFactory     *requestsfakes.FakeFactory
-		getUsernameRequest *requestsfakes.FakeGetUsernameRequest
-		postMessageRequest *requestsfakes.FakePostMessageRequest
-		startRtmRequest    *requestsfakes.FakeStartRtmRequest
-		logger             *logrus.Logger
-		logHook            *logrustest.Hook
+		requestFactory      *requestsfakes.FakeFactory
+		getUserEmailRequest *requestsfakes.FakeGetUserEmailRequest
+		getUserIdRequest    *requestsfakes.FakeGetUserIdRequest
+		getUsernameRequest  *requestsfakes.FakeGetUsernameRequest
+		postMessageRequest  *requestsfakes.FakePostMessageRequest
+		startRtmRequest     *requestsfakes.FakeStartRtmRequest
+		logger              *logrus.Logger
+		logHook             *logrustest.Hook
 	)
 
 	BeforeEach(func() {
 		requestFactory = new(requestsfakes.FakeFactory)
+		getUserEmailRequest = new(requestsfakes.FakeGetUserEmailRequest)
+		getUserIdRequest = new(requestsfakes.FakeGetUserIdRequest)
 		getUsernameRequest = new(requestsfakes.FakeGetUsernameRequest)
 		postMessageRequest = new(requestsfakes.FakePostMessageRequest)
 		startRtmRequest = new(requestsfakes.FakeStartRtmRequest)
@@ -127,6 +131,82 @@ var _ = Describe("Client", func() {
 			Expect(logHook.LastEntry().Message).To(Equal("Listening for messages"))
 		})
 
+		It("handles direct messages to the bot using the registered function", func() {
+			botId := "some-bot-id"
+			channel := "some-channel"
+			directMessageChannel := "D-some-dm-channel"
+			userId := "some-user-id"
+			username := "some-username"
+
+			websocketServer := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+				ws.Write([]byte(fmt.Sprintf(
+					`{"type":"message", "text":"keep", "channel":"%s", "user":"%s"}`,
+					directMessageChannel,
+					userId,
+				)))
+				ws.Write([]byte(fmt.Sprintf(
+					`{"type":"message", "text":"some-text-from-the-bot", "channel":"%s", "user":"%s"}`,
+					directMessageChannel,
+					botId,
+				)))
+				ws.Write([]byte(fmt.Sprintf(
+					`{"type":"message", "text":"some-text-without-mention", "channel":"%s", "user":"%s"}`,
+					channel,
+					userId,
+				)))
+				ws.Write([]byte(fmt.Sprintf(
+					`{"type":"message", "text":"<@%s> some-text", "channel":"%s", "user":"%s"}`,
+					botId,
+					channel,
+					userId,
+				)))
+			}))
+			defer websocketServer.Close()
+			websocketUrl := "ws://" + websocketServer.Listener.Addr().String()
+
+			requestFactory.NewStartRtmRequestReturns(startRtmRequest)
+			startRtmRequest.ExecuteReturns(websocketUrl, botId, nil)
+
+			requestFactory.NewGetUsernameRequestReturns(getUsernameRequest)
+			getUsernameRequest.ExecuteReturns(username, nil)
+
+			messageCount := 0
+			messageHandler := func(actualText, actualChannel, actualUsername string) {
+				messageCount++
+			}
+			directMessageCount := 0
+			directMessageHandler := func(actualText, actualChannel, actualUsername string) {
+				directMessageCount++
+				Expect(actualText).To(Equal("keep"))
+				Expect(actualChannel).To(Equal(directMessageChannel))
+				Expect(actualUsername).To(Equal(username))
+			}
+
+			client := NewClient(requestFactory, channel, logger)
+			client.OnDirectMessage(directMessageHandler)
+			client.Listen(messageHandler)
+			Eventually(func() int { return messageCount }).Should(Equal(1))
+			Eventually(func() int { return directMessageCount }).Should(Equal(1))
+			Consistently(func() int { return directMessageCount }).ShouldNot(Equal(2))
+			Expect(requestFactory.NewGetUsernameRequestCallCount()).To(Equal(2))
+		})
+
+		Context("when no direct message handler is registered", func() {
+			It("ignores direct messages", func() {
+				websocketServer := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+					ws.Write([]byte(`{"type":"message", "text":"keep", "channel":"D-some-dm-channel", "user":"some-user-id"}`))
+				}))
+				defer websocketServer.Close()
+				websocketUrl := "ws://" + websocketServer.Listener.Addr().String()
+
+				requestFactory.NewStartRtmRequestReturns(startRtmRequest)
+				startRtmRequest.ExecuteReturns(websocketUrl, "some-bot-id", nil)
+
+				go NewClient(requestFactory, "some-channel", logger).Listen(nil)
+				Consistently(requestFactory.NewGetUsernameRequestCallCount).Should(Equal(0))
+			})
+		})
+
 		Context("when there is an error starting the RTM session", func() {
 			It("returns an error", func() {
 				requestFactory.NewStartRtmRequestReturns(startRtmRequest)
@@ -233,4 +313,68 @@ var _ = Describe("Client", func() {
 			})
 		})
 	})
+
+	Describe("UserEmail", func() {
+		It("returns the email of the user with the given name", func() {
+			requestFactory.NewGetUserEmailRequestReturns(getUserEmailRequest)
+			getUserEmailRequest.ExecuteReturns("some-email@example.com", nil)
+
+			client := NewClient(requestFactory, "", logger)
+			Expect(client.UserEmail("some-username")).To(Equal("some-email@example.com"))
+			Expect(requestFactory.NewGetUserEmailRequestArgsForCall(0)).To(Equal("some-username"))
+		})
+
+		Context("when getting the email fails", func() {
+			It("returns an error", func() {
+				requestFactory.NewGetUserEmailRequestReturns(getUserEmailRequest)
+				getUserEmailRequest.ExecuteReturns("", errors.New("some-error"))
+
+				client := NewClient(requestFactory, "", logger)
+				_, err := client.UserEmail("some-username")
+				Expect(err).To(MatchError("failed to get user email: some-error"))
+			})
+		})
+	})
+
+	Describe("PostDirectMessage", func() {
+		It("posts a message to the user with the given name", func() {
+			username := "some-username"
+			userId := "some-user-id"
+			message := "some-message"
+
+			requestFactory.NewGetUserIdRequestReturns(getUserIdRequest)
+			getUserIdRequest.ExecuteReturns(userId, nil)
+			requestFactory.NewPostMessageRequestReturns(postMessageRequest)
+			postMessageRequest.ExecuteReturns(nil)
+
+			client := NewClient(requestFactory, "", logger)
+			Expect(client.PostDirectMessage(username, message)).To(Succeed())
+
+			Expect(requestFactory.NewGetUserIdRequestArgsForCall(0)).To(Equal(username))
+			actualChannel, actualMessage := requestFactory.NewPostMessageRequestArgsForCall(0)
+			Expect(actualChannel).To(Equal(userId))
+			Expect(actualMessage).To(Equal(message))
+		})
+
+		Context("when getting the user id fails", func() {
+			It("returns an error", func() {
+				requestFactory.NewGetUserIdRequestReturns(getUserIdRequest)
+				getUserIdRequest.ExecuteReturns("", errors.New("some-error"))
+
+				client := NewClient(requestFactory, "", logger)
+				Expect(client.PostDirectMessage("", "")).To(MatchError("failed to get user id: some-error"))
+			})
+		})
+
+		Context("when posting the message fails", func() {
+			It("returns an error", func() {
+				requestFactory.NewGetUserIdRequestReturns(getUserIdRequest)
+				requestFactory.NewPostMessageRequestReturns(postMessageRequest)
+				postMessageRequest.ExecuteReturns(errors.New("some-error"))
+
+				client := NewClient(requestFactory, "", logger)
+				Expect(client.PostDirectMessage("", "")).To(MatchError("failed to post message: some-error"))
+			})
+		})
+	})
 })
@@ -2,11 +2,23 @@ package requests
 
 //go:generate counterfeiter . Factory
 type Factory interface {
+	NewGetUserEmailRequest(username string) GetUserEmailRequest
+	NewGetUserIdRequest(username string) GetUserIdRequest
 	NewGetUsernameRequest(userId string) GetUsernameRequest
 	NewPostMessageRequest(channel, message string) PostMessageRequest
 	NewStartRtmRequest() StartRtmRequest
 }
 
+//go:generate counterfeiter . GetUserEmailRequest
+type GetUserEmailRequest interface {
+	Execute() (email string, err error)
+}
+
+//go:generate counterfeiter . GetUserIdRequest
+type GetUserIdRequest interface {
+	Execute() (userId string, err error)
+}
+
 //go:generate counterfeiter . GetUsernameRequest
 type GetUsernameRequest interface {
 	Execute() (username string, err error)
@@ -34,6 +46,22 @@ func NewFactory(url, apiToken string) Factory {
 	}
 }
 
+func (r *requestFactory) NewGetUserEmailRequest(username string) GetUserEmailRequest {
+	return &getUserEmailRequest{
+		url:      r.url,
+		apiToken: r.apiToken,
+		username: username,
+	}
+}
+
+func (r *requestFactory) NewGetUserIdRequest(username string) GetUserIdRequest {
+	return &getUserIdRequest{
+		url:      r.url,
+		apiToken: r.apiToken,
+		username: username,
+	}
+}
+
 func (r *requestFactory) NewGetUsernameRequest(userId string) GetUsernameRequest {
 	return &getUsernameRequest{
 		url:      r.url,
@@ -0,0 +1,42 @@
+package requests
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+)
+
+type getUserEmailRequest struct {
+	url      string
+	apiToken string
+	username string
+}
+
+func (g *getUserEmailRequest) Execute() (string, error) {
+	body, err := get(fmt.Sprintf("%s/api/users.list?token=%s", g.url, g.apiToken))
+	if err != nil {
+		return "", err
+	}
+
+	var getUserEmailResponse struct {
+		Members []struct {
+			Name    string
+			Profile struct {
+				Email string
+			}
+		}
+	}
+	if err := json.Unmarshal(body, &getUserEmailResponse); err != nil {
+		return "", errors.Wrap(err, "failed to parse body")
+	}
+
+	for _, member := range getUserEmailResponse.Members {
+		if member.Name == g.username {
+			if member.Profile.Email == "" {
+				return "", errors.Errorf("no email for user %s", g.username)
+			}
+			return member.Profile.Email, nil
+		}
+	}
+	return "", errors.Errorf("no user named %s", g.username)
+}
@@ -0,0 +1,86 @@
+package requests_test
+
+import (
+	. "github.com/mdelillo/claimer/slack/requests"
+
+	"fmt"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("GetUserEmailRequest", func() {
+	Describe("Execute", func() {
+		It("returns the profile email for the given username", func() {
+			apiToken := "some-api-token"
+			email := "some-email@example.com"
+			username := "some-username"
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+
+				Expect(r.RequestURI).To(Equal(fmt.Sprintf("/api/users.list?token=%s", apiToken)))
+				Expect(r.Method).To(Equal("GET"))
+
+				w.Write([]byte(fmt.Sprintf(
+					`{"ok": true, "members": [{"name": "some-other-username", "profile": {"email": "some-other-email"}}, {"name": "%s", "profile": {"email": "%s"}}]}`,
+					username,
+					email,
+				)))
+			}))
+			defer server.Close()
+
+			request := NewFactory(server.URL, apiToken).NewGetUserEmailRequest(username)
+			actualEmail, err := request.Execute()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualEmail).To(Equal(email))
+		})
+
+		Context("when the user has no email", func() {
+			It("returns an error", func() {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					defer GinkgoRecover()
+					w.Write([]byte(`{"ok": true, "members": [{"name": "some-username", "profile": {}}]}`))
+				}))
+				defer server.Close()
+
+				_, err := NewFactory(server.URL, "").NewGetUserEmailRequest("some-username").Execute()
+				Expect(err).To(MatchError("no email for user some-username"))
+			})
+		})
+
+		Context("when there is no user with the given name", func() {
+			It("returns an error", func() {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					defer GinkgoRecover()
+					w.Write([]byte(`{"ok": true, "members": [{"name": "some-other-username"}]}`))
+				}))
+				defer server.Close()
+
+				_, err := NewFactory(server.URL, "").NewGetUserEmailRequest("some-username").Execute()
+				Expect(err).To(MatchError("no user named some-username"))
+			})
+		})
+
+		Context("when the request fails", func() {
+			It("returns an error", func() {
+				_, err := NewFactory("", "").NewGetUserEmailRequest("").Execute()
+				Expect(err).To(MatchError(ContainSubstring("unsupported protocol scheme")))
+			})
+		})
+
+		Context("when unmarshaling the body fails", func() {
+			It("returns an error", func() {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					defer GinkgoRecover()
+					w.Write([]byte(`some-bad-json`))
+				}))
+				defer server.Close()
+
+				_, err := NewFactory(server.URL, "").NewGetUserEmailRequest("").Execute()
+				Expect(err).To(MatchError(ContainSubstring("invalid character")))
+			})
+		})
+	})
+})
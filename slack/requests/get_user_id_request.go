@@ -0,0 +1,37 @@
+package requests
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+)
+
+type getUserIdRequest struct {
+	url      string
+	apiToken string
+	username string
+}
+
+func (g *getUserIdRequest) Execute() (string, error) {
+	body, err := get(fmt.Sprintf("%s/api/users.list?token=%s", g.url, g.apiToken))
+	if err != nil {
+		return "", err
+	}
+
+	var getUserIdResponse struct {
+		Members []struct {
+			Id   string
+			Name string
+		}
+	}
+	if err := json.Unmarshal(body, &getUserIdResponse); err != nil {
+		return "", errors.Wrap(err, "failed to parse body")
+	}
+
+	for _, member := range getUserIdResponse.Members {
+		if member.Name == g.username {
+			return member.Id, nil
+		}
+	}
+	return "", errors.Errorf("no user named %s", g.username)
+}
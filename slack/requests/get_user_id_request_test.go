@@ -0,0 +1,73 @@
+package requests_test
+
+import (
+	. "github.com/mdelillo/claimer/slack/requests"
+
+	"fmt"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("GetUserIdRequest", func() {
+	Describe("Execute", func() {
+		It("returns the user id for the given username", func() {
+			apiToken := "some-api-token"
+			userId := "some-user-id"
+			username := "some-username"
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+
+				Expect(r.RequestURI).To(Equal(fmt.Sprintf("/api/users.list?token=%s", apiToken)))
+				Expect(r.Method).To(Equal("GET"))
+
+				w.Write([]byte(fmt.Sprintf(
+					`{"ok": true, "members": [{"id": "some-other-id", "name": "some-other-username"}, {"id": "%s", "name": "%s"}]}`,
+					userId,
+					username,
+				)))
+			}))
+			defer server.Close()
+
+			request := NewFactory(server.URL, apiToken).NewGetUserIdRequest(username)
+			actualUserId, err := request.Execute()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualUserId).To(Equal(userId))
+		})
+
+		Context("when there is no user with the given name", func() {
+			It("returns an error", func() {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					defer GinkgoRecover()
+					w.Write([]byte(`{"ok": true, "members": [{"id": "some-other-id", "name": "some-other-username"}]}`))
+				}))
+				defer server.Close()
+
+				_, err := NewFactory(server.URL, "").NewGetUserIdRequest("some-username").Execute()
+				Expect(err).To(MatchError("no user named some-username"))
+			})
+		})
+
+		Context("when the request fails", func() {
+			It("returns an error", func() {
+				_, err := NewFactory("", "").NewGetUserIdRequest("").Execute()
+				Expect(err).To(MatchError(ContainSubstring("unsupported protocol scheme")))
+			})
+		})
+
+		Context("when unmarshaling the body fails", func() {
+			It("returns an error", func() {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					defer GinkgoRecover()
+					w.Write([]byte(`some-bad-json`))
+				}))
+				defer server.Close()
+
+				_, err := NewFactory(server.URL, "").NewGetUserIdRequest("").Execute()
+				Expect(err).To(MatchError(ContainSubstring("invalid character")))
+			})
+		})
+	})
+})
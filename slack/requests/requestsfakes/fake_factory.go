@@ -8,6 +8,28 @@ import (
 )
 
 type FakeFactory struct {
+	NewGetUserEmailRequestStub        func(username string) requests.GetUserEmailRequest
+	newGetUserEmailRequestMutex       sync.RWMutex
+	newGetUserEmailRequestArgsForCall []struct {
+		username string
+	}
+	newGetUserEmailRequestReturns struct {
+		result1 requests.GetUserEmailRequest
+	}
+	newGetUserEmailRequestReturnsOnCall map[int]struct {
+		result1 requests.GetUserEmailRequest
+	}
+	NewGetUserIdRequestStub        func(username string) requests.GetUserIdRequest
+	newGetUserIdRequestMutex       sync.RWMutex
+	newGetUserIdRequestArgsForCall []struct {
+		username string
+	}
+	newGetUserIdRequestReturns struct {
+		result1 requests.GetUserIdRequest
+	}
+	newGetUserIdRequestReturnsOnCall map[int]struct {
+		result1 requests.GetUserIdRequest
+	}
 	NewGetUsernameRequestStub        func(userId string) requests.GetUsernameRequest
 	newGetUsernameRequestMutex       sync.RWMutex
 	newGetUsernameRequestArgsForCall []struct {
@@ -44,6 +66,102 @@ type FakeFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeFactory) NewGetUserEmailRequest(username string) requests.GetUserEmailRequest {
+	fake.newGetUserEmailRequestMutex.Lock()
+	ret, specificReturn := fake.newGetUserEmailRequestReturnsOnCall[len(fake.newGetUserEmailRequestArgsForCall)]
+	fake.newGetUserEmailRequestArgsForCall = append(fake.newGetUserEmailRequestArgsForCall, struct {
+		username string
+	}{username})
+	fake.recordInvocation("NewGetUserEmailRequest", []interface{}{username})
+	fake.newGetUserEmailRequestMutex.Unlock()
+	if fake.NewGetUserEmailRequestStub != nil {
+		return fake.NewGetUserEmailRequestStub(username)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.newGetUserEmailRequestReturns.result1
+}
+
+func (fake *FakeFactory) NewGetUserEmailRequestCallCount() int {
+	fake.newGetUserEmailRequestMutex.RLock()
+	defer fake.newGetUserEmailRequestMutex.RUnlock()
+	return len(fake.newGetUserEmailRequestArgsForCall)
+}
+
+func (fake *FakeFactory) NewGetUserEmailRequestArgsForCall(i int) string {
+	fake.newGetUserEmailRequestMutex.RLock()
+	defer fake.newGetUserEmailRequestMutex.RUnlock()
+	return fake.newGetUserEmailRequestArgsForCall[i].username
+}
+
+func (fake *FakeFactory) NewGetUserEmailRequestReturns(result1 requests.GetUserEmailRequest) {
+	fake.NewGetUserEmailRequestStub = nil
+	fake.newGetUserEmailRequestReturns = struct {
+		result1 requests.GetUserEmailRequest
+	}{result1}
+}
+
+func (fake *FakeFactory) NewGetUserEmailRequestReturnsOnCall(i int, result1 requests.GetUserEmailRequest) {
+	fake.NewGetUserEmailRequestStub = nil
+	if fake.newGetUserEmailRequestReturnsOnCall == nil {
+		fake.newGetUserEmailRequestReturnsOnCall = make(map[int]struct {
+			result1 requests.GetUserEmailRequest
+		})
+	}
+	fake.newGetUserEmailRequestReturnsOnCall[i] = struct {
+		result1 requests.GetUserEmailRequest
+	}{result1}
+}
+
+func (fake *FakeFactory) NewGetUserIdRequest(username string) requests.GetUserIdRequest {
+	fake.newGetUserIdRequestMutex.Lock()
+	ret, specificReturn := fake.newGetUserIdRequestReturnsOnCall[len(fake.newGetUserIdRequestArgsForCall)]
+	fake.newGetUserIdRequestArgsForCall = append(fake.newGetUserIdRequestArgsForCall, struct {
+		username string
+	}{username})
+	fake.recordInvocation("NewGetUserIdRequest", []interface{}{username})
+	fake.newGetUserIdRequestMutex.Unlock()
+	if fake.NewGetUserIdRequestStub != nil {
+		return fake.NewGetUserIdRequestStub(username)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.newGetUserIdRequestReturns.result1
+}
+
+func (fake *FakeFactory) NewGetUserIdRequestCallCount() int {
+	fake.newGetUserIdRequestMutex.RLock()
+	defer fake.newGetUserIdRequestMutex.RUnlock()
+	return len(fake.newGetUserIdRequestArgsForCall)
+}
+
+func (fake *FakeFactory) NewGetUserIdRequestArgsForCall(i int) string {
+	fake.newGetUserIdRequestMutex.RLock()
+	defer fake.newGetUserIdRequestMutex.RUnlock()
+	return fake.newGetUserIdRequestArgsForCall[i].username
+}
+
+func (fake *FakeFactory) NewGetUserIdRequestReturns(result1 requests.GetUserIdRequest) {
+	fake.NewGetUserIdRequestStub = nil
+	fake.newGetUserIdRequestReturns = struct {
+		result1 requests.GetUserIdRequest
+	}{result1}
+}
+
+func (fake *FakeFactory) NewGetUserIdRequestReturnsOnCall(i int, result1 requests.GetUserIdRequest) {
+	fake.NewGetUserIdRequestStub = nil
+	if fake.newGetUserIdRequestReturnsOnCall == nil {
+		fake.newGetUserIdRequestReturnsOnCall = make(map[int]struct {
+			result1 requests.GetUserIdRequest
+		})
+	}
+	fake.newGetUserIdRequestReturnsOnCall[i] = struct {
+		result1 requests.GetUserIdRequest
+	}{result1}
+}
+
 func (fake *FakeFactory) NewGetUsernameRequest(userId string) requests.GetUsernameRequest {
 	fake.newGetUsernameRequestMutex.Lock()
 	ret, specificReturn := fake.newGetUsernameRequestReturnsOnCall[len(fake.newGetUsernameRequestArgsForCall)]
@@ -184,6 +302,10 @@ func (fake *FakeFactory) NewStartRtmRequestReturnsOnCall(i int, result1 requests
 func (fake *FakeFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.newGetUserEmailRequestMutex.RLock()
+	defer fake.newGetUserEmailRequestMutex.RUnlock()
+	fake.newGetUserIdRequestMutex.RLock()
+	defer fake.newGetUserIdRequestMutex.RUnlock()
 	fake.newGetUsernameRequestMutex.RLock()
 	defer fake.newGetUsernameRequestMutex.RUnlock()
 	fake.newPostMessageRequestMutex.RLock()
@@ -0,0 +1,89 @@
+// This file was generated by counterfeiter
+package requestsfakes
+
+import (
+	"sync"
+
+	"github.com/mdelillo/claimer/slack/requests"
+)
+
+type FakeGetUserEmailRequest struct {
+	ExecuteStub        func() (email string, err error)
+	executeMutex       sync.RWMutex
+	executeArgsForCall []struct{}
+	executeReturns     struct {
+		result1 string
+		result2 error
+	}
+	executeReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeGetUserEmailRequest) Execute() (email string, err error) {
+	fake.executeMutex.Lock()
+	ret, specificReturn := fake.executeReturnsOnCall[len(fake.executeArgsForCall)]
+	fake.executeArgsForCall = append(fake.executeArgsForCall, struct{}{})
+	fake.recordInvocation("Execute", []interface{}{})
+	fake.executeMutex.Unlock()
+	if fake.ExecuteStub != nil {
+		return fake.ExecuteStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.executeReturns.result1, fake.executeReturns.result2
+}
+
+func (fake *FakeGetUserEmailRequest) ExecuteCallCount() int {
+	fake.executeMutex.RLock()
+	defer fake.executeMutex.RUnlock()
+	return len(fake.executeArgsForCall)
+}
+
+func (fake *FakeGetUserEmailRequest) ExecuteReturns(result1 string, result2 error) {
+	fake.ExecuteStub = nil
+	fake.executeReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGetUserEmailRequest) ExecuteReturnsOnCall(i int, result1 string, result2 error) {
+	fake.ExecuteStub = nil
+	if fake.executeReturnsOnCall == nil {
+		fake.executeReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.executeReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGetUserEmailRequest) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.executeMutex.RLock()
+	defer fake.executeMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeGetUserEmailRequest) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ requests.GetUserEmailRequest = new(FakeGetUserEmailRequest)
@@ -0,0 +1,268 @@
+// Package slacktest provides an in-memory Slack server implementing the parts
+// of the web and RTM APIs used by claimer, so that it can be tested without a
+// real Slack workspace.
+package slacktest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/websocket"
+)
+
+type User struct {
+	Id    string
+	Name  string
+	Email string
+	Token string
+}
+
+type Message struct {
+	Channel string
+	User    string
+	Text    string
+}
+
+type Server struct {
+	URL string
+
+	server *httptest.Server
+
+	mutex       sync.Mutex
+	users       []User
+	messages    []Message
+	connections []*websocket.Conn
+	requests    []string
+}
+
+func NewServer() *Server {
+	s := &Server{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/rtm.start", s.authenticated(s.startRtm))
+	mux.HandleFunc("/api/users.info", s.authenticated(s.userInfo))
+	mux.HandleFunc("/api/users.list", s.authenticated(s.listUsers))
+	mux.HandleFunc("/api/chat.postMessage", s.authenticated(s.postMessage))
+	mux.HandleFunc("/api/conversations.history", s.authenticated(s.history))
+	mux.Handle("/rtm", websocket.Handler(s.rtm))
+
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.Lock()
+		s.requests = append(s.requests, r.URL.Path)
+		s.mutex.Unlock()
+		mux.ServeHTTP(w, r)
+	}))
+	s.URL = s.server.URL
+	return s
+}
+
+func (s *Server) Close() {
+	s.mutex.Lock()
+	for _, connection := range s.connections {
+		connection.Close()
+	}
+	s.connections = nil
+	s.mutex.Unlock()
+
+	s.server.Close()
+}
+
+// AddUser registers a user, who can then call the API with their token.
+func (s *Server) AddUser(user User) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.users = append(s.users, user)
+}
+
+// PostMessage posts a message as the given user and sends it to every RTM
+// connection. Messages to a user ID are posted in the direct message channel
+// for that user.
+func (s *Server) PostMessage(userId, channel, text string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.findUser(func(u User) bool { return u.Id == channel }); ok {
+		channel = DirectMessageChannel(channel)
+	}
+
+	message := Message{Channel: channel, User: userId, Text: text}
+	s.messages = append(s.messages, message)
+
+	event, _ := json.Marshal(map[string]string{
+		"type":    "message",
+		"channel": message.Channel,
+		"user":    message.User,
+		"text":    message.Text,
+		"ts":      strconv.Itoa(len(s.messages)),
+	})
+	for _, connection := range s.connections {
+		websocket.Message.Send(connection, string(event))
+	}
+}
+
+// Messages returns the messages posted in a channel, oldest first.
+func (s *Server) Messages(channel string) []Message {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var messages []Message
+	for _, message := range s.messages {
+		if message.Channel == channel {
+			messages = append(messages, message)
+		}
+	}
+	return messages
+}
+
+// LatestMessage returns the text of the most recent message in a channel.
+func (s *Server) LatestMessage(channel string) string {
+	messages := s.Messages(channel)
+	if len(messages) == 0 {
+		return ""
+	}
+	return messages[len(messages)-1].Text
+}
+
+// Connections returns the number of open RTM connections.
+func (s *Server) Connections() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return len(s.connections)
+}
+
+// Requests returns the paths of the requests made to the server, oldest
+// first.
+func (s *Server) Requests() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]string(nil), s.requests...)
+}
+
+// DirectMessageChannel returns the ID of the channel in which the bot and the
+// given user exchange direct messages.
+func DirectMessageChannel(userId string) string {
+	return "D" + userId
+}
+
+func (s *Server) authenticated(handler func(http.ResponseWriter, *http.Request, User)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.Lock()
+		user, ok := s.findUser(func(u User) bool { return u.Token == r.FormValue("token") })
+		s.mutex.Unlock()
+
+		if !ok {
+			respond(w, map[string]interface{}{"ok": false, "error": "invalid_auth"})
+			return
+		}
+		handler(w, r, user)
+	}
+}
+
+func (s *Server) startRtm(w http.ResponseWriter, r *http.Request, user User) {
+	respond(w, map[string]interface{}{
+		"ok":   true,
+		"url":  "ws" + strings.TrimPrefix(s.URL, "http") + "/rtm",
+		"self": map[string]string{"id": user.Id, "name": user.Name},
+	})
+}
+
+func (s *Server) userInfo(w http.ResponseWriter, r *http.Request, _ User) {
+	s.mutex.Lock()
+	user, ok := s.findUser(func(u User) bool { return u.Id == r.FormValue("user") })
+	s.mutex.Unlock()
+
+	if !ok {
+		respond(w, map[string]interface{}{"ok": false, "error": "user_not_found"})
+		return
+	}
+	respond(w, map[string]interface{}{
+		"ok":   true,
+		"user": map[string]string{"id": user.Id, "name": user.Name},
+	})
+}
+
+func (s *Server) listUsers(w http.ResponseWriter, r *http.Request, _ User) {
+	s.mutex.Lock()
+	var members []map[string]interface{}
+	for _, user := range s.users {
+		members = append(members, map[string]interface{}{
+			"id":      user.Id,
+			"name":    user.Name,
+			"profile": map[string]string{"email": user.Email},
+		})
+	}
+	s.mutex.Unlock()
+
+	respond(w, map[string]interface{}{"ok": true, "members": members})
+}
+
+func (s *Server) postMessage(w http.ResponseWriter, r *http.Request, user User) {
+	if r.FormValue("channel") == "" {
+		respond(w, map[string]interface{}{"ok": false, "error": "channel_not_found"})
+		return
+	}
+	s.PostMessage(user.Id, r.FormValue("channel"), r.FormValue("text"))
+	respond(w, map[string]interface{}{"ok": true})
+}
+
+func (s *Server) history(w http.ResponseWriter, r *http.Request, _ User) {
+	messages := s.Messages(r.FormValue("channel"))
+	limit := len(messages)
+	if l, err := strconv.Atoi(r.FormValue("limit")); err == nil && l < limit {
+		limit = l
+	}
+
+	var history []map[string]string
+	for i := len(messages) - 1; i >= len(messages)-limit; i-- {
+		history = append(history, map[string]string{
+			"type": "message",
+			"user": messages[i].User,
+			"text": messages[i].Text,
+		})
+	}
+	respond(w, map[string]interface{}{"ok": true, "messages": history})
+}
+
+func (s *Server) rtm(ws *websocket.Conn) {
+	s.mutex.Lock()
+	s.connections = append(s.connections, ws)
+	s.mutex.Unlock()
+
+	var data []byte
+	for websocket.Message.Receive(ws, &data) == nil {
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for i, connection := range s.connections {
+		if connection == ws {
+			s.connections = append(s.connections[:i], s.connections[i+1:]...)
+			break
+		}
+	}
+}
+
+// findUser must be called with the mutex held
+func (s *Server) findUser(matches func(User) bool) (User, bool) {
+	for _, user := range s.users {
+		if matches(user) {
+			return user, true
+		}
+	}
+	return User{}, false
+}
+
+func respond(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		http.Error(w, fmt.Sprintf("failed to encode response: %s", err), http.StatusInternalServerError)
+	}
+}
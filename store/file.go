@@ -0,0 +1,90 @@
+package store
+
+import (
+	"os"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+//go:generate counterfeiter . fs
+type fs interface {
+	Exists(path string) (bool, error)
+	Mv(src, dst string) error
+	ReadFile(file string) ([]byte, error)
+	WriteFile(file string, contents []byte) error
+}
+
+type file struct {
+	fs   fs
+	path string
+}
+
+type fileContents struct {
+	Pools map[string]*pool `yaml:"pools"`
+}
+
+// NewFile returns a locker which keeps every pool in a single YAML file. The
+// file is created when the first pool is. Several claimer processes can share
+// the file, as each change holds a lock on <path>.lock.
+func NewFile(fs fs, path string) *store {
+	return &store{backend: &file{fs: fs, path: path}}
+}
+
+// lock takes an exclusive flock on a file next to the store file, which
+// cannot be locked itself because save replaces it
+func (f *file) lock() (func(), error) {
+	lockFile, err := os.OpenFile(f.path+".lock", os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open lock file")
+	}
+	if err := syscall.Flock(int(lockFile.Fd()), syscall.LOCK_EX); err != nil {
+		lockFile.Close()
+		return nil, errors.Wrap(err, "failed to lock store file")
+	}
+	return func() {
+		syscall.Flock(int(lockFile.Fd()), syscall.LOCK_UN)
+		lockFile.Close()
+	}, nil
+}
+
+func (f *file) load() (map[string]*pool, error) {
+	exists, err := f.fs.Exists(f.path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check for store file")
+	}
+	if !exists {
+		return map[string]*pool{}, nil
+	}
+
+	contents, err := f.fs.ReadFile(f.path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read store file")
+	}
+	var parsed fileContents
+	if err := yaml.Unmarshal(contents, &parsed); err != nil {
+		return nil, errors.Wrap(err, "failed to parse store file")
+	}
+	if parsed.Pools == nil {
+		parsed.Pools = map[string]*pool{}
+	}
+	return parsed.Pools, nil
+}
+
+// save writes to a temporary file first so that a crash cannot leave the
+// store half written
+func (f *file) save(pools map[string]*pool) error {
+	contents, err := yaml.Marshal(fileContents{Pools: pools})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal store file")
+	}
+	tmpPath := f.path + ".tmp"
+	if err := f.fs.WriteFile(tmpPath, contents); err != nil {
+		return errors.Wrap(err, "failed to write store file")
+	}
+	if err := f.fs.Mv(tmpPath, f.path); err != nil {
+		return errors.Wrap(err, "failed to replace store file")
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package store_test
+
+import (
+	. "github.com/mdelillo/claimer/store"
+
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+
+	"github.com/mdelillo/claimer/fs"
+	"github.com/mdelillo/claimer/locker"
+	"github.com/mdelillo/claimer/locker/lockertest"
+	"github.com/mdelillo/claimer/store/storefakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("File", func() {
+	var (
+		dir       string
+		storeFile string
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "claimer-store")
+		Expect(err).NotTo(HaveOccurred())
+		storeFile = filepath.Join(dir, "pools.yml")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	lockertest.DescribeLocker(func() locker.Locker {
+		return NewFile(fs.NewFs(), storeFile)
+	})
+
+	It("keeps pools in the file between instances", func() {
+		Expect(NewFile(fs.NewFs(), storeFile).CreatePool("some-pool", "some-user", locker.Metadata{})).To(Succeed())
+		Expect(NewFile(fs.NewFs(), storeFile).ClaimLock("some-pool", "some-user", "some message")).To(Succeed())
+
+		locks, err := NewFile(fs.NewFs(), storeFile).Status()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(locks).To(HaveLen(1))
+		Expect(locks[0].Owner).To(Equal("some-user"))
+		Expect(locks[0].Message).To(Equal("some message"))
+
+		Expect(filepath.Join(dir, "pools.yml.tmp")).NotTo(BeAnExistingFile())
+	})
+
+	It("waits for other processes sharing the file", func() {
+		lockFile, err := os.OpenFile(storeFile+".lock", os.O_CREATE|os.O_RDWR, 0644)
+		Expect(err).NotTo(HaveOccurred())
+		defer lockFile.Close()
+		Expect(syscall.Flock(int(lockFile.Fd()), syscall.LOCK_EX)).To(Succeed())
+
+		done := make(chan error)
+		go func() {
+			done <- NewFile(fs.NewFs(), storeFile).CreatePool("some-pool", "some-user", locker.Metadata{})
+		}()
+		Consistently(done).ShouldNot(Receive())
+
+		Expect(syscall.Flock(int(lockFile.Fd()), syscall.LOCK_UN)).To(Succeed())
+		Eventually(done).Should(Receive(BeNil()))
+	})
+
+	It("does not lose changes made by several instances at once", func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer GinkgoRecover()
+				defer wg.Done()
+				Expect(NewFile(fs.NewFs(), storeFile).CreatePool(fmt.Sprintf("pool-%d", i), "some-user", locker.Metadata{})).To(Succeed())
+			}(i)
+		}
+		wg.Wait()
+
+		locks, err := NewFile(fs.NewFs(), storeFile).Status()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(locks).To(HaveLen(10))
+	})
+
+	Context("when the lock file cannot be opened", func() {
+		It("returns an error", func() {
+			_, err := NewFile(fs.NewFs(), filepath.Join(dir, "missing-dir", "pools.yml")).Status()
+			Expect(err).To(MatchError(ContainSubstring("failed to lock pools: failed to open lock file: ")))
+		})
+	})
+
+	Context("when the file cannot be parsed", func() {
+		It("returns an error", func() {
+			Expect(ioutil.WriteFile(storeFile, []byte("some-bad-yaml"), 0644)).To(Succeed())
+
+			_, err := NewFile(fs.NewFs(), storeFile).Status()
+			Expect(err).To(MatchError(ContainSubstring("failed to load pools: failed to parse store file: ")))
+		})
+	})
+
+	Context("when checking for the file fails", func() {
+		It("returns an error", func() {
+			fakeFs := new(storefakes.FakeFs)
+			fakeFs.ExistsReturns(false, errors.New("some-error"))
+
+			_, err := NewFile(fakeFs, storeFile).Status()
+			Expect(err).To(MatchError("failed to load pools: failed to check for store file: some-error"))
+		})
+	})
+
+	Context("when reading the file fails", func() {
+		It("returns an error", func() {
+			fakeFs := new(storefakes.FakeFs)
+			fakeFs.ExistsReturns(true, nil)
+			fakeFs.ReadFileReturns(nil, errors.New("some-error"))
+
+			_, err := NewFile(fakeFs, storeFile).Status()
+			Expect(err).To(MatchError("failed to load pools: failed to read store file: some-error"))
+		})
+	})
+
+	Context("when writing the file fails", func() {
+		It("returns an error", func() {
+			fakeFs := new(storefakes.FakeFs)
+			fakeFs.WriteFileReturns(errors.New("some-error"))
+
+			err := NewFile(fakeFs, storeFile).CreatePool("some-pool", "some-user", locker.Metadata{})
+			Expect(err).To(MatchError("failed to save pools: failed to write store file: some-error"))
+			Expect(fakeFs.MvCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when replacing the file fails", func() {
+		It("returns an error", func() {
+			fakeFs := new(storefakes.FakeFs)
+			fakeFs.MvReturns(errors.New("some-error"))
+
+			err := NewFile(fakeFs, storeFile).CreatePool("some-pool", "some-user", locker.Metadata{})
+			Expect(err).To(MatchError("failed to save pools: failed to replace store file: some-error"))
+		})
+	})
+})
@@ -0,0 +1,35 @@
+package store
+
+type memory struct {
+	pools map[string]*pool
+}
+
+// NewMemory returns a locker which keeps pools in memory, so they are lost
+// when claimer exits.
+func NewMemory() *store {
+	return &store{backend: &memory{pools: map[string]*pool{}}}
+}
+
+// lock does nothing, as a memory store cannot be shared between processes
+func (m *memory) lock() (func(), error) {
+	return func() {}, nil
+}
+
+func (m *memory) load() (map[string]*pool, error) {
+	return copyPools(m.pools), nil
+}
+
+func (m *memory) save(pools map[string]*pool) error {
+	m.pools = copyPools(pools)
+	return nil
+}
+
+func copyPools(pools map[string]*pool) map[string]*pool {
+	copied := map[string]*pool{}
+	for name, p := range pools {
+		c := *p
+		c.Claims = append([]claim(nil), p.Claims...)
+		copied[name] = &c
+	}
+	return copied
+}
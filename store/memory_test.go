@@ -0,0 +1,33 @@
+package store_test
+
+import (
+	. "github.com/mdelillo/claimer/store"
+
+	"time"
+
+	"github.com/mdelillo/claimer/locker"
+	"github.com/mdelillo/claimer/locker/lockertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Memory", func() {
+	lockertest.DescribeLocker(func() locker.Locker {
+		return NewMemory()
+	})
+
+	It("explains why an operation is not possible", func() {
+		store := NewMemory()
+		Expect(store.CreatePool("some-pool", "some-user", locker.Metadata{})).To(Succeed())
+
+		Expect(store.CreatePool("some-pool", "some-user", locker.Metadata{})).To(MatchError("pool some-pool already exists"))
+		Expect(store.DestroyPool("some-other-pool", "some-user")).To(MatchError("pool some-other-pool does not exist"))
+		Expect(store.ClaimLock("some-other-pool", "some-user", "")).To(MatchError("pool some-other-pool does not exist"))
+		Expect(store.ReleaseLock("some-pool", "some-user")).To(MatchError("no claimed locks for pool some-pool"))
+		Expect(store.AnnotateLock("some-pool", "some-user", "")).To(MatchError("pool some-pool is not claimed"))
+		Expect(store.ExtendLock("some-pool", "some-user", time.Now())).To(MatchError("pool some-pool is not claimed"))
+
+		Expect(store.ClaimLock("some-pool", "some-user", "")).To(Succeed())
+		Expect(store.ClaimLock("some-pool", "some-user", "")).To(MatchError("no unclaimed locks for pool some-pool"))
+	})
+})
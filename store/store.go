@@ -0,0 +1,389 @@
+// Package store contains backends for locker.Locker which do not need a git
+// repo: an in-memory store for tests and demos, and a store which keeps every
+// pool in a single local file.
+package store
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mdelillo/claimer/locker"
+	"github.com/pkg/errors"
+)
+
+type pool struct {
+	Creator         string    `yaml:"creator"`
+	Created         time.Time `yaml:"created"`
+	Claims          []claim   `yaml:"claims,omitempty"`
+	locker.Metadata `yaml:",inline"`
+
+	Reservations []locker.Reservation `yaml:"reservations,omitempty"`
+	Maintenance  *locker.Maintenance  `yaml:"maintenance,omitempty"`
+}
+
+// claim is a claim of a pool. Only the last claim of a pool can be active.
+type claim struct {
+	Owner      string    `yaml:"owner"`
+	Message    string    `yaml:"message,omitempty"`
+	Claimed    time.Time `yaml:"claimed"`
+	Expires    time.Time `yaml:"expires,omitempty"`
+	Released   time.Time `yaml:"released,omitempty"`
+	ReleasedBy string    `yaml:"released_by,omitempty"`
+}
+
+func (p *pool) activeClaim() *claim {
+	if len(p.Claims) == 0 || !p.Claims[len(p.Claims)-1].Released.IsZero() {
+		return nil
+	}
+	return &p.Claims[len(p.Claims)-1]
+}
+
+// backend loads and saves the pools, keyed by name. lock keeps other
+// processes sharing the backend out until the returned unlock is called.
+type backend interface {
+	lock() (unlock func(), err error)
+	load() (map[string]*pool, error)
+	save(pools map[string]*pool) error
+}
+
+type store struct {
+	backend backend
+
+	mutex sync.Mutex
+}
+
+func (s *store) AnnotateLock(name, user, message string) error {
+	return s.update(func(pools map[string]*pool) error {
+		c, err := activeClaim(pools, name)
+		if err != nil {
+			return err
+		}
+		c.Message = message
+		return nil
+	})
+}
+
+func (s *store) ClaimAny(names []string, user, message string) (string, error) {
+	var claimed string
+	err := s.update(func(pools map[string]*pool) error {
+		for _, name := range names {
+			if p, ok := pools[name]; ok && p.activeClaim() == nil && p.Maintenance == nil {
+				p.Claims = append(p.Claims, claim{Owner: user, Message: message, Claimed: now()})
+				claimed = name
+				return nil
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return claimed, nil
+}
+
+func (s *store) ClaimLock(name, user, message string) error {
+	return s.ClaimLocks([]string{name}, user, message)
+}
+
+// ClaimLocks claims all of the pools or, since nothing is saved when the
+// change fails, none of them
+func (s *store) ClaimLocks(names []string, user, message string) error {
+	return s.update(func(pools map[string]*pool) error {
+		for _, name := range names {
+			p, ok := pools[name]
+			if !ok {
+				return errors.Errorf("pool %s does not exist", name)
+			}
+			if p.activeClaim() != nil || p.Maintenance != nil {
+				return errors.Errorf("no unclaimed locks for pool %s", name)
+			}
+			p.Claims = append(p.Claims, claim{Owner: user, Message: message, Claimed: now()})
+		}
+		return nil
+	})
+}
+
+func (s *store) CreatePool(name, user string, metadata locker.Metadata) error {
+	return s.update(func(pools map[string]*pool) error {
+		if _, ok := pools[name]; ok {
+			return errors.Errorf("pool %s already exists", name)
+		}
+		pools[name] = &pool{Creator: user, Created: now(), Metadata: metadata}
+		return nil
+	})
+}
+
+func (s *store) DestroyPool(name, user string) error {
+	return s.update(func(pools map[string]*pool) error {
+		if _, ok := pools[name]; !ok {
+			return errors.Errorf("pool %s does not exist", name)
+		}
+		delete(pools, name)
+		return nil
+	})
+}
+
+func (s *store) DisablePool(name, user, reason string) error {
+	return s.update(func(pools map[string]*pool) error {
+		p, ok := pools[name]
+		if !ok {
+			return errors.Errorf("pool %s does not exist", name)
+		}
+		if p.Maintenance != nil {
+			return errors.Errorf("pool %s is already disabled", name)
+		}
+		if p.activeClaim() != nil {
+			return errors.Errorf("no unclaimed locks for pool %s", name)
+		}
+		p.Maintenance = &locker.Maintenance{By: user, Reason: reason, Since: now()}
+		return nil
+	})
+}
+
+// Doctor finds no problems, since pools in a store cannot be malformed the
+// way directories in a repo can
+func (s *store) Doctor(fix bool, user string) ([]locker.Problem, error) {
+	return nil, nil
+}
+
+func (s *store) EnablePool(name, user string) error {
+	return s.update(func(pools map[string]*pool) error {
+		p, ok := pools[name]
+		if !ok || p.Maintenance == nil {
+			return errors.Errorf("pool %s is not disabled", name)
+		}
+		p.Maintenance = nil
+		return nil
+	})
+}
+
+func (s *store) ExtendLock(name, user string, expires time.Time) error {
+	return s.update(func(pools map[string]*pool) error {
+		c, err := activeClaim(pools, name)
+		if err != nil {
+			return err
+		}
+		c.Expires = expires
+		return nil
+	})
+}
+
+func (s *store) History(name string) (locker.History, error) {
+	pools, err := s.read()
+	if err != nil {
+		return locker.History{}, err
+	}
+
+	p, ok := pools[name]
+	if !ok {
+		return locker.History{Pool: name}, nil
+	}
+	return history(name, p), nil
+}
+
+func (s *store) Histories() ([]locker.History, error) {
+	pools, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	var histories []locker.History
+	for _, name := range sortedNames(pools) {
+		histories = append(histories, history(name, pools[name]))
+	}
+	return histories, nil
+}
+
+func (s *store) ReleaseLock(name, user string) error {
+	return s.ReleaseLocks([]string{name}, user)
+}
+
+func (s *store) ReleaseLocks(names []string, user string) error {
+	return s.update(func(pools map[string]*pool) error {
+		for _, name := range names {
+			p, ok := pools[name]
+			if !ok {
+				return errors.Errorf("pool %s does not exist", name)
+			}
+			c := p.activeClaim()
+			if c == nil {
+				return errors.Errorf("no claimed locks for pool %s", name)
+			}
+			c.Released = now()
+			c.ReleasedBy = user
+		}
+		return nil
+	})
+}
+
+func (s *store) RenamePool(name, newName, user string) error {
+	return s.update(func(pools map[string]*pool) error {
+		p, ok := pools[name]
+		if !ok {
+			return errors.Errorf("pool %s does not exist", name)
+		}
+		if newName == name || strings.HasPrefix(newName, name+"/") {
+			return errors.Errorf("cannot move pool %s into itself", name)
+		}
+		for existing := range pools {
+			if existing == newName || strings.HasPrefix(existing, newName+"/") {
+				return errors.Errorf("%s already exists", newName)
+			}
+		}
+		delete(pools, name)
+		pools[newName] = p
+		return nil
+	})
+}
+
+func (s *store) Reserve(name string, reservation locker.Reservation) error {
+	return s.update(func(pools map[string]*pool) error {
+		p, ok := pools[name]
+		if !ok {
+			return errors.Errorf("pool %s does not exist", name)
+		}
+		reservations := []locker.Reservation{reservation}
+		for _, r := range p.Reservations {
+			if !r.End.After(now()) {
+				continue
+			}
+			if r.Overlaps(reservation) {
+				return errors.Errorf("%s is already reserved by %s from %s until %s", name, r.Owner, r.Start.Format(locker.DateFormat), r.End.Format(locker.DateFormat))
+			}
+			reservations = append(reservations, r)
+		}
+		sort.Slice(reservations, func(i, j int) bool { return reservations[i].Start.Before(reservations[j].Start) })
+		p.Reservations = reservations
+		return nil
+	})
+}
+
+func (s *store) Status() ([]locker.Lock, error) {
+	pools, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	var locks []locker.Lock
+	for _, name := range sortedNames(pools) {
+		lock := locker.Lock{
+			Name:         name,
+			Metadata:     pools[name].Metadata,
+			Reservations: append([]locker.Reservation(nil), pools[name].Reservations...),
+			Maintenance:  pools[name].Maintenance,
+		}
+		if c := pools[name].activeClaim(); c != nil {
+			lock.Claimed = true
+			lock.Owner = c.Owner
+			lock.Date = c.Claimed.Format(locker.DateFormat)
+			lock.Message = c.Message
+			lock.Expires = c.Expires
+		}
+		locks = append(locks, lock)
+	}
+	return locks, nil
+}
+
+func (s *store) Unreserve(name string, reservation locker.Reservation) error {
+	return s.update(func(pools map[string]*pool) error {
+		p, ok := pools[name]
+		if !ok {
+			return errors.Errorf("pool %s does not exist", name)
+		}
+		var reservations []locker.Reservation
+		for _, r := range p.Reservations {
+			if r.Owner != reservation.Owner || !r.Start.Equal(reservation.Start) {
+				reservations = append(reservations, r)
+			}
+		}
+		if len(reservations) == len(p.Reservations) {
+			return errors.Errorf("%s has no reservation by %s starting %s", name, reservation.Owner, reservation.Start.Format(locker.DateFormat))
+		}
+		p.Reservations = reservations
+		return nil
+	})
+}
+
+func (s *store) read() (map[string]*pool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	unlock, err := s.backend.lock()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to lock pools")
+	}
+	defer unlock()
+
+	pools, err := s.backend.load()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load pools")
+	}
+	return pools, nil
+}
+
+// update applies a change to the pools and saves them. Nothing is saved if
+// the change fails.
+func (s *store) update(change func(map[string]*pool) error) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	unlock, err := s.backend.lock()
+	if err != nil {
+		return errors.Wrap(err, "failed to lock pools")
+	}
+	defer unlock()
+
+	pools, err := s.backend.load()
+	if err != nil {
+		return errors.Wrap(err, "failed to load pools")
+	}
+	if err := change(pools); err != nil {
+		return err
+	}
+	if err := s.backend.save(pools); err != nil {
+		return errors.Wrap(err, "failed to save pools")
+	}
+	return nil
+}
+
+func activeClaim(pools map[string]*pool, name string) (*claim, error) {
+	p, ok := pools[name]
+	if !ok {
+		return nil, errors.Errorf("pool %s does not exist", name)
+	}
+	c := p.activeClaim()
+	if c == nil {
+		return nil, errors.Errorf("pool %s is not claimed", name)
+	}
+	return c, nil
+}
+
+func history(name string, p *pool) locker.History {
+	h := locker.History{Pool: name, Creator: p.Creator, Created: p.Created}
+	for _, c := range p.Claims {
+		h.Claims = append(h.Claims, locker.Claim{
+			Owner:      c.Owner,
+			Message:    c.Message,
+			Claimed:    c.Claimed,
+			Released:   c.Released,
+			ReleasedBy: c.ReleasedBy,
+		})
+	}
+	return h
+}
+
+func sortedNames(pools map[string]*pool) []string {
+	var names []string
+	for name := range pools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// now is truncated to seconds to match the precision of dates in git
+func now() time.Time {
+	return time.Now().Truncate(time.Second)
+}
@@ -0,0 +1,287 @@
+// This file was generated by counterfeiter
+package storefakes
+
+import (
+	"sync"
+)
+
+type FakeFs struct {
+	ExistsStub        func(path string) (bool, error)
+	existsMutex       sync.RWMutex
+	existsArgsForCall []struct {
+		path string
+	}
+	existsReturns struct {
+		result1 bool
+		result2 error
+	}
+	existsReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	MvStub        func(src, dst string) error
+	mvMutex       sync.RWMutex
+	mvArgsForCall []struct {
+		src string
+		dst string
+	}
+	mvReturns struct {
+		result1 error
+	}
+	mvReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ReadFileStub        func(file string) ([]byte, error)
+	readFileMutex       sync.RWMutex
+	readFileArgsForCall []struct {
+		file string
+	}
+	readFileReturns struct {
+		result1 []byte
+		result2 error
+	}
+	readFileReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	WriteFileStub        func(file string, contents []byte) error
+	writeFileMutex       sync.RWMutex
+	writeFileArgsForCall []struct {
+		file     string
+		contents []byte
+	}
+	writeFileReturns struct {
+		result1 error
+	}
+	writeFileReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeFs) Exists(path string) (bool, error) {
+	fake.existsMutex.Lock()
+	ret, specificReturn := fake.existsReturnsOnCall[len(fake.existsArgsForCall)]
+	fake.existsArgsForCall = append(fake.existsArgsForCall, struct {
+		path string
+	}{path})
+	fake.recordInvocation("Exists", []interface{}{path})
+	fake.existsMutex.Unlock()
+	if fake.ExistsStub != nil {
+		return fake.ExistsStub(path)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.existsReturns.result1, fake.existsReturns.result2
+}
+
+func (fake *FakeFs) ExistsCallCount() int {
+	fake.existsMutex.RLock()
+	defer fake.existsMutex.RUnlock()
+	return len(fake.existsArgsForCall)
+}
+
+func (fake *FakeFs) ExistsArgsForCall(i int) string {
+	fake.existsMutex.RLock()
+	defer fake.existsMutex.RUnlock()
+	return fake.existsArgsForCall[i].path
+}
+
+func (fake *FakeFs) ExistsReturns(result1 bool, result2 error) {
+	fake.ExistsStub = nil
+	fake.existsReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFs) ExistsReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.ExistsStub = nil
+	if fake.existsReturnsOnCall == nil {
+		fake.existsReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.existsReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFs) Mv(src string, dst string) error {
+	fake.mvMutex.Lock()
+	ret, specificReturn := fake.mvReturnsOnCall[len(fake.mvArgsForCall)]
+	fake.mvArgsForCall = append(fake.mvArgsForCall, struct {
+		src string
+		dst string
+	}{src, dst})
+	fake.recordInvocation("Mv", []interface{}{src, dst})
+	fake.mvMutex.Unlock()
+	if fake.MvStub != nil {
+		return fake.MvStub(src, dst)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.mvReturns.result1
+}
+
+func (fake *FakeFs) MvCallCount() int {
+	fake.mvMutex.RLock()
+	defer fake.mvMutex.RUnlock()
+	return len(fake.mvArgsForCall)
+}
+
+func (fake *FakeFs) MvArgsForCall(i int) (string, string) {
+	fake.mvMutex.RLock()
+	defer fake.mvMutex.RUnlock()
+	return fake.mvArgsForCall[i].src, fake.mvArgsForCall[i].dst
+}
+
+func (fake *FakeFs) MvReturns(result1 error) {
+	fake.MvStub = nil
+	fake.mvReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeFs) MvReturnsOnCall(i int, result1 error) {
+	fake.MvStub = nil
+	if fake.mvReturnsOnCall == nil {
+		fake.mvReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.mvReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeFs) ReadFile(file string) ([]byte, error) {
+	fake.readFileMutex.Lock()
+	ret, specificReturn := fake.readFileReturnsOnCall[len(fake.readFileArgsForCall)]
+	fake.readFileArgsForCall = append(fake.readFileArgsForCall, struct {
+		file string
+	}{file})
+	fake.recordInvocation("ReadFile", []interface{}{file})
+	fake.readFileMutex.Unlock()
+	if fake.ReadFileStub != nil {
+		return fake.ReadFileStub(file)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.readFileReturns.result1, fake.readFileReturns.result2
+}
+
+func (fake *FakeFs) ReadFileCallCount() int {
+	fake.readFileMutex.RLock()
+	defer fake.readFileMutex.RUnlock()
+	return len(fake.readFileArgsForCall)
+}
+
+func (fake *FakeFs) ReadFileArgsForCall(i int) string {
+	fake.readFileMutex.RLock()
+	defer fake.readFileMutex.RUnlock()
+	return fake.readFileArgsForCall[i].file
+}
+
+func (fake *FakeFs) ReadFileReturns(result1 []byte, result2 error) {
+	fake.ReadFileStub = nil
+	fake.readFileReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFs) ReadFileReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.ReadFileStub = nil
+	if fake.readFileReturnsOnCall == nil {
+		fake.readFileReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.readFileReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFs) WriteFile(file string, contents []byte) error {
+	fake.writeFileMutex.Lock()
+	ret, specificReturn := fake.writeFileReturnsOnCall[len(fake.writeFileArgsForCall)]
+	fake.writeFileArgsForCall = append(fake.writeFileArgsForCall, struct {
+		file     string
+		contents []byte
+	}{file, contents})
+	fake.recordInvocation("WriteFile", []interface{}{file, contents})
+	fake.writeFileMutex.Unlock()
+	if fake.WriteFileStub != nil {
+		return fake.WriteFileStub(file, contents)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.writeFileReturns.result1
+}
+
+func (fake *FakeFs) WriteFileCallCount() int {
+	fake.writeFileMutex.RLock()
+	defer fake.writeFileMutex.RUnlock()
+	return len(fake.writeFileArgsForCall)
+}
+
+func (fake *FakeFs) WriteFileArgsForCall(i int) (string, []byte) {
+	fake.writeFileMutex.RLock()
+	defer fake.writeFileMutex.RUnlock()
+	return fake.writeFileArgsForCall[i].file, fake.writeFileArgsForCall[i].contents
+}
+
+func (fake *FakeFs) WriteFileReturns(result1 error) {
+	fake.WriteFileStub = nil
+	fake.writeFileReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeFs) WriteFileReturnsOnCall(i int, result1 error) {
+	fake.WriteFileStub = nil
+	if fake.writeFileReturnsOnCall == nil {
+		fake.writeFileReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeFileReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeFs) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.existsMutex.RLock()
+	defer fake.existsMutex.RUnlock()
+	fake.mvMutex.RLock()
+	defer fake.mvMutex.RUnlock()
+	fake.readFileMutex.RLock()
+	defer fake.readFileMutex.RUnlock()
+	fake.writeFileMutex.RLock()
+	defer fake.writeFileMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeFs) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
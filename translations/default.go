@@ -1,13 +1,31 @@
 package translations
 
 const helpText = "    ```\n" +
-	"      claim <env> [<message>]   Claim an unclaimed environment\n" +
-	"      create <env>              Create a new environment\n" +
+	"      claim <env>[, <env>]... [<message>]\n" +
+	"                                Claim unclaimed environments, all or none of them\n" +
+	"      claim <group> [<message>] Claim any unclaimed environment in a group, e.g. aws/us-east\n" +
+	"      claim any:<tag> [AND [NOT] <tag>]... [<message>]\n" +
+	"                                Claim the first unclaimed environment with the tags\n" +
+	"      create <env> [--description <text>] [--tag <tag>]...\n" +
+	"                                Create a new environment\n" +
 	"      destroy <env>             Destroy an environment\n" +
+	"      disable <env> [<reason>]  Take an environment out of use, e.g. while repaving\n" +
+	"      doctor [--fix]            Find (and repair) malformed environments\n" +
+	"      enable <env>              Put a disabled environment back in use\n" +
+	"      extend <env> <duration>   Extend your claim on an environment (e.g. 4h, 2d)\n" +
+	"      history <env> [<count>]   Show who has claimed an environment recently\n" +
+	"      move <env> <group>        Move an environment into a group, keeping its claim\n" +
+	"      note <env> <message>      Update the message on your claim\n" +
 	"      notify                    Notify all owners of claimed environments\n" +
 	"      owner <env>               Show the user who claimed the environment\n" +
-	"      release <env>             Release a claimed environment\n" +
-	"      status                    Show claimed and unclaimed environments\n" +
+	"      release <env>[, <env>]...\n" +
+	"                                Release claimed environments, all or none of them\n" +
+	"      rename <env> <new-name>   Rename an environment, keeping its claim\n" +
+	"      reserve <env> <start> <end> [<message>]\n" +
+	"                                Reserve an environment, e.g. 2017-03-01T09:00 4h\n" +
+	"      stats [<env>] [--since <duration>]\n" +
+	"                                Show utilization and top claimers (default: last 30d)\n" +
+	"      status [<group>]          Show claimed and unclaimed environments\n" +
 	"      help                      Display this message\n" +
 	"    ```"
 const DefaultTranslations = `---
@@ -16,29 +34,142 @@ claim:
   pool_is_already_claimed: "{{.pool}} is already claimed"
   pool_does_not_exist: "{{.pool}} does not exist"
   no_pool: "must specify pool to claim"
+  no_tag: "must specify tags to claim by, e.g. any:gcp AND NOT us-east"
+  no_pool_matches: "no unclaimed pool matches {{.selector}}"
+  group_is_fully_claimed: "every pool in {{.group}} is claimed"
+  pool_is_reserved: "{{.pool}} is reserved by {{.owner}} until {{.end}}"
+  pool_is_disabled: "{{.pool}} is disabled by {{.user}}"
 create:
   success: "Created {{.pool}}"
   pool_already_exists: "{{.pool}} already exists"
+  parent_is_pool: "{{.pool}} cannot be created inside {{.parent}}, which is a pool"
+  invalid_name: "{{.pool}} is not a valid pool name"
   no_pool: "must specify name of pool to create"
 destroy:
   success: "Destroyed {{.pool}}"
   pool_does_not_exist: "{{.pool}} does not exist"
   no_pool: "must specify pool to destroy"
+disable:
+  success: "Disabled {{.pool}}"
+  pool_does_not_exist: "{{.pool}} does not exist"
+  pool_is_already_disabled: "{{.pool}} is already disabled"
+  pool_is_claimed: "{{.pool}} is claimed by {{.owner}}, it must be released first"
+  no_pool: "must specify pool to disable"
+doctor:
+  healthy: "No problems found"
+  header: "Found problems with these pools:"
+  missing_dir: "{{.pool}}: {{.file}} is missing"
+  no_lock: "{{.pool}}: has no lock"
+  too_many_locks: "{{.pool}}: has more than one lock ({{.file}})"
+  orphaned_lock: "{{.pool}}: {{.file}} is not in claimed or unclaimed"
+  stray_file: "{{.pool}}: {{.file}} should not be there"
+  lock_name_mismatch: "{{.pool}}: lock {{.file}} is not named after the pool"
+  stale_claim_file: "{{.pool}}: has a claim file but is not claimed"
+  fixed: "(fixed)"
+` +
+	"  fix_hint: \"Run `doctor --fix` to repair what can be repaired\"\n" +
+	`enable:
+  success: "Enabled {{.pool}}"
+  pool_does_not_exist: "{{.pool}} does not exist"
+  pool_is_not_disabled: "{{.pool}} is not disabled"
+  no_pool: "must specify pool to enable"
+extend:
+  success: "Extended {{.pool}} until {{.expires}}"
+  pool_does_not_exist: "{{.pool}} does not exist"
+  pool_is_not_claimed: "{{.pool}} is not claimed"
+  not_owner: "{{.pool}} is claimed by {{.owner}}"
+  invalid_duration: "{{.duration}} is not a valid duration"
+  no_pool: "must specify pool to extend"
+  no_duration: "must specify duration to extend by"
+history:
+  header: "Recent claims of {{.pool}}:"
+  claim: "{{.owner}} claimed it on {{.date}} for {{.duration}}"
+  active_claim: "{{.owner}} has claimed it since {{.date}} ({{.duration}} so far)"
+  released_by: "released by {{.user}}"
+  created: "Created by {{.user}} on {{.date}}"
+  no_claims: "{{.pool}} has never been claimed"
+  pool_does_not_exist: "{{.pool}} does not exist"
+  invalid_count: "{{.count}} is not a valid number of claims"
+  no_pool: "must specify pool"
+move:
+  success: "Moved {{.pool}} to {{.name}}"
+  pool_does_not_exist: "{{.pool}} does not exist"
+  pool_already_exists: "{{.pool}} already exists"
+  invalid_name: "{{.pool}} is not a valid pool name"
+  no_pool: "must specify pool to move"
+  no_group: "must specify group to move to"
+note:
+  success: "Updated message on {{.pool}}"
+  pool_does_not_exist: "{{.pool}} does not exist"
+  pool_is_not_claimed: "{{.pool}} is not claimed"
+  not_owner: "{{.pool}} is claimed by {{.owner}}"
+  no_pool: "must specify pool to annotate"
+  no_message: "must specify message"
 notify:
   success: "Currently claimed locks, please release if not in use:\n{{.mentions}}"
   empty: "No locks currently claimed."
 owner:
   success: "{{.pool}} was claimed by {{.owner}} on {{.date}}"
+  expires: "expires {{.expires}}"
+  description: "*Description:* {{.description}}"
+  team: "*Team:* {{.team}}"
+  links: "*Links:* {{.links}}"
+  reservation: "*Reserved* by {{.owner}} from {{.start}} until {{.end}}"
   pool_does_not_exist: "{{.pool}} does not exist"
   pool_is_not_claimed: "{{.pool}} is not claimed"
+  pool_is_disabled: "{{.pool}} was disabled by {{.user}} on {{.date}}"
   no_pool: "must specify pool"
 release:
   success: "Released {{.pool}}"
   pool_does_not_exist: "{{.pool}} does not exist"
   pool_is_not_claimed: "{{.pool}} is not claimed"
+  pool_is_disabled: "{{.pool}} is disabled, enable it instead"
   no_pool: "must specify pool to release"
+reserve:
+  success: "Reserved {{.pool}} from {{.start}} until {{.end}}"
+  pool_does_not_exist: "{{.pool}} does not exist"
+  conflict: "{{.pool}} is already reserved by {{.owner}} from {{.start}} until {{.end}}"
+  invalid_time: "{{.time}} is not a valid time, e.g. 2017-03-01T09:00 or 2017-03-01"
+  ends_before_start: "a reservation must end after it starts"
+  in_the_past: "a reservation must end in the future"
+  no_pool: "must specify pool to reserve"
+  no_window: "must specify when the reservation starts and ends"
+  started: "Claimed {{.pool}} for <@{{.owner}}>, who reserved it until {{.end}}"
+  blocked: "<@{{.claimer}}> please release {{.pool}}, it is reserved by <@{{.owner}}> until {{.end}}"
+  ended: "Released {{.pool}}, the reservation of <@{{.owner}}> has ended"
+rename:
+  success: "Renamed {{.pool}} to {{.name}}"
+  pool_does_not_exist: "{{.pool}} does not exist"
+  pool_already_exists: "{{.pool}} already exists"
+  invalid_name: "{{.pool}} is not a valid pool name"
+  no_pool: "must specify pool to rename"
+  no_name: "must specify new name"
+` +
+	"remind:\n" +
+	"  question: \"Are you still using {{.pool}}? You claimed it on {{.date}}. Reply `keep` to hold on to it or `release` to release it.\"\n" +
+	"  escalate: \"<@{{.owner}}> has held {{.pool}} since {{.date}} and has not answered {{.reminders}} reminders, please release it if it is not in use\"\n" +
+	"  kept: \"Okay, I'll leave {{.pool}} claimed\"\n" +
+	"  released: \"Released {{.pool}}\"\n" +
+	"  which_pool: \"You have been asked about {{.pools}}, please reply with `keep <env>` or `release <env>`\"\n" +
+	"  not_reminded: \"You have not been asked about {{.pool}}\"\n" +
+	"  nothing_to_answer: \"You have not been asked about any environments\"\n" +
+	"  unknown_reply: \"Reply `keep [<env>]` to hold on to an environment or `release [<env>]` to release it\"\n" +
+	`stats:
+  header: "Usage since {{.since}}:"
+  utilization_header: "*Utilization:*"
+  pool: "{{.pool}}: {{.utilization}}% claimed, {{.claims}} claims, mean claim {{.mean}}"
+  top_claimers_header: "*Top claimers:*"
+  claimer: "{{.user}}: {{.duration}} across {{.claims}} claims"
+  current_claims_header: "*Longest current claims:*"
+  current_claim: "{{.pool}}: {{.owner}} for {{.duration}}"
+  pool_does_not_exist: "{{.pool}} does not exist"
+  invalid_since: "{{.since}} is not a valid duration"
 status:
   success: "*Claimed by you:* {{.usersClaimed}}\n*Claimed by others:* {{.otherClaimed}}\n*Unclaimed:* {{.unclaimed}}"
+  described_pool: "{{.pool}} ({{.description}})"
+  disabled: "*Disabled:* {{.disabled}}"
+  disabled_pool: "{{.pool}} ({{.reason}})"
+  group_does_not_exist: "{{.group}} does not exist"
 ` +
 	"unknown_command: \"Unknown command. Try `@claimer help` to see usage.\"\n" +
 	"help:\n" +